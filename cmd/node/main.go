@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"regexp"
 	"sync"
 	"syscall"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"github.com/etlmon/etlmon/internal/api"
 	"github.com/etlmon/etlmon/internal/collector/disk"
 	logcollector "github.com/etlmon/etlmon/internal/collector/log"
+	"github.com/etlmon/etlmon/internal/collector/partition"
+	"github.com/etlmon/etlmon/internal/collector/pipeline"
 	"github.com/etlmon/etlmon/internal/collector/path"
 	"github.com/etlmon/etlmon/internal/collector/process"
 	"github.com/etlmon/etlmon/internal/config"
@@ -28,7 +31,10 @@ type collectorManager struct {
 	mu               sync.Mutex
 	diskCollector    *disk.DiskCollector
 	pathScanner      *path.PathScanner
+	partitionChecker *partition.Checker
+	pipelineSampler  *pipeline.Sampler
 	processCollector *process.Collector
+	deletedFiles     *process.DeletedFilesCollector
 	logTailer        *logcollector.LogTailer
 }
 
@@ -40,36 +46,143 @@ func newCollectorManager(repo *repository.Repository, parentCtx context.Context)
 }
 
 func (m *collectorManager) startAll(cfg *config.NodeConfig) error {
-	// Start disk collector (static, not affected by config changes)
-	m.diskCollector = disk.NewDiskCollector(m.repo.FS, cfg.Refresh.Disk)
-	if err := m.diskCollector.Start(m.parentCtx); err != nil {
-		return fmt.Errorf("failed to start disk collector: %w", err)
+	// Deleted files scanner (static: the API server holds it)
+	m.deletedFiles = process.NewDeletedFilesCollector(process.NewInspector(), cfg.Refresh.DeletedFiles)
+	if err := m.deletedFiles.Start(m.parentCtx); err != nil {
+		return fmt.Errorf("failed to start deleted files scanner: %w", err)
 	}
-	slog.Info("disk collector started", "interval", cfg.Refresh.Disk)
+	slog.Info("deleted files scanner started", "interval", cfg.Refresh.DeletedFiles)
 
 	return m.startDynamic(cfg)
 }
 
 func (m *collectorManager) startDynamic(cfg *config.NodeConfig) error {
+	// Disk collector
+	diskConfig := disk.Config{
+		Include:       cfg.Disk.Include,
+		Exclude:       cfg.Disk.Exclude,
+		Types:         cfg.Disk.Types,
+		ExcludeTypes:  cfg.Disk.ExcludeTypes,
+		StatfsTimeout: cfg.Disk.StatfsTimeout,
+	}
+	m.diskCollector = disk.NewDiskCollector(m.repo.FS, cfg.Refresh.Disk, diskConfig)
+	if err := m.diskCollector.Start(m.parentCtx); err != nil {
+		return fmt.Errorf("failed to start disk collector: %w", err)
+	}
+	slog.Info("disk collector started", "interval", cfg.Refresh.Disk)
+
+	// Pipeline stages are measured from the file events of their paths
+	stagePaths := make(map[string]bool)
+	for _, p := range cfg.Pipelines {
+		for _, stage := range p.Stages {
+			stagePaths[stage.Path] = true
+		}
+	}
+
 	// Path scanner
 	pathConfigs := make([]path.PathConfig, len(cfg.Paths))
 	for i, p := range cfg.Paths {
 		pathConfigs[i] = path.PathConfig{
-			Path:         p.Path,
-			ScanInterval: p.ScanInterval,
-			MaxDepth:     p.MaxDepth,
-			Exclude:      p.Exclude,
-			Timeout:      p.Timeout,
+			Path:             p.Path,
+			ScanInterval:     p.ScanInterval,
+			MaxDepth:         p.MaxDepth,
+			Include:          p.Include,
+			Exclude:          p.Exclude,
+			Timeout:          p.Timeout,
+			MaxFileAge:       p.MaxFileAge,
+			MaxFileCount:     p.MaxFileCount,
+			Track:            p.Track || stagePaths[p.Path],
+			Workers:          p.Workers,
+			MaxOpsPerSec:     p.MaxOpsPerSec,
+			Incremental:      p.Incremental,
+			FullScanInterval: p.FullScanInterval,
+			SkipHidden:       p.SkipHidden,
+			Symlinks:         p.Symlinks,
+			OneFilesystem:    p.OneFilesystem,
+			Buckets:          p.Buckets,
+			BreakdownDepth:   p.BreakdownDepth,
+			ExpandInterval:   p.ExpandInterval,
+			Watch:            p.Watch,
+			ExpectedCadence:  p.ExpectedCadence,
 		}
 	}
+	prevScanner := m.pathScanner
 	m.pathScanner = path.NewPathScanner(m.repo.Paths, pathConfigs)
+	if prevScanner != nil {
+		// Keep the scan jobs of the replaced scanner queryable
+		m.pathScanner.AdoptJobs(prevScanner)
+	}
 	m.pathScanner.Start(m.parentCtx)
 	slog.Info("path scanner started", "paths", len(cfg.Paths))
 
+	// Partition checker; started without checks too, so that the
+	// partitions of removed checks are cleared
+	checks := make([]partition.CheckConfig, len(cfg.Partitions))
+	for i, p := range cfg.Partitions {
+		loc, err := time.LoadLocation(p.Timezone)
+		if err != nil {
+			return fmt.Errorf("partition check %s: %w", p.Name, err)
+		}
+		checks[i] = partition.CheckConfig{
+			Name:     p.Name,
+			Path:     p.Path,
+			Marker:   p.Marker,
+			Deadline: p.Deadline,
+			Lookback: p.Lookback,
+			Interval: p.CheckInterval,
+			Location: loc,
+		}
+	}
+	m.partitionChecker = partition.NewChecker(m.repo.Partitions, checks)
+	if err := m.partitionChecker.Start(m.parentCtx); err != nil {
+		return fmt.Errorf("failed to start partition checker: %w", err)
+	}
+	slog.Info("partition checker started", "checks", len(checks))
+
+	// Pipeline sampler; started without pipelines too, so that the
+	// samples of removed pipelines are cleared
+	pipelines := make([]pipeline.PipelineConfig, len(cfg.Pipelines))
+	for i, p := range cfg.Pipelines {
+		stages := make([]pipeline.StageConfig, len(p.Stages))
+		for j, stage := range p.Stages {
+			stages[j] = pipeline.StageConfig{Name: stage.Name, Path: stage.Path}
+		}
+		pipelines[i] = pipeline.PipelineConfig{
+			Name:     p.Name,
+			Stages:   stages,
+			Interval: p.SampleInterval,
+		}
+	}
+	m.pipelineSampler = pipeline.NewSampler(m.repo.Pipelines, m.repo.Paths, pipelines)
+	if err := m.pipelineSampler.Start(m.parentCtx); err != nil {
+		return fmt.Errorf("failed to start pipeline sampler: %w", err)
+	}
+	slog.Info("pipeline sampler started", "pipelines", len(pipelines))
+
 	// Process collector
+	watches := make([]process.WatchConfig, len(cfg.ProcessWatch))
+	for i, w := range cfg.ProcessWatch {
+		var match *regexp.Regexp
+		if w.Match != "" {
+			re, err := regexp.Compile(w.Match)
+			if err != nil {
+				return fmt.Errorf("process watch %s: %w", w.Name, err)
+			}
+			match = re
+		}
+		watches[i] = process.WatchConfig{
+			Name:  w.Name,
+			Match: match,
+			User:  w.User,
+			Min:   w.Min,
+			Max:   w.Max,
+		}
+	}
 	procConfig := process.Config{
-		Patterns: cfg.Process.Patterns,
-		TopN:     cfg.Process.TopN,
+		Patterns:         cfg.Process.Patterns,
+		TopN:             cfg.Process.TopN,
+		HistoryRetention: cfg.Process.HistoryRetention,
+		Watches:          watches,
 	}
 	m.processCollector = process.NewCollector(m.repo.Process, cfg.Refresh.Process, procConfig)
 	if err := m.processCollector.Start(m.parentCtx); err != nil {
@@ -98,6 +211,9 @@ func (m *collectorManager) startDynamic(cfg *config.NodeConfig) error {
 }
 
 func (m *collectorManager) stopDynamic() {
+	if m.diskCollector != nil {
+		m.diskCollector.Stop()
+	}
 	if m.processCollector != nil {
 		m.processCollector.Stop()
 	}
@@ -108,6 +224,14 @@ func (m *collectorManager) stopDynamic() {
 	if m.pathScanner != nil {
 		m.pathScanner.Stop()
 	}
+	if m.partitionChecker != nil {
+		m.partitionChecker.Stop()
+		m.partitionChecker = nil
+	}
+	if m.pipelineSampler != nil {
+		m.pipelineSampler.Stop()
+		m.pipelineSampler = nil
+	}
 }
 
 func (m *collectorManager) reload(cfg *config.NodeConfig) error {
@@ -123,8 +247,8 @@ func (m *collectorManager) stopAll() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.diskCollector != nil {
-		m.diskCollector.Stop()
+	if m.deletedFiles != nil {
+		m.deletedFiles.Stop()
 	}
 	m.stopDynamic()
 }
@@ -171,6 +295,9 @@ func main() {
 	// Create and start API server
 	server := api.NewServer(cfg.Node.Listen, repo, cfg.Node.NodeName, *configPath)
 	server.SetPathScanner(cm.pathScanner)
+	inspector := process.NewInspector()
+	server.SetProcessInspector(inspector)
+	server.SetDeletedFilesScanner(cm.deletedFiles)
 
 	// Set config reload callback
 	server.SetConfigReloadCallback(func() {
@@ -1,16 +1,25 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/etlmon/etlmon/internal/db/repository"
 	"github.com/etlmon/etlmon/pkg/models"
 )
 
+// DeletedFilesScanner reports the deleted files that processes still hold
+// open, from its last scan
+type DeletedFilesScanner interface {
+	DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error)
+}
+
 // FSHandler handles filesystem usage API requests
 type FSHandler struct {
-	repo *repository.FSRepository
+	repo    *repository.FSRepository
+	deleted DeletedFilesScanner // Optional scanner for deleted-but-open files
 }
 
 // NewFSHandler creates a new filesystem handler
@@ -18,6 +27,11 @@ func NewFSHandler(repo *repository.FSRepository) *FSHandler {
 	return &FSHandler{repo: repo}
 }
 
+// SetDeletedScanner sets the deleted files scanner (optional)
+func (h *FSHandler) SetDeletedScanner(scanner DeletedFilesScanner) {
+	h.deleted = scanner
+}
+
 // List handles GET /api/v1/fs
 func (h *FSHandler) List(w http.ResponseWriter, r *http.Request) {
 	usages, err := h.repo.ListAll()
@@ -38,6 +52,29 @@ func (h *FSHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// Deleted handles GET /api/v1/fs/deleted
+func (h *FSHandler) Deleted(w http.ResponseWriter, r *http.Request) {
+	if h.deleted == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("deleted files scanner not configured"))
+		return
+	}
+
+	report, err := h.deleted.DeletedFiles(r.Context())
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		writeError(w, http.StatusNotImplemented, err)
+		return
+	case errors.Is(err, models.ErrDeletedFilesPending):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: report})
+}
+
 // writeJSON writes a JSON response
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,6 +30,12 @@ func setupTestDB(t *testing.T) *sql.DB {
 			used_bytes INTEGER NOT NULL,
 			avail_bytes INTEGER NOT NULL,
 			used_percent REAL NOT NULL,
+			inodes_total INTEGER NOT NULL DEFAULT 0,
+			inodes_used INTEGER NOT NULL DEFAULT 0,
+			inodes_free INTEGER NOT NULL DEFAULT 0,
+			inodes_used_percent REAL NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT 'OK',
+			status_reason TEXT NOT NULL DEFAULT '',
 			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
 	`
@@ -139,3 +147,69 @@ func TestFSHandler_List_RepoError_Returns500(t *testing.T) {
 		t.Error("expected error message, got empty string")
 	}
 }
+
+// stubDeletedScanner returns a fixed deleted files report or an error
+type stubDeletedScanner struct {
+	report *models.DeletedFilesReport
+	err    error
+}
+
+func (s *stubDeletedScanner) DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	return s.report, s.err
+}
+
+func TestFSHandler_Deleted_ReturnsReport(t *testing.T) {
+	handler := NewFSHandler(nil)
+	handler.SetDeletedScanner(&stubDeletedScanner{report: &models.DeletedFilesReport{
+		TotalBytes: 200 << 30,
+		Mounts:     []models.DeletedFilesMount{{MountPoint: "/data", Bytes: 200 << 30, Files: 1}},
+		Processes:  []models.DeletedFilesProcess{{PID: 4242, Name: "java", Bytes: 200 << 30, Files: 1}},
+		Files:      []models.DeletedFile{{Path: "/data/logs/loader.log", Size: 200 << 30, MountPoint: "/data", PIDs: []int{4242}}},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/fs/deleted", nil)
+	w := httptest.NewRecorder()
+	handler.Deleted(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data models.DeletedFilesReport `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data.Mounts) != 1 || resp.Data.Mounts[0].Bytes != 200<<30 || resp.Data.Files[0].PIDs[0] != 4242 {
+		t.Errorf("unexpected report: %+v", resp.Data)
+	}
+}
+
+func TestFSHandler_Deleted_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		scanner DeletedFilesScanner
+		want    int
+	}{
+		{"no scanner", nil, http.StatusNotImplemented},
+		{"unsupported", &stubDeletedScanner{err: errors.ErrUnsupported}, http.StatusNotImplemented},
+		{"not scanned yet", &stubDeletedScanner{err: models.ErrDeletedFilesPending}, http.StatusServiceUnavailable},
+		{"scan failed", &stubDeletedScanner{err: errors.New("failed to list processes")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewFSHandler(nil)
+			if tt.scanner != nil {
+				handler.SetDeletedScanner(tt.scanner)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/fs/deleted", nil)
+			w := httptest.NewRecorder()
+			handler.Deleted(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// PartitionsHandler handles partition check API requests
+type PartitionsHandler struct {
+	repo *repository.PartitionsRepository
+}
+
+// NewPartitionsHandler creates a new partitions handler
+func NewPartitionsHandler(repo *repository.PartitionsRepository) *PartitionsHandler {
+	return &PartitionsHandler{repo: repo}
+}
+
+// List handles GET /api/v1/partitions
+func (h *PartitionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	status := query.Get("status")
+	switch status {
+	case "", models.PartitionPresent, models.PartitionPending, models.PartitionLate, models.PartitionMissing:
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("invalid status parameter, expected PRESENT, PENDING, LATE or MISSING"))
+		return
+	}
+
+	partitions, err := h.repo.ListPartitionStatus(r.Context(), query.Get("check"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	result := []models.PartitionStatus{}
+	for _, p := range partitions {
+		if status == "" || p.Status == status {
+			result = append(result, p)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: result})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func setupPartitionsTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+
+	schema := `
+		CREATE TABLE partition_status (
+			check_name TEXT NOT NULL,
+			partition TEXT NOT NULL,
+			period_start DATETIME NOT NULL,
+			due DATETIME NOT NULL,
+			status TEXT NOT NULL,
+			arrived_at DATETIME,
+			checked_at DATETIME NOT NULL,
+			PRIMARY KEY (check_name, partition)
+		);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	return db
+}
+
+func TestPartitionsHandler_List_FiltersByCheckAndStatus(t *testing.T) {
+	db := setupPartitionsTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPartitionsRepository(db)
+	now := time.Now()
+	save := func(check string, statuses ...string) {
+		var parts []*models.PartitionStatus
+		for i, status := range statuses {
+			start := now.AddDate(0, 0, -i)
+			parts = append(parts, &models.PartitionStatus{
+				Partition:   "/warehouse/" + check + "/dt=" + start.Format("2006-01-02"),
+				PeriodStart: start,
+				Due:         start.Add(30 * time.Hour),
+				Status:      status,
+				CheckedAt:   now,
+			})
+		}
+		if err := repo.SavePartitionStatus(context.Background(), check, parts); err != nil {
+			t.Fatalf("failed to insert test data: %v", err)
+		}
+	}
+	save("events", models.PartitionPending, models.PartitionPresent, models.PartitionMissing)
+	save("clicks", models.PartitionPending, models.PartitionLate)
+
+	handler := NewPartitionsHandler(repo)
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 5},
+		{"?check=events", 3},
+		{"?status=MISSING", 1},
+		{"?check=clicks&status=PENDING", 1},
+		{"?check=unknown", 0},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/partitions"+tt.query, nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", tt.query, http.StatusOK, w.Code)
+		}
+		var response struct {
+			Data []models.PartitionStatus `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Data == nil || len(response.Data) != tt.want {
+			t.Errorf("%s: expected %d partitions, got %d", tt.query, tt.want, len(response.Data))
+		}
+	}
+}
+
+func TestPartitionsHandler_List_InvalidStatus(t *testing.T) {
+	db := setupPartitionsTestDB(t)
+	defer db.Close()
+
+	handler := NewPartitionsHandler(repository.NewPartitionsRepository(db))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/partitions?status=DONE", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -1,18 +1,24 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/etlmon/etlmon/internal/db/repository"
 	"github.com/etlmon/etlmon/pkg/models"
 )
 
-// PathScanner interface for triggering path scans
+// PathScanner interface for running scan jobs and listing files
 type PathScanner interface {
-	ScanPaths(paths []string) error
+	SubmitScan(paths []string) (*models.ScanJob, error)
+	GetScanJob(id string) (*models.ScanJob, error)
+	CancelScanJob(id string) (*models.ScanJob, error)
+	ListFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error)
 }
 
 // PathsHandler handles path statistics API requests
@@ -102,6 +108,154 @@ func (h *PathsHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// Violations handles GET /api/v1/paths/violations
+func (h *PathsHandler) Violations(w http.ResponseWriter, r *http.Request) {
+	violations, err := h.repo.ListViolations(r.Context(), r.URL.Query().Get("path"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if violations == nil {
+		violations = []models.PathViolation{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: violations})
+}
+
+// Breakdown handles GET /api/v1/paths/breakdown
+func (h *PathsHandler) Breakdown(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		writeError(w, http.StatusBadRequest, errors.New("path parameter is required"))
+		return
+	}
+
+	usage, err := h.repo.ListPathBreakdown(r.Context(), path)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if usage == nil {
+		usage = []models.PathDirUsage{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: usage})
+}
+
+// defaultEventsLimit is the number of events returned when no limit is given
+const defaultEventsLimit = 500
+
+// Events handles GET /api/v1/paths/events
+func (h *PathsHandler) Events(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("invalid since parameter, expected RFC3339"))
+			return
+		}
+	}
+
+	limit := defaultEventsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid limit parameter"))
+			return
+		}
+	}
+
+	events, err := h.repo.ListPathEvents(r.Context(), query.Get("path"), since, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if events == nil {
+		events = []models.PathEvent{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: events})
+}
+
+// File listing limits
+const (
+	defaultFilesLimit = 100
+	maxFilesLimit     = 1000
+)
+
+// Files handles GET /api/v1/paths/files
+func (h *PathsHandler) Files(w http.ResponseWriter, r *http.Request) {
+	if h.scanner == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("path scanner not configured"))
+		return
+	}
+
+	query := r.URL.Query()
+	path := query.Get("path")
+	if path == "" {
+		writeError(w, http.StatusBadRequest, errors.New("path parameter is required"))
+		return
+	}
+
+	q := models.PathFilesQuery{
+		Sort:    query.Get("sort"),
+		Order:   query.Get("order"),
+		Limit:   defaultFilesLimit,
+		Pattern: query.Get("pattern"),
+	}
+	if q.Sort == "" {
+		q.Sort = models.PathFileSortSize
+	}
+
+	switch q.Sort {
+	case models.PathFileSortSize, models.PathFileSortMtime, models.PathFileSortName:
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("invalid sort parameter, expected size, mtime or name"))
+		return
+	}
+	if q.Order != "" && q.Order != "asc" && q.Order != "desc" {
+		writeError(w, http.StatusBadRequest, errors.New("invalid order parameter, expected asc or desc"))
+		return
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		q.Limit, err = strconv.Atoi(limitStr)
+		if err != nil || q.Limit <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid limit parameter"))
+			return
+		}
+		if q.Limit > maxFilesLimit {
+			q.Limit = maxFilesLimit
+		}
+	}
+	if _, err := filepath.Match(q.Pattern, ""); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("invalid pattern parameter"))
+		return
+	}
+
+	list, err := h.scanner.ListFiles(r.Context(), path, q)
+	if errors.Is(err, models.ErrPathNotConfigured) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: list})
+}
+
 // TriggerScan handles POST /api/v1/paths/scan
 func (h *PathsHandler) TriggerScan(w http.ResponseWriter, r *http.Request) {
 	if h.scanner == nil {
@@ -123,18 +277,51 @@ func (h *PathsHandler) TriggerScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.scanner.ScanPaths(req.Paths); err != nil {
+	job, err := h.scanner.SubmitScan(req.Paths)
+	if errors.Is(err, models.ErrPathNotConfigured) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	resp := models.Response{
-		Data: map[string]interface{}{
-			"status":  "scan triggered",
-			"paths":   req.Paths,
-			"message": "scan initiated successfully",
-		},
+	writeJSON(w, http.StatusAccepted, models.Response{Data: job})
+}
+
+// ScanJob handles GET /api/v1/paths/scan/{id}
+func (h *PathsHandler) ScanJob(w http.ResponseWriter, r *http.Request) {
+	if h.scanner == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("path scanner not configured"))
+		return
+	}
+
+	job, err := h.scanner.GetScanJob(r.PathValue("id"))
+	writeScanJob(w, job, err)
+}
+
+// CancelScanJob handles DELETE /api/v1/paths/scan/{id}
+func (h *PathsHandler) CancelScanJob(w http.ResponseWriter, r *http.Request) {
+	if h.scanner == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("path scanner not configured"))
+		return
+	}
+
+	job, err := h.scanner.CancelScanJob(r.PathValue("id"))
+	writeScanJob(w, job, err)
+}
+
+// writeScanJob writes a scan job lookup result, mapping unknown IDs to 404
+func writeScanJob(w http.ResponseWriter, job *models.ScanJob, err error) {
+	if errors.Is(err, models.ErrScanJobNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
 	}
 
-	writeJSON(w, http.StatusAccepted, resp)
+	writeJSON(w, http.StatusOK, models.Response{Data: job})
 }
@@ -2,10 +2,12 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -27,8 +29,53 @@ func setupPathsTestDB(t *testing.T) *sql.DB {
 			scan_duration_ms INTEGER NOT NULL DEFAULT 0,
 			status TEXT NOT NULL DEFAULT 'OK',
 			error_message TEXT,
+			status_reason TEXT NOT NULL DEFAULT '',
+			fs_type TEXT NOT NULL DEFAULT '',
+			fs_ops INTEGER NOT NULL DEFAULT 0,
+			ops_limit INTEGER NOT NULL DEFAULT 0,
+			throttled_ms INTEGER NOT NULL DEFAULT 0,
+			scan_mode TEXT NOT NULL DEFAULT '',
+			dirs_reused INTEGER NOT NULL DEFAULT 0,
+			last_full_scan DATETIME,
+			pattern_matches TEXT NOT NULL DEFAULT '',
+			buckets TEXT NOT NULL DEFAULT '',
+			watch_state TEXT NOT NULL DEFAULT '',
+			watch_error TEXT NOT NULL DEFAULT '',
+			newest_file_at DATETIME,
+			freshness TEXT NOT NULL DEFAULT '',
+			data_age_sec INTEGER NOT NULL DEFAULT 0,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			oldest_file_at DATETIME,
 			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
+		CREATE TABLE path_violations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			path TEXT NOT NULL,
+			file_path TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			mod_time DATETIME NOT NULL,
+			reason TEXT NOT NULL,
+			detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE path_breakdown (
+			path TEXT NOT NULL,
+			dir TEXT NOT NULL,
+			depth INTEGER NOT NULL,
+			file_count INTEGER NOT NULL,
+			dir_count INTEGER NOT NULL,
+			size_bytes INTEGER NOT NULL,
+			collected_at DATETIME NOT NULL,
+			PRIMARY KEY (path, dir)
+		);
+		CREATE TABLE path_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			path TEXT NOT NULL,
+			file_name TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			mod_time DATETIME NOT NULL,
+			detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
 	`
 	if _, err := db.Exec(schema); err != nil {
 		t.Fatalf("failed to create schema: %v", err)
@@ -191,3 +238,444 @@ func TestPathsHandler_TriggerScan_SucceedsWithPaths(t *testing.T) {
 		t.Errorf("expected status %d, got %d", http.StatusNotImplemented, w.Code)
 	}
 }
+
+func TestPathsHandler_Violations_FiltersByPath(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	for _, row := range []struct{ path, file string }{
+		{"/data/input", "/data/input/a.csv"},
+		{"/data/input", "/data/input/b.csv"},
+		{"/data/outbox", "/data/outbox/c.csv"},
+	} {
+		_, err := db.Exec(`
+			INSERT INTO path_violations (path, file_path, size_bytes, mod_time, reason, detected_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, row.path, row.file, 100, now.Add(-2*time.Hour), "older than 1h0m0s", now)
+		if err != nil {
+			t.Fatalf("failed to insert test data: %v", err)
+		}
+	}
+
+	repo := repository.NewPathsRepository(db)
+	handler := NewPathsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/violations?path=/data/input", nil)
+	w := httptest.NewRecorder()
+
+	handler.Violations(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data []models.PathViolation `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Fatalf("expected 2 violations, got %d", len(response.Data))
+	}
+	for _, v := range response.Data {
+		if v.Path != "/data/input" {
+			t.Errorf("expected only /data/input violations, got %s", v.Path)
+		}
+	}
+}
+
+func TestPathsHandler_Breakdown_ReturnsPathDirs(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPathsRepository(db)
+	now := time.Now()
+	err := repo.SavePathBreakdown(context.Background(), "/data/input", []*models.PathDirUsage{
+		{Dir: ".", Depth: 0, FileCount: 30, DirCount: 2, SizeBytes: 3000, CollectedAt: now},
+		{Dir: "partner_a", Depth: 1, FileCount: 20, SizeBytes: 2500, CollectedAt: now},
+		{Dir: "partner_b", Depth: 1, FileCount: 10, SizeBytes: 500, CollectedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("failed to save breakdown: %v", err)
+	}
+	if err := repo.SavePathBreakdown(context.Background(), "/data/outbox", []*models.PathDirUsage{
+		{Dir: ".", CollectedAt: now},
+	}); err != nil {
+		t.Fatalf("failed to save breakdown: %v", err)
+	}
+	handler := NewPathsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/breakdown?path=/data/input", nil)
+	w := httptest.NewRecorder()
+
+	handler.Breakdown(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data []models.PathDirUsage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response.Data) != 3 {
+		t.Fatalf("expected 3 dirs, got %d", len(response.Data))
+	}
+	if response.Data[0].Dir != "." || response.Data[1].Dir != "partner_a" || response.Data[1].SizeBytes != 2500 {
+		t.Errorf("unexpected breakdown: %+v", response.Data)
+	}
+}
+
+func TestPathsHandler_Breakdown_RequiresPath(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/breakdown", nil)
+	w := httptest.NewRecorder()
+
+	handler.Breakdown(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPathsHandler_Violations_EmptyDB_ReturnsEmptyArray(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPathsRepository(db)
+	handler := NewPathsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/violations", nil)
+	w := httptest.NewRecorder()
+
+	handler.Violations(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response models.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	data, ok := response.Data.([]interface{})
+	if !ok {
+		t.Fatalf("expected data to be array, got %T", response.Data)
+	}
+	if len(data) != 0 {
+		t.Errorf("expected empty array, got %d items", len(data))
+	}
+}
+
+func TestPathsHandler_Events_FiltersBySince(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPathsRepository(db)
+	handler := NewPathsHandler(repo)
+
+	now := time.Now()
+	err := repo.SavePathEvents(context.Background(), []*models.PathEvent{
+		{Path: "/data/input", FileName: "old.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now.Add(-2 * time.Hour)},
+		{Path: "/data/input", FileName: "new.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now},
+		{Path: "/data/outbox", FileName: "other.csv", EventType: models.PathEventRemoved, ModTime: now, DetectedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+
+	since := url.QueryEscape(now.Add(-time.Hour).Format(time.RFC3339))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/events?path=/data/input&since="+since, nil)
+	w := httptest.NewRecorder()
+
+	handler.Events(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data []models.PathEvent `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response.Data) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(response.Data))
+	}
+	if response.Data[0].FileName != "new.csv" || response.Data[0].EventType != models.PathEventCreated {
+		t.Errorf("unexpected event: %+v", response.Data[0])
+	}
+}
+
+func TestPathsHandler_Events_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/events?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.Events(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestPathsHandler_Events_EmptyDB_ReturnsEmptyArray(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/events", nil)
+	w := httptest.NewRecorder()
+
+	handler.Events(w, req)
+
+	var response models.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if data, ok := response.Data.([]interface{}); !ok || len(data) != 0 {
+		t.Errorf("expected empty array, got %v", response.Data)
+	}
+}
+
+// fakePathScanner records the last listing query and returns canned results
+type fakePathScanner struct {
+	query     models.PathFilesQuery
+	list      *models.PathFileList
+	job       *models.ScanJob
+	cancelled string
+	err       error
+}
+
+func (f *fakePathScanner) SubmitScan(paths []string) (*models.ScanJob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.ScanJob{ID: "job-1", Paths: paths, Status: models.ScanJobQueued}, nil
+}
+
+func (f *fakePathScanner) GetScanJob(id string) (*models.ScanJob, error) {
+	return f.job, f.err
+}
+
+func (f *fakePathScanner) CancelScanJob(id string) (*models.ScanJob, error) {
+	f.cancelled = id
+	return f.job, f.err
+}
+
+func (f *fakePathScanner) ListFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error) {
+	f.query = q
+	return f.list, f.err
+}
+
+func TestPathsHandler_Files_PassesQueryToScanner(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	scanner := &fakePathScanner{list: &models.PathFileList{
+		Path:    "/data/input",
+		Files:   []models.PathFile{{Name: "big.csv", SizeBytes: 4096, Owner: "etl"}},
+		Matched: 40000,
+	}}
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(scanner)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/files?path=/data/input&sort=mtime&order=desc&limit=5000&pattern=*.csv", nil)
+	w := httptest.NewRecorder()
+
+	handler.Files(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	want := models.PathFilesQuery{Sort: "mtime", Order: "desc", Limit: maxFilesLimit, Pattern: "*.csv"}
+	if scanner.query != want {
+		t.Errorf("query = %+v, want %+v", scanner.query, want)
+	}
+
+	var response struct {
+		Data models.PathFileList `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.Matched != 40000 || len(response.Data.Files) != 1 || response.Data.Files[0].Owner != "etl" {
+		t.Errorf("unexpected listing: %+v", response.Data)
+	}
+}
+
+func TestPathsHandler_Files_Errors(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	tests := []struct {
+		name   string
+		query  string
+		err    error
+		status int
+	}{
+		{"missing path", "", nil, http.StatusBadRequest},
+		{"bad sort", "path=/data&sort=owner", nil, http.StatusBadRequest},
+		{"bad order", "path=/data&order=up", nil, http.StatusBadRequest},
+		{"bad limit", "path=/data&limit=-1", nil, http.StatusBadRequest},
+		{"bad pattern", "path=/data&pattern=%5B", nil, http.StatusBadRequest},
+		{"unknown path", "path=/nope", models.ErrPathNotConfigured, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPathsHandler(repository.NewPathsRepository(db))
+			handler.SetScanner(&fakePathScanner{err: tt.err})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/files?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Files(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestPathsHandler_TriggerScan_ReturnsJob(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(&fakePathScanner{})
+
+	body := bytes.NewBufferString(`{"paths": ["/data/input"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/scan", body)
+	w := httptest.NewRecorder()
+
+	handler.TriggerScan(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data models.ScanJob `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.ID != "job-1" || response.Data.Status != models.ScanJobQueued {
+		t.Errorf("unexpected job: %+v", response.Data)
+	}
+}
+
+func TestPathsHandler_TriggerScan_UnknownPath_ReturnsNotFound(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(&fakePathScanner{err: models.ErrPathNotConfigured})
+
+	body := bytes.NewBufferString(`{"paths": ["/nope"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/scan", body)
+	w := httptest.NewRecorder()
+
+	handler.TriggerScan(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestPathsHandler_ScanJob_ReturnsProgress(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(&fakePathScanner{job: &models.ScanJob{
+		ID:           "job-1",
+		Status:       models.ScanJobRunning,
+		CurrentPath:  "/data/input",
+		FilesScanned: 1200,
+		DirsScanned:  30,
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/scan/job-1", nil)
+	req.SetPathValue("id", "job-1")
+	w := httptest.NewRecorder()
+
+	handler.ScanJob(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data models.ScanJob `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.FilesScanned != 1200 || response.Data.CurrentPath != "/data/input" {
+		t.Errorf("unexpected job: %+v", response.Data)
+	}
+}
+
+func TestPathsHandler_CancelScanJob(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	scanner := &fakePathScanner{job: &models.ScanJob{ID: "job-1", Status: models.ScanJobCancelled}}
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(scanner)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/paths/scan/job-1", nil)
+	req.SetPathValue("id", "job-1")
+	w := httptest.NewRecorder()
+
+	handler.CancelScanJob(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if scanner.cancelled != "job-1" {
+		t.Errorf("cancelled = %q, want job-1", scanner.cancelled)
+	}
+}
+
+func TestPathsHandler_ScanJob_UnknownID_ReturnsNotFound(t *testing.T) {
+	db := setupPathsTestDB(t)
+	defer db.Close()
+
+	handler := NewPathsHandler(repository.NewPathsRepository(db))
+	handler.SetScanner(&fakePathScanner{err: models.ErrScanJobNotFound})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/paths/scan/missing", nil)
+	req.SetPathValue("id", "missing")
+	w := httptest.NewRecorder()
+
+	handler.ScanJob(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
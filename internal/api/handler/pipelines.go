@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// defaultPipelineHistory is how far back pipeline history goes without a since parameter
+const defaultPipelineHistory = 24 * time.Hour
+
+// PipelinesHandler handles pipeline stage API requests
+type PipelinesHandler struct {
+	repo *repository.PipelinesRepository
+}
+
+// NewPipelinesHandler creates a new pipelines handler
+func NewPipelinesHandler(repo *repository.PipelinesRepository) *PipelinesHandler {
+	return &PipelinesHandler{repo: repo}
+}
+
+// List handles GET /api/v1/pipelines
+func (h *PipelinesHandler) List(w http.ResponseWriter, r *http.Request) {
+	pipelines, err := h.repo.LatestPipelines(r.Context(), r.URL.Query().Get("name"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if pipelines == nil {
+		pipelines = []models.Pipeline{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: pipelines})
+}
+
+// History handles GET /api/v1/pipelines/history
+func (h *PipelinesHandler) History(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since := time.Now().Add(-defaultPipelineHistory)
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("invalid since parameter, expected RFC3339"))
+			return
+		}
+	}
+
+	samples, err := h.repo.ListPipelineSamples(r.Context(), query.Get("name"), since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if samples == nil {
+		samples = []models.PipelineStage{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: samples})
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func setupPipelinesTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+
+	schema := `
+		CREATE TABLE pipeline_samples (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pipeline TEXT NOT NULL,
+			stage TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			path TEXT NOT NULL,
+			file_count INTEGER NOT NULL DEFAULT 0,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			oldest_file_at DATETIME,
+			arrived INTEGER NOT NULL DEFAULT 0,
+			departed INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT '',
+			collected_at DATETIME NOT NULL
+		);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	return db
+}
+
+// savePipelineRound stores one inbox/done sample of a pipeline taken at at
+func savePipelineRound(t *testing.T, repo *repository.PipelinesRepository, name string, at time.Time, inbox int64) {
+	t.Helper()
+	samples := []*models.PipelineStage{
+		{Stage: "inbox", Position: 0, Path: "/data/" + name + "/inbox", FileCount: inbox, Arrived: 4, Departed: 3, CollectedAt: at},
+		{Stage: "done", Position: 1, Path: "/data/" + name + "/done", FileCount: 1, Arrived: 3, CollectedAt: at},
+	}
+	if err := repo.SavePipelineSamples(context.Background(), name, samples); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+}
+
+func TestPipelinesHandler_List_ReturnsLatestStages(t *testing.T) {
+	db := setupPipelinesTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPipelinesRepository(db)
+	now := time.Now()
+	savePipelineRound(t, repo, "ingest", now.Add(-time.Minute), 10)
+	savePipelineRound(t, repo, "ingest", now, 7)
+	savePipelineRound(t, repo, "export", now, 2)
+
+	handler := NewPipelinesHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines?name=ingest", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var response struct {
+		Data []models.Pipeline `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Data) != 1 || len(response.Data[0].Stages) != 2 {
+		t.Fatalf("expected ingest with 2 stages, got %+v", response.Data)
+	}
+	if inbox := response.Data[0].Stages[0]; inbox.Stage != "inbox" || inbox.FileCount != 7 {
+		t.Errorf("expected the latest inbox sample with 7 files, got %+v", inbox)
+	}
+}
+
+func TestPipelinesHandler_List_EmptyReturnsArray(t *testing.T) {
+	db := setupPipelinesTestDB(t)
+	defer db.Close()
+
+	handler := NewPipelinesHandler(repository.NewPipelinesRepository(db))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var response struct {
+		Data []models.Pipeline `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data == nil {
+		t.Error("expected an empty array, got null")
+	}
+}
+
+func TestPipelinesHandler_History_FiltersBySince(t *testing.T) {
+	db := setupPipelinesTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewPipelinesRepository(db)
+	now := time.Now()
+	savePipelineRound(t, repo, "ingest", now.Add(-48*time.Hour), 9)
+	savePipelineRound(t, repo, "ingest", now.Add(-2*time.Hour), 8)
+	savePipelineRound(t, repo, "ingest", now, 7)
+
+	handler := NewPipelinesHandler(repo)
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"?name=ingest", 4}, // Last 24 hours by default
+		{"?name=ingest&since=" + now.Add(-time.Hour).UTC().Format(time.RFC3339), 2},
+		{"?name=export", 0},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/history"+tt.query, nil)
+		w := httptest.NewRecorder()
+
+		handler.History(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", tt.query, http.StatusOK, w.Code)
+		}
+		var response struct {
+			Data []models.PipelineStage `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Data == nil || len(response.Data) != tt.want {
+			t.Errorf("%s: expected %d samples, got %d", tt.query, tt.want, len(response.Data))
+		}
+	}
+}
+
+func TestPipelinesHandler_History_InvalidSince(t *testing.T) {
+	db := setupPipelinesTestDB(t)
+	defer db.Close()
+
+	handler := NewPipelinesHandler(repository.NewPipelinesRepository(db))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/history?since=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	handler.History(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
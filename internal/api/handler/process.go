@@ -1,15 +1,28 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/etlmon/etlmon/internal/db/repository"
 	"github.com/etlmon/etlmon/pkg/models"
 )
 
+// ProcessInspector reads the open files and sockets of a running process
+type ProcessInspector interface {
+	Details(ctx context.Context, pid int) (*models.ProcessDetails, error)
+}
+
 // ProcessHandler handles process info API requests
 type ProcessHandler struct {
-	repo *repository.ProcessRepository
+	repo      *repository.ProcessRepository
+	inspector ProcessInspector // Optional inspector for process details
 }
 
 // NewProcessHandler creates a new process handler
@@ -17,6 +30,11 @@ func NewProcessHandler(repo *repository.ProcessRepository) *ProcessHandler {
 	return &ProcessHandler{repo: repo}
 }
 
+// SetInspector sets the process inspector (optional)
+func (h *ProcessHandler) SetInspector(inspector ProcessInspector) {
+	h.inspector = inspector
+}
+
 // List handles GET /api/v1/processes
 func (h *ProcessHandler) List(w http.ResponseWriter, r *http.Request) {
 	procs, err := h.repo.ListAll()
@@ -30,3 +48,267 @@ func (h *ProcessHandler) List(w http.ResponseWriter, r *http.Request) {
 	resp := models.Response{Data: procs}
 	writeJSON(w, http.StatusOK, resp)
 }
+
+// restartWindow is the period process watches count restarts over
+const restartWindow = 24 * time.Hour
+
+// Watches handles GET /api/v1/processes/watches
+func (h *ProcessHandler) Watches(w http.ResponseWriter, r *http.Request) {
+	watches, err := h.repo.ListProcessWatches(r.Context(), time.Now().Add(-restartWindow))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if watches == nil {
+		watches = []*models.ProcessWatch{}
+	}
+	writeJSON(w, http.StatusOK, models.Response{Data: watches})
+}
+
+// Events handles GET /api/v1/processes/events
+func (h *ProcessHandler) Events(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("invalid since parameter, expected RFC3339"))
+			return
+		}
+	}
+
+	limit := defaultEventsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid limit parameter"))
+			return
+		}
+	}
+
+	events, err := h.repo.ListProcessEvents(r.Context(), query.Get("watch"), since, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if events == nil {
+		events = []models.ProcessEvent{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: events})
+}
+
+// defaultProcessHistory is how far back process history goes without a
+// since parameter
+const defaultProcessHistory = 24 * time.Hour
+
+// History handles GET /api/v1/processes/history
+func (h *ProcessHandler) History(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since := time.Now().Add(-defaultProcessHistory)
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("invalid since parameter, expected RFC3339"))
+			return
+		}
+	}
+
+	samples, err := h.repo.ListProcessWatchSamples(r.Context(), query.Get("watch"), since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Ensure we always return an array
+	if samples == nil {
+		samples = []models.ProcessWatchSample{}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: samples})
+}
+
+// Groups handles GET /api/v1/processes/groups
+func (h *ProcessHandler) Groups(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	if kind != "" && kind != models.ProcessGroupUnit && kind != models.ProcessGroupContainer {
+		writeError(w, http.StatusBadRequest, errors.New("invalid kind parameter, expected unit or container"))
+		return
+	}
+
+	groups, err := h.repo.ListProcessGroups(r.Context(), kind)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if groups == nil {
+		groups = []*models.ProcessGroup{}
+	}
+	writeJSON(w, http.StatusOK, models.Response{Data: groups})
+}
+
+// Tree handles GET /api/v1/processes/tree. Without a root it returns the
+// whole forest; with root=<pid> it returns that process and its subtree.
+func (h *ProcessHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	root := 0
+	if rootStr := r.URL.Query().Get("root"); rootStr != "" {
+		var err error
+		root, err = strconv.Atoi(rootStr)
+		if err != nil || root <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid root parameter"))
+			return
+		}
+	}
+
+	procs, err := h.repo.ListProcessTree(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	roots, nodes := buildProcessTree(procs)
+	if root != 0 {
+		node, ok := nodes[root]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("process %d not found", root))
+			return
+		}
+		roots = []*models.ProcessNode{node}
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: roots})
+}
+
+// buildProcessTree links processes to their parents and sums CPU and RSS
+// over every subtree. Processes whose parent is not in the snapshot become
+// roots. It returns the roots, busiest subtree first, and every node by PID.
+func buildProcessTree(procs []*models.ProcessInfo) ([]*models.ProcessNode, map[int]*models.ProcessNode) {
+	nodes := make(map[int]*models.ProcessNode, len(procs))
+	for _, p := range procs {
+		nodes[p.PID] = &models.ProcessNode{
+			PID:        p.PID,
+			PPID:       p.PPID,
+			Name:       p.Name,
+			User:       p.User,
+			Cmdline:    p.Cmdline,
+			CPUPercent: p.CPUPercent,
+			MemRSS:     p.MemRSS,
+			Children:   []*models.ProcessNode{},
+		}
+	}
+
+	roots := []*models.ProcessNode{}
+	for _, p := range procs {
+		node := nodes[p.PID]
+		if parent, ok := nodes[p.PPID]; ok && p.PPID != p.PID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	// A parent loop, possible when PIDs are reused between reads of a
+	// snapshot, is unreachable from any root; it is broken at its lowest PID
+	visited := make(map[int]bool, len(nodes))
+	for _, root := range roots {
+		sumSubtree(root, visited)
+	}
+	for _, p := range procs {
+		if !visited[p.PID] {
+			node := nodes[p.PID]
+			if parent, ok := nodes[node.PPID]; ok {
+				parent.Children = removeNode(parent.Children, node)
+			}
+			roots = append(roots, node)
+			sumSubtree(node, visited)
+		}
+	}
+
+	sortByTreeCPU(roots)
+	return roots, nodes
+}
+
+// sumSubtree fills in the subtree totals of node and sorts its children,
+// skipping nodes that were already visited
+func sumSubtree(node *models.ProcessNode, visited map[int]bool) {
+	visited[node.PID] = true
+	node.TreeCPU = node.CPUPercent
+	node.TreeMemRSS = node.MemRSS
+	node.Descendants = 0
+
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if visited[child.PID] {
+			continue
+		}
+		sumSubtree(child, visited)
+		node.TreeCPU += child.TreeCPU
+		node.TreeMemRSS += child.TreeMemRSS
+		node.Descendants += 1 + child.Descendants
+		children = append(children, child)
+	}
+	node.Children = children
+	sortByTreeCPU(node.Children)
+}
+
+// sortByTreeCPU orders nodes by subtree CPU, then subtree RSS, then PID
+func sortByTreeCPU(nodes []*models.ProcessNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].TreeCPU != nodes[j].TreeCPU {
+			return nodes[i].TreeCPU > nodes[j].TreeCPU
+		}
+		if nodes[i].TreeMemRSS != nodes[j].TreeMemRSS {
+			return nodes[i].TreeMemRSS > nodes[j].TreeMemRSS
+		}
+		return nodes[i].PID < nodes[j].PID
+	})
+}
+
+// removeNode removes node from nodes
+func removeNode(nodes []*models.ProcessNode, node *models.ProcessNode) []*models.ProcessNode {
+	for i, n := range nodes {
+		if n == node {
+			return append(nodes[:i], nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+// Details handles GET /api/v1/processes/{pid}/details
+func (h *ProcessHandler) Details(w http.ResponseWriter, r *http.Request) {
+	if h.inspector == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("process inspector not configured"))
+		return
+	}
+
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("invalid pid"))
+		return
+	}
+
+	details, err := h.inspector.Details(r.Context(), pid)
+	switch {
+	case errors.Is(err, models.ErrProcessNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, os.ErrPermission):
+		writeError(w, http.StatusForbidden, err)
+		return
+	case errors.Is(err, errors.ErrUnsupported):
+		writeError(w, http.StatusNotImplemented, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.Response{Data: details})
+}
@@ -0,0 +1,481 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func setupProcessTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+
+	schema := `
+		CREATE TABLE process_stats (
+			pid INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			user TEXT NOT NULL,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT 'unknown',
+			elapsed TEXT NOT NULL DEFAULT '',
+			cmdline TEXT NOT NULL DEFAULT '',
+			ppid INTEGER NOT NULL DEFAULT 0,
+			threads INTEGER NOT NULL DEFAULT 0,
+			start_time DATETIME,
+			read_bytes INTEGER NOT NULL DEFAULT 0,
+			write_bytes INTEGER NOT NULL DEFAULT 0,
+			cgroup TEXT NOT NULL DEFAULT '',
+			unit TEXT NOT NULL DEFAULT '',
+			container_id TEXT NOT NULL DEFAULT '',
+			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE process_watches (
+			name TEXT PRIMARY KEY,
+			pattern TEXT NOT NULL DEFAULT '',
+			user TEXT NOT NULL DEFAULT '',
+			min_count INTEGER NOT NULL DEFAULT 1,
+			max_count INTEGER NOT NULL DEFAULT 0,
+			count INTEGER NOT NULL DEFAULT 0,
+			pids TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			checked_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			watch TEXT NOT NULL,
+			pid INTEGER NOT NULL,
+			event_type TEXT NOT NULL,
+			restart INTEGER NOT NULL DEFAULT 0,
+			start_time DATETIME,
+			runtime_sec INTEGER NOT NULL DEFAULT 0,
+			cmdline TEXT NOT NULL DEFAULT '',
+			detected_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_tree (
+			pid INTEGER PRIMARY KEY,
+			ppid INTEGER NOT NULL DEFAULT 0,
+			name TEXT NOT NULL,
+			user TEXT NOT NULL DEFAULT '',
+			cmdline TEXT NOT NULL DEFAULT '',
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_groups (
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			cgroup TEXT NOT NULL,
+			processes INTEGER NOT NULL DEFAULT 0,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			memory_current INTEGER NOT NULL DEFAULT 0,
+			cgroup_cpu_percent REAL NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL,
+			PRIMARY KEY (kind, name)
+		);
+		CREATE TABLE process_watch_samples (
+			watch TEXT NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			threads INTEGER NOT NULL DEFAULT 0,
+			open_fds INTEGER NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL
+		);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	return db
+}
+
+func TestProcessHandler_Watches_ReportsDownWatches(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	watches := []*models.ProcessWatch{
+		{Name: "etl_worker", Match: "etl_worker.*", Min: 1, Count: 2, PIDs: []int{20, 21}, Status: models.ProcessWatchUp, CheckedAt: time.Now()},
+		{Name: "scheduler", Match: "scheduler.py", Min: 1, Status: models.ProcessWatchDown, Reason: "no matching process", CheckedAt: time.Now()},
+	}
+	if err := repo.SaveProcessWatches(context.Background(), watches); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+
+	handler := NewProcessHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/watches", nil)
+	w := httptest.NewRecorder()
+	handler.Watches(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessWatch `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 watches, got %d", len(resp.Data))
+	}
+	down := resp.Data[1]
+	if down.Name != "scheduler" || down.Status != models.ProcessWatchDown || down.Count != 0 || down.PIDs == nil {
+		t.Errorf("expected scheduler DOWN with an empty PID list, got %+v", down)
+	}
+}
+
+func TestProcessHandler_Watches_EmptyReturnsArray(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+
+	handler := NewProcessHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/watches", nil)
+	w := httptest.NewRecorder()
+	handler.Watches(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if string(resp.Data) != "[]" {
+		t.Errorf("expected an empty array, got %s", resp.Data)
+	}
+}
+
+func TestProcessHandler_Events_FiltersByWatch(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	now := time.Now()
+	events := []*models.ProcessEvent{
+		{Watch: "etl_worker", PID: 20, EventType: models.ProcessEventExit, RuntimeSec: 12, DetectedAt: now.Add(-2 * time.Minute)},
+		{Watch: "etl_worker", PID: 21, EventType: models.ProcessEventStart, Restart: true, DetectedAt: now.Add(-2 * time.Minute)},
+		{Watch: "scheduler", PID: 30, EventType: models.ProcessEventStart, DetectedAt: now.Add(-time.Minute)},
+	}
+	if err := repo.SaveProcessEvents(context.Background(), events); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+
+	handler := NewProcessHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/events?watch=etl_worker", nil)
+	w := httptest.NewRecorder()
+	handler.Events(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessEvent `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].EventType != models.ProcessEventExit || !resp.Data[1].Restart {
+		t.Errorf("expected the worker's exit and restart, got %+v", resp.Data)
+	}
+}
+
+func TestProcessHandler_Events_InvalidParams(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	handler := NewProcessHandler(repo)
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/events?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.Events(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestProcessHandler_History_DefaultsToLastDay(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	now := time.Now()
+	samples := []*models.ProcessWatchSample{
+		{Watch: "etl_worker", Count: 2, CPUPercent: 30, MemRSS: 512 << 20, Threads: 20, OpenFDs: 64, CollectedAt: now.Add(-30 * time.Hour)},
+		{Watch: "etl_worker", Count: 2, CPUPercent: 45, MemRSS: 600 << 20, Threads: 22, OpenFDs: 70, CollectedAt: now.Add(-time.Hour)},
+		{Watch: "scheduler", Count: 1, CPUPercent: 1, MemRSS: 64 << 20, Threads: 3, OpenFDs: 9, CollectedAt: now.Add(-time.Hour)},
+	}
+	if err := repo.SaveProcessWatchSamples(context.Background(), samples, 7*24*time.Hour); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+
+	handler := NewProcessHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/history?watch=etl_worker", nil)
+	w := httptest.NewRecorder()
+	handler.History(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessWatchSample `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].CPUPercent != 45 || resp.Data[0].OpenFDs != 70 {
+		t.Errorf("expected the worker's sample from the last day, got %+v", resp.Data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/processes/history?since=yesterday", nil)
+	w = httptest.NewRecorder()
+	handler.History(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid since, got %d", w.Code)
+	}
+}
+
+func saveTestProcessTree(t *testing.T, repo *repository.ProcessRepository) {
+	t.Helper()
+	now := time.Now()
+	procs := []*models.ProcessInfo{
+		{PID: 1, PPID: 0, Name: "systemd", User: "root", CPUPercent: 0.5, MemRSS: 10 << 20, CollectedAt: now},
+		{PID: 100, PPID: 1, Name: "sshd", User: "root", CPUPercent: 0.1, MemRSS: 5 << 20, CollectedAt: now},
+		{PID: 200, PPID: 1, Name: "airflow", User: "etl", CPUPercent: 2, MemRSS: 100 << 20, CollectedAt: now},
+		{PID: 201, PPID: 200, Name: "python3", User: "etl", CPUPercent: 40, MemRSS: 300 << 20, CollectedAt: now},
+		{PID: 202, PPID: 200, Name: "python3", User: "etl", CPUPercent: 10, MemRSS: 200 << 20, CollectedAt: now},
+	}
+	if err := repo.SaveProcessTree(context.Background(), procs); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+}
+
+func TestProcessHandler_Tree_AggregatesSubtrees(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	saveTestProcessTree(t, repo)
+
+	handler := NewProcessHandler(repo)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/tree", nil)
+	w := httptest.NewRecorder()
+	handler.Tree(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessNode `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].PID != 1 {
+		t.Fatalf("expected systemd as the only root, got %+v", resp.Data)
+	}
+	root := resp.Data[0]
+	if root.Descendants != 4 || root.TreeCPU != 52.6 || root.TreeMemRSS != 615<<20 {
+		t.Errorf("unexpected root totals: descendants=%d cpu=%v rss=%d", root.Descendants, root.TreeCPU, root.TreeMemRSS)
+	}
+	if len(root.Children) != 2 || root.Children[0].PID != 200 {
+		t.Fatalf("expected the airflow subtree first, got %+v", root.Children)
+	}
+	if airflow := root.Children[0]; airflow.TreeCPU != 52 || airflow.Children[0].PID != 201 {
+		t.Errorf("unexpected airflow subtree: %+v", airflow)
+	}
+}
+
+func TestProcessHandler_Tree_Root(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	saveTestProcessTree(t, repo)
+	handler := NewProcessHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/tree?root=200", nil)
+	w := httptest.NewRecorder()
+	handler.Tree(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessNode `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].PID != 200 || resp.Data[0].Descendants != 2 {
+		t.Errorf("expected the airflow subtree, got %+v", resp.Data)
+	}
+
+	for query, code := range map[string]int{"root=999": http.StatusNotFound, "root=abc": http.StatusBadRequest, "root=-1": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/tree?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.Tree(w, req)
+
+		if w.Code != code {
+			t.Errorf("%s: expected %d, got %d", query, code, w.Code)
+		}
+	}
+}
+
+func TestBuildProcessTree_BreaksParentLoops(t *testing.T) {
+	procs := []*models.ProcessInfo{
+		{PID: 10, PPID: 11, Name: "a", CPUPercent: 1},
+		{PID: 11, PPID: 10, Name: "b", CPUPercent: 2},
+		{PID: 12, PPID: 12, Name: "self"},
+	}
+
+	roots, nodes := buildProcessTree(procs)
+
+	if len(roots) != 2 || len(nodes) != 3 {
+		t.Fatalf("expected 2 roots, got %+v", roots)
+	}
+	loop := roots[0]
+	if loop.PID != 10 || loop.Descendants != 1 || loop.TreeCPU != 3 {
+		t.Errorf("expected the loop rooted at its lowest PID, got %+v", loop)
+	}
+	if len(loop.Children) != 1 || len(loop.Children[0].Children) != 0 {
+		t.Errorf("expected the loop to be broken, got %+v", loop.Children)
+	}
+}
+
+// stubInspector returns fixed process details or an error
+type stubInspector struct {
+	details *models.ProcessDetails
+	err     error
+}
+
+func (s *stubInspector) Details(ctx context.Context, pid int) (*models.ProcessDetails, error) {
+	return s.details, s.err
+}
+
+func TestProcessHandler_Details(t *testing.T) {
+	handler := NewProcessHandler(nil)
+	handler.SetInspector(&stubInspector{details: &models.ProcessDetails{
+		PID: 4242, Name: "java", OpenFDs: 3, MaxFDs: 1024,
+		Files:       []models.ProcessFile{{FD: 3, Path: "/data/in/orders.csv"}},
+		Sockets:     []models.ProcessSocket{{FD: 5, Protocol: "tcp", State: "ESTABLISHED", LocalAddr: "10.0.0.5:51234", RemoteAddr: "10.0.0.9:5432"}},
+		ListenPorts: []int{},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/4242/details", nil)
+	req.SetPathValue("pid", "4242")
+	w := httptest.NewRecorder()
+	handler.Details(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data models.ProcessDetails `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.PID != 4242 || len(resp.Data.Sockets) != 1 || resp.Data.Sockets[0].RemoteAddr != "10.0.0.9:5432" {
+		t.Errorf("unexpected details: %+v", resp.Data)
+	}
+}
+
+func TestProcessHandler_Details_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		pid       string
+		inspector ProcessInspector
+		want      int
+	}{
+		{"no inspector", "1", nil, http.StatusNotImplemented},
+		{"invalid pid", "abc", &stubInspector{}, http.StatusBadRequest},
+		{"not running", "4242", &stubInspector{err: models.ErrProcessNotFound}, http.StatusNotFound},
+		{"permission denied", "1", &stubInspector{err: os.ErrPermission}, http.StatusForbidden},
+		{"unsupported", "1", &stubInspector{err: errors.ErrUnsupported}, http.StatusNotImplemented},
+	}
+	for _, tt := range tests {
+		handler := NewProcessHandler(nil)
+		if tt.inspector != nil {
+			handler.SetInspector(tt.inspector)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/"+tt.pid+"/details", nil)
+		req.SetPathValue("pid", tt.pid)
+		w := httptest.NewRecorder()
+		handler.Details(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+}
+
+func TestProcessHandler_Groups(t *testing.T) {
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewProcessRepository(db)
+	defer repo.Close()
+	groups := []*models.ProcessGroup{
+		{Kind: models.ProcessGroupUnit, Name: "nifi.service", Cgroup: "/system.slice/nifi.service", Processes: 2, CPUPercent: 35, CollectedAt: time.Now()},
+		{Kind: models.ProcessGroupContainer, Name: "4f1c2d3e4b5a", Cgroup: "/docker/4f1c2d3e4b5a", Processes: 1, CPUPercent: 40, CollectedAt: time.Now()},
+	}
+	if err := repo.SaveProcessGroups(context.Background(), groups); err != nil {
+		t.Fatalf("failed to insert test data: %v", err)
+	}
+	handler := NewProcessHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/processes/groups?kind=unit", nil)
+	w := httptest.NewRecorder()
+	handler.Groups(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data []models.ProcessGroup `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Name != "nifi.service" || resp.Data[0].Processes != 2 {
+		t.Errorf("expected the nifi unit only, got %+v", resp.Data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/processes/groups?kind=pod", nil)
+	w = httptest.NewRecorder()
+	handler.Groups(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown kind, got %d", w.Code)
+	}
+}
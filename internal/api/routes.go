@@ -12,10 +12,19 @@ func (s *Server) setupRoutes() http.Handler {
 	// Create handlers
 	fsHandler := handler.NewFSHandler(s.repo.FS)
 	pathsHandler := handler.NewPathsHandler(s.repo.Paths)
+	partitionsHandler := handler.NewPartitionsHandler(s.repo.Partitions)
+	pipelinesHandler := handler.NewPipelinesHandler(s.repo.Pipelines)
 	healthHandler := handler.NewHealthHandler(s.nodeName)
 	processHandler := handler.NewProcessHandler(s.repo.Process)
 	logHandler := handler.NewLogHandler(s.repo.Log, s.configPath)
 
+	if s.inspector != nil {
+		processHandler.SetInspector(s.inspector)
+	}
+	if s.deletedScanner != nil {
+		fsHandler.SetDeletedScanner(s.deletedScanner)
+	}
+
 	// Set scanner proxy (supports hot-swap on config reload)
 	pathsHandler.SetScanner(s.scannerProxy)
 
@@ -34,6 +43,13 @@ func (s *Server) setupRoutes() http.Handler {
 
 	// Register routes
 	mux.HandleFunc("/api/v1/fs", fsHandler.List)
+	mux.HandleFunc("/api/v1/fs/deleted", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fsHandler.Deleted(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
 	mux.HandleFunc("/api/v1/paths", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			pathsHandler.List(w, r)
@@ -41,6 +57,34 @@ func (s *Server) setupRoutes() http.Handler {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+	mux.HandleFunc("/api/v1/paths/violations", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pathsHandler.Violations(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/paths/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pathsHandler.Files(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/paths/breakdown", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pathsHandler.Breakdown(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/paths/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pathsHandler.Events(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
 	mux.HandleFunc("/api/v1/paths/scan", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			pathsHandler.TriggerScan(w, r)
@@ -48,8 +92,81 @@ func (s *Server) setupRoutes() http.Handler {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+	mux.HandleFunc("/api/v1/paths/scan/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			pathsHandler.ScanJob(w, r)
+		case http.MethodDelete:
+			pathsHandler.CancelScanJob(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/partitions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			partitionsHandler.List(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pipelinesHandler.List(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/pipelines/history", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			pipelinesHandler.History(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
 	mux.HandleFunc("/api/v1/health", healthHandler.Health)
 	mux.HandleFunc("/api/v1/processes", processHandler.List)
+	mux.HandleFunc("/api/v1/processes/watches", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.Watches(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/processes/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.Events(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/processes/history", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.History(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/processes/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.Groups(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/processes/tree", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.Tree(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/processes/{pid}/details", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			processHandler.Details(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
 	mux.HandleFunc("/api/v1/logs/files", logHandler.ListFiles)
 	mux.HandleFunc("/api/v1/logs", logHandler.List)
 
@@ -8,11 +8,15 @@ import (
 	"sync"
 
 	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
 )
 
-// PathScanner interface for triggering path scans
+// PathScanner interface for triggering path scans and listing files
 type PathScanner interface {
-	ScanPaths(paths []string) error
+	SubmitScan(paths []string) (*models.ScanJob, error)
+	GetScanJob(id string) (*models.ScanJob, error)
+	CancelScanJob(id string) (*models.ScanJob, error)
+	ListFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error)
 }
 
 // ScannerProxy wraps a PathScanner and allows hot-swapping the underlying scanner
@@ -26,15 +30,48 @@ func NewScannerProxy() *ScannerProxy {
 	return &ScannerProxy{}
 }
 
-// ScanPaths delegates to the underlying scanner
-func (p *ScannerProxy) ScanPaths(paths []string) error {
+// SubmitScan delegates to the underlying scanner
+func (p *ScannerProxy) SubmitScan(paths []string) (*models.ScanJob, error) {
 	p.mu.RLock()
 	s := p.scanner
 	p.mu.RUnlock()
 	if s == nil {
-		return fmt.Errorf("scanner not available")
+		return nil, fmt.Errorf("scanner not available")
 	}
-	return s.ScanPaths(paths)
+	return s.SubmitScan(paths)
+}
+
+// GetScanJob delegates to the underlying scanner
+func (p *ScannerProxy) GetScanJob(id string) (*models.ScanJob, error) {
+	p.mu.RLock()
+	s := p.scanner
+	p.mu.RUnlock()
+	if s == nil {
+		return nil, fmt.Errorf("scanner not available")
+	}
+	return s.GetScanJob(id)
+}
+
+// CancelScanJob delegates to the underlying scanner
+func (p *ScannerProxy) CancelScanJob(id string) (*models.ScanJob, error) {
+	p.mu.RLock()
+	s := p.scanner
+	p.mu.RUnlock()
+	if s == nil {
+		return nil, fmt.Errorf("scanner not available")
+	}
+	return s.CancelScanJob(id)
+}
+
+// ListFiles delegates to the underlying scanner
+func (p *ScannerProxy) ListFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error) {
+	p.mu.RLock()
+	s := p.scanner
+	p.mu.RUnlock()
+	if s == nil {
+		return nil, fmt.Errorf("scanner not available")
+	}
+	return s.ListFiles(ctx, path, q)
 }
 
 // Update replaces the underlying scanner
@@ -44,6 +81,16 @@ func (p *ScannerProxy) Update(scanner PathScanner) {
 	p.mu.Unlock()
 }
 
+// ProcessInspector interface for reading a running process's open files and sockets
+type ProcessInspector interface {
+	Details(ctx context.Context, pid int) (*models.ProcessDetails, error)
+}
+
+// DeletedFilesScanner interface for finding deleted files still held open
+type DeletedFilesScanner interface {
+	DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error)
+}
+
 // Server represents the HTTP API server
 type Server struct {
 	addr           string
@@ -52,6 +99,8 @@ type Server struct {
 	configPath     string
 	httpServer     *http.Server
 	scannerProxy   *ScannerProxy
+	inspector      ProcessInspector
+	deletedScanner DeletedFilesScanner
 	onConfigReload func()
 	listener       net.Listener
 	mu             sync.RWMutex
@@ -73,6 +122,18 @@ func (s *Server) SetPathScanner(scanner PathScanner) {
 	s.scannerProxy.Update(scanner)
 }
 
+// SetProcessInspector sets the inspector for process details; it must be
+// called before Start
+func (s *Server) SetProcessInspector(inspector ProcessInspector) {
+	s.inspector = inspector
+}
+
+// SetDeletedFilesScanner sets the scanner for deleted-but-open files; it
+// must be called before Start
+func (s *Server) SetDeletedFilesScanner(scanner DeletedFilesScanner) {
+	s.deletedScanner = scanner
+}
+
 // SetConfigReloadCallback sets the callback to invoke when config is updated via API
 func (s *Server) SetConfigReloadCallback(cb func()) {
 	s.onConfigReload = cb
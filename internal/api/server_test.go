@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	_ "modernc.org/sqlite"
 
 	"github.com/etlmon/etlmon/internal/db/repository"
+	"github.com/etlmon/etlmon/pkg/models"
 )
 
 func setupServerTestDB(t *testing.T) *sql.DB {
@@ -25,6 +27,12 @@ func setupServerTestDB(t *testing.T) *sql.DB {
 			used_bytes INTEGER NOT NULL,
 			avail_bytes INTEGER NOT NULL,
 			used_percent REAL NOT NULL,
+			inodes_total INTEGER NOT NULL DEFAULT 0,
+			inodes_used INTEGER NOT NULL DEFAULT 0,
+			inodes_free INTEGER NOT NULL DEFAULT 0,
+			inodes_used_percent REAL NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT 'OK',
+			status_reason TEXT NOT NULL DEFAULT '',
 			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
 		CREATE TABLE path_stats (
@@ -34,8 +42,77 @@ func setupServerTestDB(t *testing.T) *sql.DB {
 			scan_duration_ms INTEGER NOT NULL DEFAULT 0,
 			status TEXT NOT NULL DEFAULT 'OK',
 			error_message TEXT,
+			status_reason TEXT NOT NULL DEFAULT '',
+			fs_type TEXT NOT NULL DEFAULT '',
+			fs_ops INTEGER NOT NULL DEFAULT 0,
+			ops_limit INTEGER NOT NULL DEFAULT 0,
+			throttled_ms INTEGER NOT NULL DEFAULT 0,
+			scan_mode TEXT NOT NULL DEFAULT '',
+			dirs_reused INTEGER NOT NULL DEFAULT 0,
+			last_full_scan DATETIME,
+			pattern_matches TEXT NOT NULL DEFAULT '',
+			buckets TEXT NOT NULL DEFAULT '',
+			watch_state TEXT NOT NULL DEFAULT '',
+			watch_error TEXT NOT NULL DEFAULT '',
+			newest_file_at DATETIME,
+			freshness TEXT NOT NULL DEFAULT '',
+			data_age_sec INTEGER NOT NULL DEFAULT 0,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			oldest_file_at DATETIME,
 			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
+		CREATE TABLE path_violations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			path TEXT NOT NULL,
+			file_path TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			mod_time DATETIME NOT NULL,
+			reason TEXT NOT NULL,
+			detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE path_breakdown (
+			path TEXT NOT NULL,
+			dir TEXT NOT NULL,
+			depth INTEGER NOT NULL,
+			file_count INTEGER NOT NULL,
+			dir_count INTEGER NOT NULL,
+			size_bytes INTEGER NOT NULL,
+			collected_at DATETIME NOT NULL,
+			PRIMARY KEY (path, dir)
+		);
+		CREATE TABLE path_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			path TEXT NOT NULL,
+			file_name TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			mod_time DATETIME NOT NULL,
+			detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE partition_status (
+			check_name TEXT NOT NULL,
+			partition TEXT NOT NULL,
+			period_start DATETIME NOT NULL,
+			due DATETIME NOT NULL,
+			status TEXT NOT NULL,
+			arrived_at DATETIME,
+			checked_at DATETIME NOT NULL,
+			PRIMARY KEY (check_name, partition)
+		);
+		CREATE TABLE pipeline_samples (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pipeline TEXT NOT NULL,
+			stage TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			path TEXT NOT NULL,
+			file_count INTEGER NOT NULL DEFAULT 0,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			oldest_file_at DATETIME,
+			arrived INTEGER NOT NULL DEFAULT 0,
+			departed INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT '',
+			collected_at DATETIME NOT NULL
+		);
 		CREATE TABLE process_stats (
 			pid INTEGER PRIMARY KEY,
 			name TEXT NOT NULL,
@@ -44,8 +121,71 @@ func setupServerTestDB(t *testing.T) *sql.DB {
 			mem_rss INTEGER NOT NULL DEFAULT 0,
 			status TEXT NOT NULL DEFAULT 'unknown',
 			elapsed TEXT NOT NULL DEFAULT '',
+			cmdline TEXT NOT NULL DEFAULT '',
+			ppid INTEGER NOT NULL DEFAULT 0,
+			threads INTEGER NOT NULL DEFAULT 0,
+			start_time DATETIME,
+			read_bytes INTEGER NOT NULL DEFAULT 0,
+			write_bytes INTEGER NOT NULL DEFAULT 0,
+			cgroup TEXT NOT NULL DEFAULT '',
+			unit TEXT NOT NULL DEFAULT '',
+			container_id TEXT NOT NULL DEFAULT '',
 			collected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
+		CREATE TABLE process_watches (
+			name TEXT PRIMARY KEY,
+			pattern TEXT NOT NULL DEFAULT '',
+			user TEXT NOT NULL DEFAULT '',
+			min_count INTEGER NOT NULL DEFAULT 1,
+			max_count INTEGER NOT NULL DEFAULT 0,
+			count INTEGER NOT NULL DEFAULT 0,
+			pids TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			checked_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			watch TEXT NOT NULL,
+			pid INTEGER NOT NULL,
+			event_type TEXT NOT NULL,
+			restart INTEGER NOT NULL DEFAULT 0,
+			start_time DATETIME,
+			runtime_sec INTEGER NOT NULL DEFAULT 0,
+			cmdline TEXT NOT NULL DEFAULT '',
+			detected_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_tree (
+			pid INTEGER PRIMARY KEY,
+			ppid INTEGER NOT NULL DEFAULT 0,
+			name TEXT NOT NULL,
+			user TEXT NOT NULL DEFAULT '',
+			cmdline TEXT NOT NULL DEFAULT '',
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL
+		);
+		CREATE TABLE process_groups (
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			cgroup TEXT NOT NULL,
+			processes INTEGER NOT NULL DEFAULT 0,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			memory_current INTEGER NOT NULL DEFAULT 0,
+			cgroup_cpu_percent REAL NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL,
+			PRIMARY KEY (kind, name)
+		);
+		CREATE TABLE process_watch_samples (
+			watch TEXT NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			mem_rss INTEGER NOT NULL DEFAULT 0,
+			threads INTEGER NOT NULL DEFAULT 0,
+			open_fds INTEGER NOT NULL DEFAULT 0,
+			collected_at DATETIME NOT NULL
+		);
 		CREATE TABLE log_lines (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			log_name TEXT NOT NULL,
@@ -169,3 +309,73 @@ func TestServer_Routes_HealthEndpoint(t *testing.T) {
 		t.Fatal("setupRoutes returned nil handler")
 	}
 }
+
+// fakeInspector returns fixed process details
+type fakeInspector struct {
+	pid int
+}
+
+func (f *fakeInspector) Details(ctx context.Context, pid int) (*models.ProcessDetails, error) {
+	f.pid = pid
+	return &models.ProcessDetails{PID: pid, Name: "loader"}, nil
+}
+
+func TestServer_Routes_ProcessDetails(t *testing.T) {
+	db := setupServerTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewRepository(db)
+	server := NewServer("127.0.0.1:0", repo, "test-node", "")
+	inspector := &fakeInspector{}
+	server.SetProcessInspector(inspector)
+
+	handler := server.setupRoutes()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/processes/4242/details", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if inspector.pid != 4242 {
+		t.Errorf("expected details of PID 4242, got %d", inspector.pid)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/processes/4242/details", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
+
+// fakeDeletedScanner returns a fixed deleted files report
+type fakeDeletedScanner struct{}
+
+func (fakeDeletedScanner) DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	return &models.DeletedFilesReport{
+		TotalBytes: 1 << 30,
+		Mounts:     []models.DeletedFilesMount{{MountPoint: "/data", Bytes: 1 << 30, Files: 1}},
+	}, nil
+}
+
+func TestServer_Routes_DeletedFiles(t *testing.T) {
+	db := setupServerTestDB(t)
+	defer db.Close()
+
+	repo := repository.NewRepository(db)
+	server := NewServer("127.0.0.1:0", repo, "test-node", "")
+	server.SetDeletedFilesScanner(fakeDeletedScanner{})
+
+	handler := server.setupRoutes()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/fs/deleted", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/fs/deleted", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
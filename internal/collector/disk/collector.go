@@ -1,13 +1,10 @@
 package disk
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"runtime"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -19,42 +16,62 @@ import (
 type FSRepository interface {
 	SaveFilesystemUsage(ctx context.Context, usage *models.FilesystemUsage) error
 	GetLatestFilesystemUsage(ctx context.Context) ([]*models.FilesystemUsage, error)
+	RetainFilesystemMounts(ctx context.Context, mountPoints []string) error
+	SetFilesystemStatus(ctx context.Context, mountPoint, status, reason string) error
+}
+
+// errStatfsTimeout marks a mount whose statfs did not return in time
+var errStatfsTimeout = errors.New("statfs timed out")
+
+// Config selects the mounts to collect
+type Config struct {
+	Include       []string      // mount point globs to collect (empty = all)
+	Exclude       []string      // mount point globs to skip
+	Types         []string      // filesystem types to collect (empty = device-backed, network and fuse)
+	ExcludeTypes  []string      // filesystem types to skip, besides the pseudo filesystems
+	StatfsTimeout time.Duration // per-mount statfs timeout (default: 5s)
 }
 
 // DiskCollector collects filesystem usage statistics
 type DiskCollector struct {
-	repo     FSRepository
-	interval time.Duration
-	excludes map[string]bool
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
-	mu       sync.Mutex
+	repo         FSRepository
+	interval     time.Duration
+	config       Config
+	types        map[string]bool
+	excludeTypes map[string]bool
+	mountsPath   string
+	statfs       func(path string, stat *syscall.Statfs_t) error
+	pending      map[string]bool // mounts whose statfs has not returned yet
+	pendingMu    sync.Mutex
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	mu           sync.Mutex
 }
 
 // NewDiskCollector creates a new disk usage collector
-func NewDiskCollector(repo FSRepository, interval time.Duration) *DiskCollector {
-	// Default pseudo filesystems to exclude
-	excludes := map[string]bool{
-		"proc":     true,
-		"sysfs":    true,
-		"devpts":   true,
-		"tmpfs":    true,
-		"devtmpfs": true,
-		"cgroup":   true,
-		"cgroup2":  true,
-		"pstore":   true,
-		"bpf":      true,
-		"tracefs":  true,
-		"debugfs":  true,
-		"securityfs": true,
-		"hugetlbfs": true,
-		"mqueue":    true,
+func NewDiskCollector(repo FSRepository, interval time.Duration, cfg Config) *DiskCollector {
+	if cfg.StatfsTimeout <= 0 {
+		cfg.StatfsTimeout = 5 * time.Second
+	}
+
+	types := make(map[string]bool)
+	for _, t := range cfg.Types {
+		types[t] = true
+	}
+	excludeTypes := make(map[string]bool)
+	for _, t := range cfg.ExcludeTypes {
+		excludeTypes[t] = true
 	}
 
 	return &DiskCollector{
-		repo:     repo,
-		interval: interval,
-		excludes: excludes,
+		repo:         repo,
+		interval:     interval,
+		config:       cfg,
+		types:        types,
+		excludeTypes: excludeTypes,
+		mountsPath:   "/proc/mounts",
+		statfs:       syscall.Statfs,
+		pending:      make(map[string]bool),
 	}
 }
 
@@ -96,14 +113,26 @@ func (c *DiskCollector) Stop() {
 // CollectOnce performs a single collection of all filesystem usage
 func (c *DiskCollector) CollectOnce(ctx context.Context) error {
 	mounts, err := c.getMountPoints()
+	listed := err == nil
 	if err != nil {
-		return fmt.Errorf("failed to get mount points: %w", err)
+		mounts = []string{"/"}
 	}
 
 	for _, mount := range mounts {
-		stats, err := c.getFilesystemStats(mount)
+		stats, err := c.getFilesystemStats(ctx, mount)
 		if err != nil {
-			// Log error but continue with other mounts
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Continue with other mounts; a mount that failed keeps its
+			// last usage, flagged so that it is not shown as current
+			status := models.FilesystemError
+			if errors.Is(err, errStatfsTimeout) {
+				status = models.FilesystemStale
+			}
+			if err := c.repo.SetFilesystemStatus(ctx, mount, status, err.Error()); err != nil {
+				return fmt.Errorf("failed to save filesystem status for %s: %w", mount, err)
+			}
 			continue
 		}
 
@@ -112,6 +141,13 @@ func (c *DiskCollector) CollectOnce(ctx context.Context) error {
 		}
 	}
 
+	// Drop mounts that were unmounted or are no longer selected
+	if listed {
+		if err := c.repo.RetainFilesystemMounts(ctx, mounts); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -133,120 +169,76 @@ func (c *DiskCollector) collectLoop(ctx context.Context) {
 	}
 }
 
-// getMountPoints returns a list of non-pseudo filesystem mount points
+// getMountPoints returns the mount points selected by the config. Without
+// include patterns or types, "/" stands in when nothing is selected.
 func (c *DiskCollector) getMountPoints() ([]string, error) {
+	var entries []mountEntry
+	var err error
 	if runtime.GOOS == "darwin" {
-		return c.getMountPointsDarwin()
+		entries, err = readMountsDarwin()
+	} else {
+		entries, err = readMountsLinux(c.mountsPath)
 	}
-	return c.getMountPointsLinux()
-}
-
-// getMountPointsDarwin parses mount command output on macOS
-func (c *DiskCollector) getMountPointsDarwin() ([]string, error) {
-	out, err := exec.Command("mount").Output()
 	if err != nil {
-		return []string{"/"}, nil
-	}
-
-	var mounts []string
-	seenDevices := make(map[string]bool)
-
-	for _, line := range strings.Split(string(out), "\n") {
-		// Format: /dev/disk3s1s1 on / (apfs, sealed, local, read-only, journaled)
-		parts := strings.SplitN(line, " on ", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		device := strings.TrimSpace(parts[0])
-
-		// Only real disk devices
-		if !strings.HasPrefix(device, "/dev/") {
-			continue
-		}
-
-		// Deduplicate by device
-		if seenDevices[device] {
-			continue
-		}
-
-		// Extract mount point (before parenthesized options)
-		rest := parts[1]
-		parenIdx := strings.LastIndex(rest, " (")
-		if parenIdx < 0 {
-			continue
-		}
-		mountPoint := strings.TrimSpace(rest[:parenIdx])
-
-		// Verify the mount point is accessible
-		var stat syscall.Statfs_t
-		if err := syscall.Statfs(mountPoint, &stat); err != nil {
-			continue
-		}
-
-		seenDevices[device] = true
-		mounts = append(mounts, mountPoint)
+		return nil, err
 	}
 
-	if len(mounts) == 0 {
+	mounts := c.selectMounts(entries)
+	if len(mounts) == 0 && len(c.config.Include) == 0 && len(c.types) == 0 {
 		return []string{"/"}, nil
 	}
 	return mounts, nil
 }
 
-// getMountPointsLinux parses /proc/mounts on Linux systems
-func (c *DiskCollector) getMountPointsLinux() ([]string, error) {
-	f, err := os.Open("/proc/mounts")
-	if err != nil {
-		return []string{"/"}, nil
-	}
-	defer f.Close()
-
-	var mounts []string
-	seenDevices := make(map[string]bool)
-
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Format: device mountpoint fstype options dump pass
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
-			continue
-		}
-
-		device := fields[0]
-		mountPoint := fields[1]
-		fsType := fields[2]
+// statfsResult is the outcome of a statfs call
+type statfsResult struct {
+	stat syscall.Statfs_t
+	err  error
+}
 
-		// Skip pseudo filesystems
-		if c.excludes[fsType] {
-			continue
-		}
+// statfsWithTimeout runs statfs, giving up after the configured timeout so
+// that a hung network server cannot stall the collection. A call that
+// timed out keeps running; its mount is skipped until it returns, so that
+// each hung mount holds at most one goroutine.
+func (c *DiskCollector) statfsWithTimeout(ctx context.Context, mountPoint string) (*syscall.Statfs_t, error) {
+	c.pendingMu.Lock()
+	if c.pending[mountPoint] {
+		c.pendingMu.Unlock()
+		return nil, fmt.Errorf("%w: statfs for %s has not returned yet", errStatfsTimeout, mountPoint)
+	}
+	c.pending[mountPoint] = true
+	c.pendingMu.Unlock()
 
-		// Only real device-backed mounts
-		if !strings.HasPrefix(device, "/dev/") {
-			continue
-		}
+	done := make(chan statfsResult, 1)
+	go func() {
+		var r statfsResult
+		r.err = c.statfs(mountPoint, &r.stat)
+		c.pendingMu.Lock()
+		delete(c.pending, mountPoint)
+		c.pendingMu.Unlock()
+		done <- r
+	}()
 
-		// Deduplicate by device (avoids showing bind mounts twice)
-		if seenDevices[device] {
-			continue
+	timer := time.NewTimer(c.config.StatfsTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, fmt.Errorf("statfs failed for %s: %w", mountPoint, r.err)
 		}
-		seenDevices[device] = true
-
-		mounts = append(mounts, mountPoint)
+		return &r.stat, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%w for %s after %s", errStatfsTimeout, mountPoint, c.config.StatfsTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-
-	if len(mounts) == 0 {
-		return []string{"/"}, nil
-	}
-	return mounts, nil
 }
 
 // getFilesystemStats gets usage statistics for a specific mount point
-func (c *DiskCollector) getFilesystemStats(mountPoint string) (*models.FilesystemUsage, error) {
-	var stat syscall.Statfs_t
-	if err := syscall.Statfs(mountPoint, &stat); err != nil {
-		return nil, fmt.Errorf("statfs failed for %s: %w", mountPoint, err)
+func (c *DiskCollector) getFilesystemStats(ctx context.Context, mountPoint string) (*models.FilesystemUsage, error) {
+	stat, err := c.statfsWithTimeout(ctx, mountPoint)
+	if err != nil {
+		return nil, err
 	}
 
 	// Calculate sizes based on OS
@@ -267,12 +259,27 @@ func (c *DiskCollector) getFilesystemStats(mountPoint string) (*models.Filesyste
 		usedPercent = float64(usedBytes) / float64(totalBytes) * 100
 	}
 
+	// Small-file landing zones can run out of inodes long before bytes
+	inodesTotal := uint64(stat.Files)
+	inodesFree := uint64(stat.Ffree)
+	var inodesUsed uint64
+	var inodesUsedPercent float64
+	if inodesTotal > 0 && inodesFree <= inodesTotal {
+		inodesUsed = inodesTotal - inodesFree
+		inodesUsedPercent = float64(inodesUsed) / float64(inodesTotal) * 100
+	}
+
 	return &models.FilesystemUsage{
-		MountPoint:  mountPoint,
-		TotalBytes:  totalBytes,
-		UsedBytes:   usedBytes,
-		AvailBytes:  availBytes,
-		UsedPercent: usedPercent,
-		CollectedAt: time.Now(),
+		MountPoint:        mountPoint,
+		TotalBytes:        totalBytes,
+		UsedBytes:         usedBytes,
+		AvailBytes:        availBytes,
+		UsedPercent:       usedPercent,
+		InodesTotal:       inodesTotal,
+		InodesUsed:        inodesUsed,
+		InodesFree:        inodesFree,
+		InodesUsedPercent: inodesUsedPercent,
+		Status:            models.FilesystemOK,
+		CollectedAt:       time.Now(),
 	}, nil
 }
@@ -2,6 +2,12 @@ package disk
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -12,6 +18,8 @@ import (
 type MockFSRepository struct {
 	savedUsage []*models.FilesystemUsage
 	saveError  error
+	retained   []string
+	statuses   map[string]string
 }
 
 func (m *MockFSRepository) SaveFilesystemUsage(ctx context.Context, usage *models.FilesystemUsage) error {
@@ -26,9 +34,22 @@ func (m *MockFSRepository) GetLatestFilesystemUsage(ctx context.Context) ([]*mod
 	return m.savedUsage, nil
 }
 
+func (m *MockFSRepository) RetainFilesystemMounts(ctx context.Context, mountPoints []string) error {
+	m.retained = mountPoints
+	return nil
+}
+
+func (m *MockFSRepository) SetFilesystemStatus(ctx context.Context, mountPoint, status, reason string) error {
+	if m.statuses == nil {
+		m.statuses = make(map[string]string)
+	}
+	m.statuses[mountPoint] = status
+	return nil
+}
+
 func TestDiskCollector_getMountPoints_ReturnsNonPseudoMounts(t *testing.T) {
 	repo := &MockFSRepository{}
-	collector := NewDiskCollector(repo, 1*time.Second)
+	collector := NewDiskCollector(repo, 1*time.Second, Config{})
 
 	mounts, err := collector.getMountPoints()
 	if err != nil {
@@ -58,10 +79,10 @@ func TestDiskCollector_getMountPoints_ReturnsNonPseudoMounts(t *testing.T) {
 
 func TestDiskCollector_getFilesystemStats_CalculatesCorrectly(t *testing.T) {
 	repo := &MockFSRepository{}
-	collector := NewDiskCollector(repo, 1*time.Second)
+	collector := NewDiskCollector(repo, 1*time.Second, Config{})
 
 	// Test with root filesystem (should always exist)
-	stats, err := collector.getFilesystemStats("/")
+	stats, err := collector.getFilesystemStats(context.Background(), "/")
 	if err != nil {
 		t.Fatalf("getFilesystemStats() error = %v", err)
 	}
@@ -96,9 +117,40 @@ func TestDiskCollector_getFilesystemStats_CalculatesCorrectly(t *testing.T) {
 	}
 }
 
+func TestDiskCollector_getFilesystemStats_CountsInodes(t *testing.T) {
+	repo := &MockFSRepository{}
+	collector := NewDiskCollector(repo, 1*time.Second, Config{})
+
+	stats, err := collector.getFilesystemStats(context.Background(), t.TempDir())
+	if err != nil {
+		t.Fatalf("getFilesystemStats() error = %v", err)
+	}
+
+	// Some filesystems (e.g. btrfs) report no inode count
+	if stats.InodesTotal == 0 {
+		if stats.InodesUsed != 0 || stats.InodesUsedPercent != 0 {
+			t.Errorf("expected no inode usage without an inode count, got %+v", stats)
+		}
+		return
+	}
+
+	if stats.InodesUsed+stats.InodesFree != stats.InodesTotal {
+		t.Errorf("InodesUsed (%d) + InodesFree (%d) should equal InodesTotal (%d)",
+			stats.InodesUsed, stats.InodesFree, stats.InodesTotal)
+	}
+	// The temp directory itself takes an inode
+	if stats.InodesUsed == 0 {
+		t.Error("InodesUsed should not be zero")
+	}
+	expectedPercent := float64(stats.InodesUsed) / float64(stats.InodesTotal) * 100
+	if stats.InodesUsedPercent < expectedPercent-0.1 || stats.InodesUsedPercent > expectedPercent+0.1 {
+		t.Errorf("InodesUsedPercent = %.2f, want approximately %.2f", stats.InodesUsedPercent, expectedPercent)
+	}
+}
+
 func TestDiskCollector_CollectOnce_SavesAllMounts(t *testing.T) {
 	repo := &MockFSRepository{}
-	collector := NewDiskCollector(repo, 1*time.Second)
+	collector := NewDiskCollector(repo, 1*time.Second, Config{})
 
 	ctx := context.Background()
 	err := collector.CollectOnce(ctx)
@@ -128,7 +180,7 @@ func TestDiskCollector_CollectOnce_SavesAllMounts(t *testing.T) {
 func TestDiskCollector_Start_CollectsAtInterval(t *testing.T) {
 	repo := &MockFSRepository{}
 	interval := 100 * time.Millisecond
-	collector := NewDiskCollector(repo, interval)
+	collector := NewDiskCollector(repo, interval, Config{})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
 	defer cancel()
@@ -151,7 +203,7 @@ func TestDiskCollector_Start_CollectsAtInterval(t *testing.T) {
 
 func TestDiskCollector_ExcludesPseudoFS(t *testing.T) {
 	repo := &MockFSRepository{}
-	collector := NewDiskCollector(repo, 1*time.Second)
+	collector := NewDiskCollector(repo, 1*time.Second, Config{})
 
 	ctx := context.Background()
 	err := collector.CollectOnce(ctx)
@@ -178,7 +230,7 @@ func TestDiskCollector_ExcludesPseudoFS(t *testing.T) {
 func TestDiskCollector_Stop_StopsCollection(t *testing.T) {
 	repo := &MockFSRepository{}
 	interval := 50 * time.Millisecond
-	collector := NewDiskCollector(repo, interval)
+	collector := NewDiskCollector(repo, interval, Config{})
 
 	ctx := context.Background()
 	err := collector.Start(ctx)
@@ -203,3 +255,80 @@ func TestDiskCollector_Stop_StopsCollection(t *testing.T) {
 		t.Errorf("Collections continued after Stop(). Count at stop: %d, Final: %d", countAfterStop, finalCount)
 	}
 }
+
+func TestDiskCollector_CollectOnce_RetainsSelectedMounts(t *testing.T) {
+	dir := t.TempDir()
+	mountsPath := filepath.Join(dir, "mounts")
+	content := "/dev/sda1 / ext4 rw 0 0\nnas01:/export /mnt/etl nfs4 rw 0 0\n"
+	if err := os.WriteFile(mountsPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &MockFSRepository{}
+	collector := NewDiskCollector(repo, time.Second, Config{})
+	collector.mountsPath = mountsPath
+	collector.statfs = func(path string, stat *syscall.Statfs_t) error {
+		if path == "/mnt/etl" {
+			return syscall.EIO
+		}
+		stat.Blocks, stat.Bsize = 100, 4096
+		return nil
+	}
+
+	if err := collector.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce() error = %v", err)
+	}
+
+	if len(repo.savedUsage) != 1 || repo.savedUsage[0].MountPoint != "/" {
+		t.Errorf("expected only / to be saved, got %+v", repo.savedUsage)
+	}
+	if got := repo.statuses["/mnt/etl"]; got != models.FilesystemError {
+		t.Errorf("status of /mnt/etl = %q, want %q", got, models.FilesystemError)
+	}
+	// A failing mount keeps its last usage
+	if want := []string{"/", "/mnt/etl"}; !reflect.DeepEqual(repo.retained, want) {
+		t.Errorf("retained = %v, want %v", repo.retained, want)
+	}
+}
+
+func TestDiskCollector_getFilesystemStats_TimesOut(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	collector := NewDiskCollector(&MockFSRepository{}, time.Second, Config{StatfsTimeout: 50 * time.Millisecond})
+	collector.statfs = func(path string, stat *syscall.Statfs_t) error {
+		calls.Add(1)
+		<-release // a hung NFS server
+		return nil
+	}
+
+	start := time.Now()
+	if _, err := collector.getFilesystemStats(context.Background(), "/mnt/etl"); !errors.Is(err, errStatfsTimeout) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("getFilesystemStats() took %s, want about the timeout", elapsed)
+	}
+
+	// The hung call is not repeated while it is still pending, and the
+	// mount is flagged stale
+	if _, err := collector.getFilesystemStats(context.Background(), "/mnt/etl"); !errors.Is(err, errStatfsTimeout) {
+		t.Fatalf("expected a timeout error while statfs is pending, got %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("statfs called %d times, want 1", n)
+	}
+
+	// Once the server answers, the mount is collected again
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := collector.getFilesystemStats(context.Background(), "/mnt/etl"); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("mount was not collected after statfs returned")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,180 @@
+package disk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/etlmon/etlmon/internal/mountinfo"
+)
+
+// pseudoTypes are filesystems that never hold data worth reporting; they
+// are skipped even when their source looks like a device
+var pseudoTypes = map[string]bool{
+	"proc":            true,
+	"sysfs":           true,
+	"devpts":          true,
+	"tmpfs":           true,
+	"devtmpfs":        true,
+	"devfs":           true,
+	"cgroup":          true,
+	"cgroup2":         true,
+	"pstore":          true,
+	"bpf":             true,
+	"tracefs":         true,
+	"debugfs":         true,
+	"securityfs":      true,
+	"hugetlbfs":       true,
+	"mqueue":          true,
+	"autofs":          true,
+	"binfmt_misc":     true,
+	"configfs":        true,
+	"fusectl":         true,
+	"nsfs":            true,
+	"rpc_pipefs":      true,
+	"fuse.lxcfs":      true,
+	"fuse.portal":     true,
+	"fuse.gvfsd-fuse": true,
+}
+
+// mountEntry is one filesystem in the mount table
+type mountEntry struct {
+	source string
+	point  string
+	fsType string
+}
+
+// isFuse reports whether a filesystem type is served by a fuse daemon
+func isFuse(fsType string) bool {
+	return fsType == "fuse" || fsType == "fuseblk" || strings.HasPrefix(fsType, "fuse.")
+}
+
+// selectMounts returns the mount points to collect, in mount table order.
+// Without configured types, device-backed, network and fuse filesystems
+// are collected. Device-backed and network mounts of the same source are
+// reported once, so that bind mounts do not show up twice.
+func (c *DiskCollector) selectMounts(entries []mountEntry) []string {
+	var mounts []string
+	seenSources := make(map[string]bool)
+	seenPoints := make(map[string]bool)
+
+	for _, e := range entries {
+		if c.excludeTypes[e.fsType] || (pseudoTypes[e.fsType] && !c.types[e.fsType]) {
+			continue
+		}
+
+		device := strings.HasPrefix(e.source, "/dev/")
+		network := mountinfo.IsNetwork(e.fsType)
+		if len(c.types) > 0 {
+			if !c.types[e.fsType] {
+				continue
+			}
+		} else if !device && !network && !isFuse(e.fsType) {
+			continue
+		}
+
+		if !c.matchMountPoint(e.point) || seenPoints[e.point] {
+			continue
+		}
+		if device || network {
+			if seenSources[e.source] {
+				continue
+			}
+			seenSources[e.source] = true
+		}
+		seenPoints[e.point] = true
+
+		mounts = append(mounts, e.point)
+	}
+	return mounts
+}
+
+// matchMountPoint applies the include and exclude globs to a mount point
+func (c *DiskCollector) matchMountPoint(point string) bool {
+	for _, pattern := range c.config.Exclude {
+		if ok, _ := filepath.Match(pattern, point); ok {
+			return false
+		}
+	}
+	if len(c.config.Include) == 0 {
+		return true
+	}
+	for _, pattern := range c.config.Include {
+		if ok, _ := filepath.Match(pattern, point); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readMountsLinux reads the mount table from /proc/mounts
+func readMountsLinux(path string) ([]mountEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	defer f.Close()
+	return parseMountsLinux(f)
+}
+
+// parseMountsLinux parses /proc/mounts content
+func parseMountsLinux(r io.Reader) ([]mountEntry, error) {
+	var entries []mountEntry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Format: device mountpoint fstype options dump pass
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		entries = append(entries, mountEntry{
+			source: mountinfo.Unescape(fields[0]),
+			point:  mountinfo.Unescape(fields[1]),
+			fsType: fields[2],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	return entries, nil
+}
+
+// readMountsDarwin reads the mount table from the mount command on macOS
+func readMountsDarwin() ([]mountEntry, error) {
+	out, err := exec.Command("mount").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run mount: %w", err)
+	}
+	return parseMountsDarwin(string(out)), nil
+}
+
+// parseMountsDarwin parses mount command output on macOS
+func parseMountsDarwin(out string) []mountEntry {
+	var entries []mountEntry
+	for _, line := range strings.Split(out, "\n") {
+		// Format: /dev/disk3s1s1 on / (apfs, sealed, local, read-only, journaled)
+		source, rest, ok := strings.Cut(line, " on ")
+		if !ok {
+			continue
+		}
+
+		// Mount point before the parenthesized type and options
+		parenIdx := strings.LastIndex(rest, " (")
+		if parenIdx < 0 {
+			continue
+		}
+		options := strings.TrimSuffix(rest[parenIdx+2:], ")")
+		fsType, _, _ := strings.Cut(options, ",")
+
+		entries = append(entries, mountEntry{
+			source: strings.TrimSpace(source),
+			point:  strings.TrimSpace(rest[:parenIdx]),
+			fsType: strings.TrimSpace(fsType),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,86 @@
+package disk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testProcMounts = `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda1 / ext4 rw,relatime 0 0
+tmpfs /run tmpfs rw,nosuid,nodev 0 0
+/dev/sdb1 /data xfs rw,relatime 0 0
+/dev/sdb1 /var/lib/landing xfs rw,relatime 0 0
+nas01:/export/etl /mnt/etl nfs4 rw,relatime,vers=4.2 0 0
+//fs01/share /mnt/share\040drive cifs rw,relatime 0 0
+lxcfs /var/lib/lxcfs fuse.lxcfs rw,nosuid,nodev 0 0
+s3fs /mnt/s3 fuse.s3fs rw,nosuid,nodev 0 0
+10.0.0.1:6789:/ /mnt/ceph ceph rw,relatime 0 0
+overlay /var/lib/docker/overlay2/abc/merged overlay rw,relatime 0 0
+systemd-1 /mnt/auto autofs rw,relatime 0 0
+`
+
+func selectTestMounts(t *testing.T, cfg Config) []string {
+	t.Helper()
+	entries, err := parseMountsLinux(strings.NewReader(testProcMounts))
+	if err != nil {
+		t.Fatalf("parseMountsLinux() error = %v", err)
+	}
+	return NewDiskCollector(&MockFSRepository{}, time.Second, cfg).selectMounts(entries)
+}
+
+func TestSelectMounts_DefaultIncludesNetworkAndFuse(t *testing.T) {
+	got := selectTestMounts(t, Config{})
+	want := []string{"/", "/data", "/mnt/etl", "/mnt/share drive", "/mnt/s3", "/mnt/ceph"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectMounts_IncludeExcludeGlobs(t *testing.T) {
+	got := selectTestMounts(t, Config{
+		Include: []string{"/mnt/*", "/var/lib/*"},
+		Exclude: []string{"/mnt/s3"},
+	})
+	// The bind mount of /data is reported since /data itself is not included
+	want := []string{"/var/lib/landing", "/mnt/etl", "/mnt/share drive", "/mnt/ceph"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectMounts_Types(t *testing.T) {
+	got := selectTestMounts(t, Config{Types: []string{"nfs4", "tmpfs"}})
+	want := []string{"/run", "/mnt/etl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectMounts_ExcludeTypes(t *testing.T) {
+	got := selectTestMounts(t, Config{ExcludeTypes: []string{"cifs", "fuse.s3fs"}})
+	want := []string{"/", "/data", "/mnt/etl", "/mnt/ceph"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestParseMountsDarwin(t *testing.T) {
+	out := `/dev/disk3s1s1 on / (apfs, sealed, local, read-only, journaled)
+devfs on /dev (devfs, local, nobrowse)
+//etl@fs01/share on /Volumes/share (smbfs, nodev, nosuid, mounted by etl)
+nas01:/export/etl on /Volumes/etl data (nfs, asynchronous)
+`
+	got := parseMountsDarwin(out)
+	want := []mountEntry{
+		{source: "/dev/disk3s1s1", point: "/", fsType: "apfs"},
+		{source: "devfs", point: "/dev", fsType: "devfs"},
+		{source: "//etl@fs01/share", point: "/Volumes/share", fsType: "smbfs"},
+		{source: "nas01:/export/etl", point: "/Volumes/etl data", fsType: "nfs"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMountsDarwin() = %+v, want %+v", got, want)
+	}
+}
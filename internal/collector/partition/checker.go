@@ -0,0 +1,214 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// statTimeout bounds a single stat, so that a hung mount cannot stall a check
+const statTimeout = 10 * time.Second
+
+// Repository defines the interface for storing partition statuses
+type Repository interface {
+	SavePartitionStatus(ctx context.Context, check string, statuses []*models.PartitionStatus) error
+	RetainPartitionChecks(ctx context.Context, checks []string) error
+}
+
+// CheckConfig represents a completeness check for date partitions
+type CheckConfig struct {
+	Name     string
+	Path     string         // Partition directory with {YYYY}, {MM}, {DD} and optionally {HH} placeholders
+	Marker   string         // File, relative to the partition, whose presence marks it complete
+	Deadline time.Duration  // How long after its day or hour ends a partition must be complete
+	Lookback int            // Partitions checked, counting back from the current one
+	Interval time.Duration  // How often the check runs
+	Location *time.Location // Time zone of the partition dates (nil = UTC)
+}
+
+// Checker periodically reports whether date partitions are present, late or missing
+type Checker struct {
+	repo   Repository
+	checks []CheckConfig
+	stat   func(name string) (fs.FileInfo, error)
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+// NewChecker creates a new partition checker
+func NewChecker(repo Repository, checks []CheckConfig) *Checker {
+	return &Checker{
+		repo:   repo,
+		checks: checks,
+		stat:   os.Stat,
+	}
+}
+
+// Start removes the stored partitions of checks that are no longer
+// configured and begins periodic checking
+func (c *Checker) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("checker already started")
+	}
+
+	// Create cancellable context
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	names := make([]string, len(c.checks))
+	for i, check := range c.checks {
+		names[i] = check.Name
+	}
+	if err := c.repo.RetainPartitionChecks(ctx, names); err != nil {
+		return err
+	}
+
+	for _, check := range c.checks {
+		c.wg.Add(1)
+		go func(check CheckConfig) {
+			defer c.wg.Done()
+			c.checkLoop(ctx, check)
+		}(check)
+	}
+
+	return nil
+}
+
+// Stop stops all partition checks
+func (c *Checker) Stop() {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+	c.mu.Unlock()
+
+	c.wg.Wait()
+}
+
+// checkLoop runs the periodic checking of a single check
+func (c *Checker) checkLoop(ctx context.Context, check CheckConfig) {
+	ticker := time.NewTicker(check.Interval)
+	defer ticker.Stop()
+
+	// Check immediately on start
+	_ = c.CheckOnce(ctx, check)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.CheckOnce(ctx, check)
+		}
+	}
+}
+
+// CheckOnce checks the partitions of a check and stores their statuses. A
+// round interrupted by an unresponsive filesystem stores nothing, so the
+// previous statuses are kept rather than reported as missing.
+func (c *Checker) CheckOnce(ctx context.Context, check CheckConfig) error {
+	statuses, err := c.Check(ctx, check, time.Now())
+	if err != nil {
+		return fmt.Errorf("partition check %s: %w", check.Name, err)
+	}
+	if err := c.repo.SavePartitionStatus(ctx, check.Name, statuses); err != nil {
+		return fmt.Errorf("failed to save partition status: %w", err)
+	}
+	return nil
+}
+
+// Check returns the status of the Lookback partitions up to and including
+// the one holding now, newest first
+func (c *Checker) Check(ctx context.Context, check CheckConfig, now time.Time) ([]*models.PartitionStatus, error) {
+	loc := check.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	tmpl := newTemplate(check.Path)
+	local := now.In(loc)
+
+	statuses := make([]*models.PartitionStatus, 0, check.Lookback)
+	for i := 0; i < check.Lookback; i++ {
+		start := tmpl.periodStart(local, i)
+		p := &models.PartitionStatus{
+			Check:       check.Name,
+			Partition:   tmpl.format(start),
+			PeriodStart: start,
+			Due:         tmpl.periodEnd(start).Add(check.Deadline),
+			CheckedAt:   now,
+		}
+		status, arrived, err := c.partitionStatus(ctx, p.Partition, check.Marker, now.Before(p.Due))
+		if err != nil {
+			return nil, err
+		}
+		p.Status, p.ArrivedAt = status, arrived
+		statuses = append(statuses, p)
+	}
+	return statuses, nil
+}
+
+// partitionStatus looks for the marker of one partition. Without a marker,
+// a partition is PENDING before its deadline; after it, LATE when the
+// partition directory exists and MISSING when it does not.
+func (c *Checker) partitionStatus(ctx context.Context, dir, marker string, beforeDue bool) (string, *time.Time, error) {
+	info, err := c.statWithTimeout(ctx, filepath.Join(dir, marker))
+	if err == nil {
+		arrived := info.ModTime()
+		return models.PartitionPresent, &arrived, nil
+	}
+	if isTimeout(err) {
+		return "", nil, err
+	}
+	if beforeDue {
+		return models.PartitionPending, nil, nil
+	}
+
+	if _, err := c.statWithTimeout(ctx, dir); err == nil {
+		return models.PartitionLate, nil, nil
+	} else if isTimeout(err) {
+		return "", nil, err
+	}
+	return models.PartitionMissing, nil, nil
+}
+
+// statWithTimeout stats name, giving up after statTimeout or when ctx ends.
+// An abandoned stat finishes in the background.
+func (c *Checker) statWithTimeout(ctx context.Context, name string) (fs.FileInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, statTimeout)
+	defer cancel()
+
+	type result struct {
+		info fs.FileInfo
+		err  error
+	}
+	done := make(chan result, 1)
+	stat := c.stat
+	go func() {
+		info, err := stat(name)
+		done <- result{info, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.info, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("stat %s: %w", name, ctx.Err())
+	}
+}
+
+// isTimeout reports whether err comes from a stat that did not return in time
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,178 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// MockRepository is a mock implementation of the Repository for testing
+type MockRepository struct {
+	mu       sync.Mutex
+	saved    map[string][]*models.PartitionStatus
+	retained []string
+}
+
+func (m *MockRepository) SavePartitionStatus(ctx context.Context, check string, statuses []*models.PartitionStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.saved == nil {
+		m.saved = make(map[string][]*models.PartitionStatus)
+	}
+	m.saved[check] = statuses
+	return nil
+}
+
+func (m *MockRepository) RetainPartitionChecks(ctx context.Context, checks []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retained = checks
+	return nil
+}
+
+func (m *MockRepository) get(check string) []*models.PartitionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saved[check]
+}
+
+// writeMarker creates a partition directory with a marker modified at mtime
+func writeMarker(t *testing.T, dir string, mtime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	marker := filepath.Join(dir, "_SUCCESS")
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(marker, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChecker_Check_ReportsEachStatus(t *testing.T) {
+	root := t.TempDir()
+	check := CheckConfig{
+		Name:     "events",
+		Path:     filepath.Join(root, "dt={YYYY}-{MM}-{DD}"),
+		Marker:   "_SUCCESS",
+		Deadline: 6 * time.Hour,
+		Lookback: 5,
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	// Oct 19: in progress. Oct 18: due at 06:00 today, arrived 05:00.
+	// Oct 17: arrived after its deadline. Oct 16: started, no marker. Oct 15: nothing.
+	writeMarker(t, filepath.Join(root, "dt=2026-10-18"), time.Date(2026, 10, 19, 5, 0, 0, 0, time.UTC))
+	writeMarker(t, filepath.Join(root, "dt=2026-10-17"), time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC))
+	if err := os.MkdirAll(filepath.Join(root, "dt=2026-10-16"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewChecker(&MockRepository{}, []CheckConfig{check})
+	statuses, err := c.Check(context.Background(), check, now)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	want := []struct {
+		day    string
+		status string
+		late   bool
+	}{
+		{"2026-10-19", models.PartitionPending, false},
+		{"2026-10-18", models.PartitionPresent, false},
+		{"2026-10-17", models.PartitionPresent, true},
+		{"2026-10-16", models.PartitionLate, false},
+		{"2026-10-15", models.PartitionMissing, false},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("Expected %d partitions, got %d", len(want), len(statuses))
+	}
+	for i, w := range want {
+		p := statuses[i]
+		if p.Partition != filepath.Join(root, "dt="+w.day) || p.Status != w.status {
+			t.Errorf("partition %d = %s %s, want dt=%s %s", i, p.Partition, p.Status, w.day, w.status)
+		}
+		if p.ArrivedLate() != w.late {
+			t.Errorf("partition %s: ArrivedLate = %v, want %v", w.day, p.ArrivedLate(), w.late)
+		}
+		if p.Check != "events" || !p.CheckedAt.Equal(now) {
+			t.Errorf("partition %s: unexpected check %q or checked_at %v", w.day, p.Check, p.CheckedAt)
+		}
+	}
+	if due := statuses[1].Due; !due.Equal(time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Oct 18 due at 06:00 on Oct 19, got %v", due)
+	}
+}
+
+func TestChecker_CheckOnce_KeepsPreviousStatusOnHungStat(t *testing.T) {
+	check := CheckConfig{Name: "events", Path: "/nfs/events/dt={YYYY}-{MM}-{DD}", Marker: "_SUCCESS", Lookback: 2}
+	repo := &MockRepository{}
+	c := NewChecker(repo, []CheckConfig{check})
+
+	release := make(chan struct{})
+	defer close(release)
+	c.stat = func(name string) (fs.FileInfo, error) {
+		<-release
+		return nil, fs.ErrNotExist
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := c.CheckOnce(ctx, check)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if repo.get("events") != nil {
+		t.Error("Expected nothing stored after an interrupted check")
+	}
+}
+
+func TestChecker_Start_StoresAndRetainsChecks(t *testing.T) {
+	root := t.TempDir()
+	check := CheckConfig{
+		Name:     "events",
+		Path:     filepath.Join(root, "dt={YYYY}-{MM}-{DD}"),
+		Marker:   "_SUCCESS",
+		Lookback: 3,
+		Interval: time.Hour,
+	}
+	repo := &MockRepository{}
+	c := NewChecker(repo, []CheckConfig{check})
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer c.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.get("events") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the first check")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := repo.get("events"); len(got) != 3 {
+		t.Errorf("Expected 3 partitions, got %d", len(got))
+	}
+
+	repo.mu.Lock()
+	retained := repo.retained
+	repo.mu.Unlock()
+	if len(retained) != 1 || retained[0] != "events" {
+		t.Errorf("Expected only events to be retained, got %v", retained)
+	}
+
+	if err := c.Start(context.Background()); err == nil {
+		t.Error("Expected an error when starting twice")
+	}
+}
@@ -0,0 +1,45 @@
+package partition
+
+import (
+	"strings"
+	"time"
+)
+
+// template is a partition path with date placeholders
+type template struct {
+	pattern string
+	hourly  bool // Partitions cover an hour ({HH} is present) rather than a day
+}
+
+func newTemplate(pattern string) template {
+	return template{pattern: pattern, hourly: strings.Contains(pattern, "{HH}")}
+}
+
+// format returns the partition directory of the period starting at start
+func (t template) format(start time.Time) string {
+	return strings.NewReplacer(
+		"{YYYY}", start.Format("2006"),
+		"{MM}", start.Format("01"),
+		"{DD}", start.Format("02"),
+		"{HH}", start.Format("15"),
+	).Replace(t.pattern)
+}
+
+// periodStart returns the start of the period i periods before the one
+// holding now, in now's location
+func (t template) periodStart(now time.Time, i int) time.Time {
+	if t.hourly {
+		hour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+		return hour.Add(-time.Duration(i) * time.Hour)
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return day.AddDate(0, 0, -i)
+}
+
+// periodEnd returns the end of the period starting at start
+func (t template) periodEnd(start time.Time) time.Time {
+	if t.hourly {
+		return start.Add(time.Hour)
+	}
+	return start.AddDate(0, 0, 1)
+}
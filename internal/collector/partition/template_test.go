@@ -0,0 +1,56 @@
+package partition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplate_DailyPeriods(t *testing.T) {
+	tmpl := newTemplate("/warehouse/events/dt={YYYY}-{MM}-{DD}")
+	now := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	start := tmpl.periodStart(now, 1)
+	if want := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("periodStart(now, 1) = %v, want %v", start, want)
+	}
+	if got := tmpl.format(start); got != "/warehouse/events/dt=2026-02-28" {
+		t.Errorf("format = %q", got)
+	}
+	if end := tmpl.periodEnd(start); !end.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("periodEnd = %v", end)
+	}
+}
+
+func TestTemplate_HourlyPeriods(t *testing.T) {
+	tmpl := newTemplate("/warehouse/clicks/{YYYY}/{MM}/{DD}/{HH}")
+	if !tmpl.hourly {
+		t.Fatal("Expected an {HH} template to be hourly")
+	}
+	now := time.Date(2026, 1, 1, 0, 45, 0, 0, time.UTC)
+
+	start := tmpl.periodStart(now, 1)
+	if got := tmpl.format(start); got != "/warehouse/clicks/2025/12/31/23" {
+		t.Errorf("format = %q", got)
+	}
+	if end := tmpl.periodEnd(start); !end.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("periodEnd = %v", end)
+	}
+}
+
+func TestTemplate_DailyPeriodsFollowLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	tmpl := newTemplate("dt={YYYY}-{MM}-{DD}")
+	// 23:30 UTC on March 28 is already March 29 in Berlin, the day clocks go forward
+	now := time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC).In(loc)
+
+	start := tmpl.periodStart(now, 0)
+	if got := tmpl.format(start); got != "dt=2026-03-29" {
+		t.Errorf("format = %q", got)
+	}
+	if d := tmpl.periodEnd(start).Sub(start); d != 23*time.Hour {
+		t.Errorf("Expected a 23h day at the DST change, got %v", d)
+	}
+}
@@ -0,0 +1,104 @@
+package path
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// maxBreakdownDirs caps the directories recorded per breakdown. The largest
+// subtrees are kept; a directory is never smaller than its subdirectories,
+// so the kept entries still form a tree.
+const maxBreakdownDirs = 5000
+
+// breakdown accumulates du-style subtree sizes down to a fixed depth
+type breakdown struct {
+	depth int
+	dirs  map[string]*models.PathDirUsage
+}
+
+func newBreakdown(depth int) *breakdown {
+	return &breakdown{
+		depth: depth,
+		dirs:  map[string]*models.PathDirUsage{".": {Dir: "."}},
+	}
+}
+
+// dir returns the entry of a directory, creating it on first use
+func (b *breakdown) dir(rel string, depth int) *models.PathDirUsage {
+	u, ok := b.dirs[rel]
+	if !ok {
+		u = &models.PathDirUsage{Dir: rel, Depth: depth}
+		b.dirs[rel] = u
+	}
+	return u
+}
+
+// add counts an entry, given relative to the monitored path, in the root
+// and in every ancestor directory within the breakdown depth. A directory
+// within the depth also gets its own entry, so empty ones are listed.
+func (b *breakdown) add(rel string, isDir bool, size int64) {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i := 0; i < len(parts) && i <= b.depth; i++ {
+		u := b.dir(ancestor(parts, i), i)
+		if isDir {
+			u.DirCount++
+		} else {
+			u.FileCount++
+			u.SizeBytes += size
+		}
+	}
+	if isDir && len(parts) <= b.depth {
+		b.dir(rel, len(parts))
+	}
+}
+
+// ancestor returns the directory formed by the first n parts ("." for n == 0)
+func ancestor(parts []string, n int) string {
+	if n == 0 {
+		return "."
+	}
+	return filepath.Join(parts[:n]...)
+}
+
+// result returns the entries of a completed walk, shallowest first, keeping
+// the maxBreakdownDirs largest subtrees
+func (b *breakdown) result(path string, collectedAt time.Time) []*models.PathDirUsage {
+	out := make([]*models.PathDirUsage, 0, len(b.dirs))
+	for _, u := range b.dirs {
+		u.Path = path
+		u.CollectedAt = collectedAt
+		out = append(out, u)
+	}
+
+	if len(out) > maxBreakdownDirs {
+		sort.Slice(out, func(i, j int) bool {
+			if out[i].SizeBytes != out[j].SizeBytes {
+				return out[i].SizeBytes > out[j].SizeBytes
+			}
+			return out[i].Depth < out[j].Depth
+		})
+		out = out[:maxBreakdownDirs]
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Depth != out[j].Depth {
+			return out[i].Depth < out[j].Depth
+		}
+		return out[i].Dir < out[j].Dir
+	})
+	return out
+}
+
+// relPath returns path relative to the monitored root, or path itself if it
+// is not below the root
+func relPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,114 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// setupBreakdownDir creates:
+//
+//	root/
+//	  top.csv (5 bytes)
+//	  a/x.csv (10 bytes)
+//	  a/b/y.csv (20 bytes)
+//	  a/b/c/z.csv (40 bytes)
+//	  empty/
+func setupBreakdownDir(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"a/b/c", "empty"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, size := range map[string]int{"top.csv": 5, "a/x.csv": 10, "a/b/y.csv": 20, "a/b/c/z.csv": 40} {
+		if err := os.WriteFile(filepath.Join(root, name), make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func breakdownByDir(usage []*models.PathDirUsage) map[string]*models.PathDirUsage {
+	m := make(map[string]*models.PathDirUsage)
+	for _, u := range usage {
+		m[u.Dir] = u
+	}
+	return m
+}
+
+func TestPathScanner_Breakdown_RecordsSubtreesToDepth(t *testing.T) {
+	root := setupBreakdownDir(t)
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: root, Timeout: 30 * time.Second, BreakdownDepth: 2}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	if err := scanner.TriggerScan(context.Background(), []string{root}); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+
+	usage := repo.savedBreakdown[root]
+	got := breakdownByDir(usage)
+	want := []models.PathDirUsage{
+		{Dir: ".", Depth: 0, FileCount: 4, DirCount: 4, SizeBytes: 75},
+		{Dir: "a", Depth: 1, FileCount: 3, DirCount: 2, SizeBytes: 70},
+		{Dir: "empty", Depth: 1},
+		{Dir: "a/b", Depth: 2, FileCount: 2, DirCount: 1, SizeBytes: 60},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("recorded %d dirs, want %d: %v", len(usage), len(want), got)
+	}
+	for _, w := range want {
+		u, ok := got[w.Dir]
+		if !ok {
+			t.Errorf("missing %s", w.Dir)
+			continue
+		}
+		if u.Depth != w.Depth || u.FileCount != w.FileCount || u.DirCount != w.DirCount || u.SizeBytes != w.SizeBytes {
+			t.Errorf("%s = %+v, want %+v", w.Dir, *u, w)
+		}
+		if u.Path != root || u.CollectedAt.IsZero() {
+			t.Errorf("%s: path %q, collected at %v", w.Dir, u.Path, u.CollectedAt)
+		}
+	}
+	// Shallowest first, so parents precede their children
+	if usage[0].Dir != "." || usage[len(usage)-1].Dir != "a/b" {
+		t.Errorf("unexpected order: first %s, last %s", usage[0].Dir, usage[len(usage)-1].Dir)
+	}
+}
+
+func TestPathScanner_Breakdown_DisabledByDefault(t *testing.T) {
+	root := setupBreakdownDir(t)
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: root, Timeout: 30 * time.Second}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	if err := scanner.TriggerScan(context.Background(), []string{root}); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+	if _, ok := repo.savedBreakdown[root]; ok {
+		t.Error("Expected no breakdown without breakdown_depth")
+	}
+}
+
+func TestBreakdown_ResultKeepsLargestSubtrees(t *testing.T) {
+	b := newBreakdown(2)
+	for i := 0; i < maxBreakdownDirs+10; i++ {
+		b.add(filepath.Join("d", fmt.Sprintf("sub%05d", i), "f"), false, int64(i))
+	}
+
+	usage := b.result("/data", time.Now())
+	if len(usage) != maxBreakdownDirs {
+		t.Fatalf("kept %d dirs, want %d", len(usage), maxBreakdownDirs)
+	}
+	got := breakdownByDir(usage)
+	if got["."] == nil || got["d"] == nil {
+		t.Error("Expected the root and its child to survive trimming")
+	}
+}
@@ -0,0 +1,65 @@
+package path
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// bucketSet sorts the files of a walk into a path's named buckets
+type bucketSet struct {
+	root     string
+	names    []string // Bucket names, sorted
+	patterns []pathPattern
+}
+
+// newBucketSet compiles the bucket patterns of cfg. Buckets use the same
+// pattern syntax as include and exclude.
+func newBucketSet(cfg PathConfig) (*bucketSet, error) {
+	if len(cfg.Buckets) == 0 {
+		return nil, nil
+	}
+	b := &bucketSet{root: cfg.Path}
+	for name := range cfg.Buckets {
+		b.names = append(b.names, name)
+	}
+	sort.Strings(b.names)
+	for _, name := range b.names {
+		p, err := compilePattern(cfg.Buckets[name], true)
+		if err != nil {
+			return nil, fmt.Errorf("bucket %s: %w", name, err)
+		}
+		b.patterns = append(b.patterns, p)
+	}
+	return b, nil
+}
+
+// add counts a file in every bucket whose pattern it matches
+func (b *bucketSet) add(counts []models.PathBucket, path string, size int64) {
+	rel, err := filepath.Rel(b.root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+	name := filepath.Base(path)
+	for i := range b.patterns {
+		if b.patterns[i].matches(name, rel) {
+			counts[i].FileCount++
+			counts[i].SizeBytes += size
+		}
+	}
+}
+
+// empty returns zeroed counts for every bucket, in name order
+func (b *bucketSet) empty() []models.PathBucket {
+	if b == nil {
+		return nil
+	}
+	counts := make([]models.PathBucket, len(b.names))
+	for i, name := range b.names {
+		counts[i] = models.PathBucket{Name: name, Pattern: b.patterns[i].raw}
+	}
+	return counts
+}
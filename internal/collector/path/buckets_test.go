@@ -0,0 +1,80 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestPathScanner_Buckets_CountsFilesAndBytes(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "in"), 0755)
+	files := map[string]int{
+		"in/a.csv":  100,
+		"in/b.csv":  50,
+		"in/a.ctl":  0,
+		"in/b.err":  10,
+		"c.err":     5,
+		"notes.txt": 7,
+	}
+	for name, size := range files {
+		if err := os.WriteFile(filepath.Join(root, name), make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := PathConfig{
+		Path:    root,
+		Timeout: 30 * time.Second,
+		Buckets: map[string]string{
+			"errors":  "*.err",
+			"data":    "*.csv",
+			"in_all":  "in/*",
+			"control": `re:\.(ctl|done)$`,
+		},
+	}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	want := []models.PathBucket{
+		{Name: "control", Pattern: `re:\.(ctl|done)$`, FileCount: 1, SizeBytes: 0},
+		{Name: "data", Pattern: "*.csv", FileCount: 2, SizeBytes: 150},
+		{Name: "errors", Pattern: "*.err", FileCount: 2, SizeBytes: 15},
+		{Name: "in_all", Pattern: "in/*", FileCount: 4, SizeBytes: 160},
+	}
+	if fmt.Sprint(stats.Buckets) != fmt.Sprint(want) {
+		t.Errorf("Buckets = %v, want %v", stats.Buckets, want)
+	}
+	if stats.FileCount != 6 {
+		t.Errorf("FileCount = %d, want 6", stats.FileCount)
+	}
+}
+
+func TestPathScanner_Buckets_NoneConfigured(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cfg := PathConfig{Path: tmpDir, Timeout: 30 * time.Second}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if stats.Buckets != nil {
+		t.Errorf("Buckets = %v, want nil", stats.Buckets)
+	}
+}
+
+func TestNewBucketSet_InvalidPattern(t *testing.T) {
+	if _, err := newBucketSet(PathConfig{Buckets: map[string]string{"bad": "re:("}}); err == nil {
+		t.Error("Expected error for invalid bucket pattern")
+	}
+}
@@ -0,0 +1,199 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultExpandInterval is how often a path template is re-expanded when it
+// does not configure its own interval
+const defaultExpandInterval = 5 * time.Minute
+
+// expandTimeout bounds one expansion of a template, so that a hung mount
+// cannot stall the template's loop
+const expandTimeout = 30 * time.Second
+
+// isPathTemplate reports whether a configured path is a glob template that
+// expands into concrete paths rather than a path itself
+func isPathTemplate(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// templateRoot returns the directory above the first glob element of a
+// template, which must be readable for an expansion to be trusted
+func templateRoot(pattern string) string {
+	dir := pattern
+	for isPathTemplate(dir) {
+		dir = filepath.Dir(dir)
+	}
+	return dir
+}
+
+// expandedPath is a concrete path matched by a template, scanned by its own loop
+type expandedPath struct {
+	cfg      PathConfig
+	template string
+	cancel   context.CancelFunc
+	done     chan struct{} // Closed when the path's scan loop has returned
+}
+
+// expandLoop keeps the paths matched by a template monitored until ctx is
+// cancelled. Paths stored by an earlier run that no longer match are
+// retired on the first expansion.
+func (s *PathScanner) expandLoop(ctx context.Context, tmpl PathConfig) {
+	interval := tmpl.ExpandInterval
+	if interval <= 0 {
+		interval = defaultExpandInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.expandTemplate(ctx, tmpl, s.storedMatches(ctx, tmpl.Path))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expandTemplate(ctx, tmpl, nil)
+		}
+	}
+}
+
+// expandTemplate starts scanning new matches of a template and retires
+// matches that disappeared, along with any stale stored paths. A failed
+// expansion changes nothing, so an unreachable mount does not retire paths.
+func (s *PathScanner) expandTemplate(ctx context.Context, tmpl PathConfig, stale []string) {
+	matches, err := s.globDirs(ctx, tmpl.Path)
+	if err != nil {
+		return
+	}
+	current := make(map[string]bool, len(matches))
+	for _, path := range matches {
+		current[path] = true
+	}
+
+	s.mu.Lock()
+	var gone []string
+	for path, e := range s.expanded {
+		if e.template == tmpl.Path && !current[path] {
+			gone = append(gone, path)
+		}
+	}
+	for _, path := range matches {
+		if _, ok := s.expanded[path]; ok || s.isStatic(path) {
+			continue
+		}
+		cfg := tmpl
+		cfg.Path = path
+		s.startExpanded(ctx, cfg, tmpl.Path)
+	}
+	s.mu.Unlock()
+
+	for _, path := range stale {
+		if !current[path] {
+			gone = append(gone, path)
+		}
+	}
+	sort.Strings(gone)
+	for _, path := range gone {
+		s.retire(ctx, path)
+	}
+}
+
+// startExpanded starts the scan loop of a newly matched path. s.mu must be held.
+func (s *PathScanner) startExpanded(ctx context.Context, cfg PathConfig, template string) {
+	loopCtx, cancel := context.WithCancel(ctx)
+	e := &expandedPath{cfg: cfg, template: template, cancel: cancel, done: make(chan struct{})}
+	s.expanded[cfg.Path] = e
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(e.done)
+		s.scanLoop(loopCtx, cfg)
+	}()
+}
+
+// retire stops scanning a path that no longer matches its template and
+// removes everything stored for it
+func (s *PathScanner) retire(ctx context.Context, path string) {
+	s.mu.Lock()
+	e, ok := s.expanded[path]
+	delete(s.expanded, path)
+	s.mu.Unlock()
+
+	// Wait for the loop so that an in-flight scan cannot store the path again
+	if ok {
+		e.cancel()
+		<-e.done
+	}
+
+	s.mu.Lock()
+	delete(s.manifests, path)
+	delete(s.dirCaches, path)
+	delete(s.lastFull, path)
+	delete(s.quarantined, path)
+	s.mu.Unlock()
+
+	_ = s.repo.DeletePath(ctx, path)
+}
+
+// globDirs returns the directories matching a template, sorted
+func (s *PathScanner) globDirs(ctx context.Context, pattern string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, expandTimeout)
+	defer cancel()
+
+	stat := statPath
+	root := templateRoot(pattern)
+	if _, err := fsCall(ctx, func() (fs.FileInfo, error) { return stat(root) }); err != nil {
+		return nil, fmt.Errorf("template root %s: %w", root, err)
+	}
+
+	matches, err := fsCall(ctx, func() ([]string, error) { return filepath.Glob(pattern) })
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, path := range matches {
+		info, err := fsCall(ctx, func() (fs.FileInfo, error) { return stat(path) })
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err == nil && info.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// storedMatches returns the stored paths that a template matches, so that
+// paths which disappeared while the scanner was down can be retired
+func (s *PathScanner) storedMatches(ctx context.Context, pattern string) []string {
+	stats, err := s.repo.GetLatestPathStats(ctx)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, ps := range stats {
+		if ok, _ := filepath.Match(pattern, ps.Path); ok && !s.isStatic(ps.Path) {
+			paths = append(paths, ps.Path)
+		}
+	}
+	return paths
+}
+
+// isStatic reports whether path is configured explicitly rather than by a template
+func (s *PathScanner) isStatic(path string) bool {
+	for i := range s.paths {
+		if s.paths[i].Path == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package path
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestIsPathTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		template bool
+		root     string
+	}{
+		{"/data/input", false, "/data/input"},
+		{"/data/partners/*/inbox", true, "/data/partners"},
+		{"/data/partner?/inbox", true, "/data"},
+		{"/data/[ab]/in/*", true, "/data"},
+	}
+
+	for _, tt := range tests {
+		if got := isPathTemplate(tt.path); got != tt.template {
+			t.Errorf("isPathTemplate(%q) = %v, want %v", tt.path, got, tt.template)
+		}
+		if got := templateRoot(tt.path); got != tt.root {
+			t.Errorf("templateRoot(%q) = %q, want %q", tt.path, got, tt.root)
+		}
+	}
+}
+
+func mkdirs(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func deletedPaths(repo *MockPathsRepository) []string {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return slices.Clone(repo.deletedPaths)
+}
+
+func TestPathScanner_Template_ExpandsAndRetires(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, root, "partners/a/inbox", "partners/b/inbox", "partners/c", "partners/d", "static")
+	os.WriteFile(filepath.Join(root, "partners/d/inbox"), nil, 0644) // A file, not a directory
+
+	tmpl := PathConfig{
+		Path:         filepath.Join(root, "partners/*/inbox"),
+		ScanInterval: time.Hour,
+		Timeout:      30 * time.Second,
+		MaxFileCount: 10,
+	}
+	static := PathConfig{Path: filepath.Join(root, "static"), ScanInterval: time.Hour, Timeout: 30 * time.Second}
+	repo := &MockPathsRepository{}
+	scanner := NewPathScanner(repo, []PathConfig{tmpl, static})
+	if len(scanner.templates) != 1 || len(scanner.paths) != 1 {
+		t.Fatalf("Expected 1 template and 1 static path, got %d and %d", len(scanner.templates), len(scanner.paths))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		scanner.Stop()
+	}()
+
+	scanner.expandTemplate(ctx, tmpl, nil)
+
+	a := filepath.Join(root, "partners/a/inbox")
+	b := filepath.Join(root, "partners/b/inbox")
+	cfg := scanner.configFor(a)
+	if cfg == nil || scanner.configFor(b) == nil {
+		t.Fatal("Expected both inbox directories to be monitored")
+	}
+	if cfg.MaxFileCount != 10 || cfg.ScanInterval != time.Hour {
+		t.Errorf("Expected expanded path to inherit the template settings, got %+v", cfg)
+	}
+	if scanner.configFor(filepath.Join(root, "partners/d/inbox")) != nil {
+		t.Error("Expected files matching the template to be ignored")
+	}
+
+	// A partner directory that disappears is retired
+	if err := os.RemoveAll(filepath.Join(root, "partners/b")); err != nil {
+		t.Fatal(err)
+	}
+	scanner.expandTemplate(ctx, tmpl, nil)
+	if scanner.configFor(b) != nil {
+		t.Error("Expected removed inbox to stop being monitored")
+	}
+	if got := deletedPaths(repo); !slices.Equal(got, []string{b}) {
+		t.Errorf("deleted paths = %v, want [%s]", got, b)
+	}
+	if scanner.configFor(a) == nil {
+		t.Error("Expected remaining inbox to stay monitored")
+	}
+
+	// An unreadable template root retires nothing
+	if err := os.Rename(filepath.Join(root, "partners"), filepath.Join(root, "moved")); err != nil {
+		t.Fatal(err)
+	}
+	scanner.expandTemplate(ctx, tmpl, nil)
+	if scanner.configFor(a) == nil || len(deletedPaths(repo)) != 1 {
+		t.Error("Expected paths to be kept while the template root is missing")
+	}
+}
+
+func TestPathScanner_Template_RetiresStoredPathsOnStart(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, root, "partners/a/inbox")
+
+	old := filepath.Join(root, "partners/old/inbox")
+	repo := &MockPathsRepository{savedStats: []*models.PathStats{
+		{Path: old},
+		{Path: filepath.Join(root, "partners/a/inbox")},
+		{Path: "/elsewhere"},
+	}}
+	tmpl := PathConfig{Path: filepath.Join(root, "partners/*/inbox"), ScanInterval: time.Hour, Timeout: 30 * time.Second}
+	scanner := NewPathScanner(repo, []PathConfig{tmpl})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		scanner.Stop()
+	}()
+
+	scanner.expandTemplate(ctx, tmpl, scanner.storedMatches(ctx, tmpl.Path))
+
+	if got := deletedPaths(repo); !slices.Equal(got, []string{old}) {
+		t.Errorf("deleted paths = %v, want [%s]", got, old)
+	}
+}
@@ -0,0 +1,185 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// defaultFileListLimit is used when a listing query does not set a limit
+const defaultFileListLimit = 100
+
+// ListFiles walks a monitored path under its depth, exclude and timeout rules
+// and returns the top files according to the query. A walk that hits the
+// timeout returns the files seen so far with Truncated set.
+func (s *PathScanner) ListFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error) {
+	cfg := s.configFor(path)
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrPathNotConfigured, path)
+	}
+
+	if s.isQuarantined(cfg.Path) {
+		return nil, fmt.Errorf("%w: %s is quarantined until it responds", errStaleMount, cfg.Path)
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = defaultFileListLimit
+	}
+
+	walkCtx := ctx
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		walkCtx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	var mu sync.Mutex
+	top := &topFiles{limit: q.Limit, less: fileLess(q.Sort, q.Order)}
+	owners := make(map[uint32]string)
+
+	w, err := s.newWalker(*cfg, detectFsType(walkCtx, cfg.Path))
+	if err != nil {
+		return nil, err
+	}
+	err = w.walk(walkCtx, func(p string, d fs.DirEntry) error {
+		if d.IsDir() {
+			return nil
+		}
+		if q.Pattern != "" {
+			if matched, _ := filepath.Match(q.Pattern, d.Name()); !matched {
+				return nil
+			}
+		}
+
+		info, err := w.info(walkCtx, d)
+		if err != nil {
+			// File vanished between readdir and stat, or the walk was cancelled
+			return walkCtx.Err()
+		}
+		rel, err := filepath.Rel(cfg.Path, p)
+		if err != nil {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		top.add(models.PathFile{
+			Name:      rel,
+			SizeBytes: info.Size(),
+			ModTime:   info.ModTime(),
+			Owner:     fileOwner(info, owners),
+		})
+		return nil
+	})
+
+	result := &models.PathFileList{Path: cfg.Path}
+	if err != nil {
+		// Only our own deadline yields a partial result; caller cancellation is an error
+		if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to list files under %s: %w", cfg.Path, err)
+		}
+		result.Truncated = true
+	}
+
+	top.trim()
+	result.Files = top.files
+	result.Matched = top.matched
+	if result.Files == nil {
+		result.Files = []models.PathFile{}
+	}
+	return result, nil
+}
+
+// topFiles keeps the best limit files seen so far according to less
+type topFiles struct {
+	limit   int
+	less    func(a, b models.PathFile) bool
+	files   []models.PathFile
+	matched int64
+}
+
+// add records a file, periodically trimming to the best entries
+func (t *topFiles) add(f models.PathFile) {
+	t.matched++
+	t.files = append(t.files, f)
+	if len(t.files) >= 2*t.limit {
+		t.trim()
+	}
+}
+
+// trim sorts the collected files and keeps at most limit of them
+func (t *topFiles) trim() {
+	sort.Slice(t.files, func(i, j int) bool {
+		return t.less(t.files[i], t.files[j])
+	})
+	if len(t.files) > t.limit {
+		t.files = t.files[:t.limit]
+	}
+}
+
+// fileLess returns the ordering for a sort key. Size defaults to largest
+// first, mtime to oldest first and name to alphabetical.
+func fileLess(sortBy, order string) func(a, b models.PathFile) bool {
+	var asc func(a, b models.PathFile) bool
+	desc := false
+
+	switch sortBy {
+	case models.PathFileSortMtime:
+		asc = func(a, b models.PathFile) bool {
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.Before(b.ModTime)
+			}
+			return a.Name < b.Name
+		}
+	case models.PathFileSortName:
+		asc = func(a, b models.PathFile) bool { return a.Name < b.Name }
+	default:
+		asc = func(a, b models.PathFile) bool {
+			if a.SizeBytes != b.SizeBytes {
+				return a.SizeBytes < b.SizeBytes
+			}
+			return a.Name < b.Name
+		}
+		desc = true
+	}
+
+	switch order {
+	case "asc":
+		desc = false
+	case "desc":
+		desc = true
+	}
+
+	if desc {
+		return func(a, b models.PathFile) bool { return asc(b, a) }
+	}
+	return asc
+}
+
+// fileOwner resolves the owning user name of a file, caching lookups by uid
+func fileOwner(info fs.FileInfo, cache map[uint32]string) string {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	if name, ok := cache[st.Uid]; ok {
+		return name
+	}
+
+	uid := strconv.FormatUint(uint64(st.Uid), 10)
+	name := uid
+	if u, err := user.LookupId(uid); err == nil {
+		name = u.Username
+	}
+	cache[st.Uid] = name
+	return name
+}
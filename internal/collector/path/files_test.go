@@ -0,0 +1,126 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func writeSizedFile(t *testing.T, dir, name string, size int, age time.Duration) {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newListingScanner(t *testing.T, cfg PathConfig) *PathScanner {
+	t.Helper()
+	return NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+}
+
+func TestPathScanner_ListFiles_SortsAndLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSizedFile(t, tmpDir, "small.csv", 10, 3*time.Hour)
+	writeSizedFile(t, tmpDir, "big.csv", 300, time.Hour)
+	writeSizedFile(t, tmpDir, "sub/medium.csv", 200, 2*time.Hour)
+
+	scanner := newListingScanner(t, PathConfig{Path: tmpDir, Timeout: 30 * time.Second})
+
+	tests := []struct {
+		name  string
+		query models.PathFilesQuery
+		want  []string
+	}{
+		{"largest", models.PathFilesQuery{Sort: models.PathFileSortSize, Limit: 2}, []string{"big.csv", filepath.Join("sub", "medium.csv")}},
+		{"oldest", models.PathFilesQuery{Sort: models.PathFileSortMtime, Limit: 1}, []string{"small.csv"}},
+		{"newest", models.PathFilesQuery{Sort: models.PathFileSortMtime, Order: "desc", Limit: 1}, []string{"big.csv"}},
+		{"name", models.PathFilesQuery{Sort: models.PathFileSortName, Limit: 10}, []string{"big.csv", "small.csv", filepath.Join("sub", "medium.csv")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := scanner.ListFiles(context.Background(), tmpDir, tt.query)
+			if err != nil {
+				t.Fatalf("ListFiles() error = %v", err)
+			}
+			if list.Matched != 3 {
+				t.Errorf("Matched = %d, want 3", list.Matched)
+			}
+			if len(list.Files) != len(tt.want) {
+				t.Fatalf("Expected %d files, got %+v", len(tt.want), list.Files)
+			}
+			for i, name := range tt.want {
+				if list.Files[i].Name != name {
+					t.Errorf("Files[%d] = %s, want %s", i, list.Files[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestPathScanner_ListFiles_AppliesPatternDepthAndExclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSizedFile(t, tmpDir, "a.csv", 1, 0)
+	writeSizedFile(t, tmpDir, "b.log", 1, 0)
+	writeSizedFile(t, tmpDir, "skip.tmp.csv", 1, 0)
+	writeSizedFile(t, tmpDir, "d1/d2/deep.csv", 1, 0)
+
+	scanner := newListingScanner(t, PathConfig{
+		Path:     tmpDir,
+		MaxDepth: 2,
+		Exclude:  []string{"skip.*"},
+		Timeout:  30 * time.Second,
+	})
+
+	list, err := scanner.ListFiles(context.Background(), tmpDir, models.PathFilesQuery{Sort: models.PathFileSortName, Pattern: "*.csv"})
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+
+	if len(list.Files) != 1 || list.Files[0].Name != "a.csv" {
+		t.Errorf("Expected only a.csv, got %+v", list.Files)
+	}
+	if list.Files[0].Owner == "" {
+		t.Error("Expected owner to be resolved")
+	}
+	if list.Truncated {
+		t.Error("Expected complete listing")
+	}
+}
+
+func TestPathScanner_ListFiles_UnknownPath_ReturnsNotConfigured(t *testing.T) {
+	scanner := newListingScanner(t, PathConfig{Path: t.TempDir()})
+
+	_, err := scanner.ListFiles(context.Background(), "/not/monitored", models.PathFilesQuery{})
+	if !errors.Is(err, models.ErrPathNotConfigured) {
+		t.Errorf("Expected ErrPathNotConfigured, got %v", err)
+	}
+}
+
+func TestPathScanner_ListFiles_Timeout_ReturnsPartialResult(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	scanner := newListingScanner(t, PathConfig{Path: tmpDir, Timeout: time.Nanosecond})
+
+	list, err := scanner.ListFiles(context.Background(), tmpDir, models.PathFilesQuery{})
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if !list.Truncated {
+		t.Error("Expected truncated listing after timeout")
+	}
+}
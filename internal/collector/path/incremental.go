@@ -0,0 +1,268 @@
+package path
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// defaultFullScanInterval is how often an incremental path is verified with
+// a full walk when it does not configure its own interval
+const defaultFullScanInterval = 24 * time.Hour
+
+// racyMtimeWindow is how close to the walk a directory's mtime may be before
+// its cached counts are distrusted. Coarse mtime granularity can hide an
+// entry added right after the directory was read within the same tick.
+const racyMtimeWindow = 2 * time.Second
+
+// racyMtime marks a cache entry whose directory must be read on the next walk
+var racyMtime = time.Unix(0, 0)
+
+// dirCache carries directory mtimes and entry counts between incremental
+// walks of one path
+type dirCache struct {
+	root     string
+	rules    string // rulesKey of the walk; entries taken under other rules are not reused
+	started  time.Time
+	stored   map[string]models.DirCacheEntry // Entries persisted by earlier walks
+	reusable map[string]models.DirCacheEntry // Entries this walk may trust (empty on a full walk)
+	children map[string][]string             // Reusable directories by parent
+
+	mu   sync.Mutex
+	next map[string]models.DirCacheEntry // Entries seen by this walk
+
+	reused atomic.Int64 // Directories counted from the cache
+	files  atomic.Int64 // Files in reused directories
+	dirs   atomic.Int64 // Subdirectories in reused directories
+}
+
+// newDirCache prepares a cache for one walk. A full walk passes full=true so
+// that every directory is read and compared against the stored entries.
+func newDirCache(root, rules string, stored map[string]models.DirCacheEntry, full bool, started time.Time) *dirCache {
+	c := &dirCache{
+		root:     root,
+		rules:    rules,
+		started:  started,
+		stored:   stored,
+		reusable: make(map[string]models.DirCacheEntry),
+		children: make(map[string][]string),
+		next:     make(map[string]models.DirCacheEntry),
+	}
+	if full {
+		return c
+	}
+	for dir, e := range stored {
+		if e.Rules != rules {
+			continue
+		}
+		c.reusable[dir] = e
+		if dir != "." {
+			parent := filepath.Dir(dir)
+			c.children[parent] = append(c.children[parent], dir)
+		}
+	}
+	return c
+}
+
+// rel returns a directory's cache key relative to the walk root
+func (c *dirCache) rel(path string) string {
+	rel, err := filepath.Rel(c.root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// lookup returns the cached entry of dir if its mtime is unchanged
+func (c *dirCache) lookup(dir string, modTime time.Time) (models.DirCacheEntry, bool) {
+	e, ok := c.reusable[dir]
+	if !ok || !e.ModTime.Equal(modTime) || e.ModTime.Equal(racyMtime) {
+		return models.DirCacheEntry{}, false
+	}
+	return e, true
+}
+
+// store records the state of a directory that was read by this walk
+func (c *dirCache) store(e models.DirCacheEntry) {
+	if c.started.Sub(e.ModTime) < racyMtimeWindow {
+		e.ModTime = racyMtime
+	}
+	c.mu.Lock()
+	c.next[e.Dir] = e
+	c.mu.Unlock()
+}
+
+// reuse records a directory counted from the cache
+func (c *dirCache) reuse(e models.DirCacheEntry) {
+	c.mu.Lock()
+	c.next[e.Dir] = e
+	c.mu.Unlock()
+	c.reused.Add(1)
+	c.files.Add(e.FileCount)
+	c.dirs.Add(e.DirCount)
+}
+
+// changes returns the entries that differ from the stored cache and the
+// directories that are gone since it was written
+func (c *dirCache) changes() ([]models.DirCacheEntry, []string) {
+	var changed []models.DirCacheEntry
+	var removed []string
+	for dir, e := range c.next {
+		if old, ok := c.stored[dir]; !ok || !sameEntry(old, e) {
+			changed = append(changed, e)
+		}
+	}
+	for dir := range c.stored {
+		if _, ok := c.next[dir]; !ok {
+			removed = append(removed, dir)
+		}
+	}
+	return changed, removed
+}
+
+// sameEntry reports whether two cache entries hold the same state
+func sameEntry(a, b models.DirCacheEntry) bool {
+	return a.Dir == b.Dir && a.ModTime.Equal(b.ModTime) && a.FileCount == b.FileCount &&
+		a.DirCount == b.DirCount && a.Rules == b.Rules && slices.Equal(a.Matches, b.Matches)
+}
+
+// readDirCached stats a directory and reuses its cached counts when its
+// mtime is unchanged, reading it only when it changed. Subdirectories of a
+// reused directory are still visited, since changes deeper in the tree do
+// not update the parent's mtime.
+func (w *walker) readDirCached(ctx context.Context, dir dirItem, fn walkFunc) ([]dirItem, error) {
+	if err := w.wait(ctx); err != nil {
+		return nil, err
+	}
+	stat := statPath
+	info, err := fsCall(ctx, func() (fs.FileInfo, error) {
+		return stat(dir.path)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// Skip directories we can't stat
+		return nil, nil
+	}
+
+	rel := w.cache.rel(dir.path)
+	if e, ok := w.cache.lookup(rel, info.ModTime()); ok {
+		w.cache.reuse(e)
+		w.addMatches(e.Matches)
+		w.progress.addCounts(e.FileCount, e.DirCount)
+		return w.cachedSubdirs(dir, rel), nil
+	}
+
+	l, err := w.listDir(ctx, dir, fn)
+	if err != nil || !l.read {
+		return l.subdirs, err
+	}
+	w.cache.store(models.DirCacheEntry{
+		Dir:       rel,
+		ModTime:   info.ModTime(),
+		FileCount: l.files,
+		DirCount:  l.dirs,
+		Rules:     w.cache.rules,
+		Matches:   l.matches,
+	})
+	return l.subdirs, nil
+}
+
+// cachedSubdirs returns the cached subdirectories of dir that the current
+// depth and matching rules still descend into
+func (w *walker) cachedSubdirs(dir dirItem, rel string) []dirItem {
+	depth := dir.depth + 1
+	if w.cfg.MaxDepth > 0 && depth >= w.cfg.MaxDepth {
+		return nil
+	}
+	var subdirs []dirItem
+	for _, child := range w.cache.children[rel] {
+		path := filepath.Join(w.cfg.Path, child)
+		if visible, _ := w.match.match(path, true); !visible {
+			continue
+		}
+		subdirs = append(subdirs, dirItem{path: path, depth: depth})
+	}
+	return subdirs
+}
+
+// startIncremental attaches the directory cache to w and reports whether
+// this walk is a full verification: no cache exists yet or the last full
+// walk is older than the path's full scan interval
+func (s *PathScanner) startIncremental(ctx context.Context, cfg PathConfig, w *walker, started time.Time) bool {
+	stored, lastFull := s.loadDirCache(ctx, cfg.Path)
+
+	interval := cfg.FullScanInterval
+	if interval <= 0 {
+		interval = defaultFullScanInterval
+	}
+	full := len(stored) == 0 || started.Sub(lastFull) >= interval
+
+	w.cache = newDirCache(cfg.Path, rulesKey(cfg), stored, full, started)
+	return full
+}
+
+// finishIncremental records the scan mode on stats and, after a complete
+// walk, persists the directories that changed
+func (s *PathScanner) finishIncremental(ctx context.Context, cfg PathConfig, w *walker, stats *models.PathStats, full, complete bool) {
+	stats.ScanMode = models.ScanModeIncremental
+	if full {
+		stats.ScanMode = models.ScanModeFull
+	}
+	stats.DirsReused = w.cache.reused.Load()
+
+	if complete {
+		changed, removed := w.cache.changes()
+		if err := s.repo.UpdateDirCache(ctx, cfg.Path, changed, removed); err == nil {
+			s.mu.Lock()
+			s.dirCaches[cfg.Path] = w.cache.next
+			if full {
+				s.lastFull[cfg.Path] = stats.CollectedAt
+			}
+			s.mu.Unlock()
+		}
+	}
+
+	s.mu.Lock()
+	if t, ok := s.lastFull[cfg.Path]; ok && !t.IsZero() {
+		stats.LastFullScan = &t
+	}
+	s.mu.Unlock()
+}
+
+// loadDirCache returns the directory cache of path and the time of its last
+// full walk. After a restart both are restored from the repository.
+func (s *PathScanner) loadDirCache(ctx context.Context, path string) (map[string]models.DirCacheEntry, time.Time) {
+	s.mu.Lock()
+	stored, ok := s.dirCaches[path]
+	lastFull := s.lastFull[path]
+	s.mu.Unlock()
+	if ok {
+		return stored, lastFull
+	}
+
+	entries, err := s.repo.LoadDirCache(ctx, path)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	stored = make(map[string]models.DirCacheEntry, len(entries))
+	for _, e := range entries {
+		stored[e.Dir] = e
+	}
+	if ps, err := s.repo.GetPathStats(ctx, path); err == nil && ps != nil && ps.LastFullScan != nil {
+		lastFull = *ps.LastFullScan
+	}
+
+	s.mu.Lock()
+	s.dirCaches[path] = stored
+	s.lastFull[path] = lastFull
+	s.mu.Unlock()
+	return stored, lastFull
+}
@@ -0,0 +1,163 @@
+package path
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// ageDirs sets the mtime of every directory under root to mtime so that the
+// incremental cache trusts them
+func ageDirs(t *testing.T, root string, mtime time.Time) {
+	t.Helper()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, mtime, mtime)
+	})
+	if err != nil {
+		t.Fatalf("Failed to age dirs: %v", err)
+	}
+}
+
+func scanIncremental(t *testing.T, s *PathScanner, cfg PathConfig) *models.PathStats {
+	t.Helper()
+	stats, err := s.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if stats.Status != "OK" {
+		t.Fatalf("Status = %s (%s), want OK", stats.Status, stats.ErrorMessage)
+	}
+	return stats
+}
+
+func TestPathScanner_Incremental_ReusesUnchangedDirs(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	old := time.Now().Add(-time.Hour)
+	ageDirs(t, tmpDir, old)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second, Incremental: true}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	first := scanIncremental(t, scanner, cfg)
+	if first.ScanMode != models.ScanModeFull || first.DirsReused != 0 {
+		t.Errorf("First scan: mode %s, reused %d, want full and 0", first.ScanMode, first.DirsReused)
+	}
+	if first.LastFullScan == nil {
+		t.Error("Expected LastFullScan after a full walk")
+	}
+	if len(repo.dirCache[tmpDir]) != 4 {
+		t.Errorf("Expected 4 cached dirs, got %d", len(repo.dirCache[tmpDir]))
+	}
+
+	second := scanIncremental(t, scanner, cfg)
+	if second.ScanMode != models.ScanModeIncremental || second.DirsReused != 4 {
+		t.Errorf("Second scan: mode %s, reused %d, want incremental and 4", second.ScanMode, second.DirsReused)
+	}
+	if second.FileCount != 5 || second.DirCount != 3 {
+		t.Errorf("Second scan counts = %d files, %d dirs, want 5 and 3", second.FileCount, second.DirCount)
+	}
+	if second.FsOps >= first.FsOps {
+		t.Errorf("Incremental scan issued %d ops, full scan %d", second.FsOps, first.FsOps)
+	}
+
+	// Adding a file changes dir2's mtime, so only dir2 is read again
+	if err := os.WriteFile(filepath.Join(tmpDir, "dir2", "file6.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(filepath.Join(tmpDir, "dir2"), old.Add(time.Minute), old.Add(time.Minute))
+
+	third := scanIncremental(t, scanner, cfg)
+	if third.FileCount != 6 || third.DirsReused != 3 {
+		t.Errorf("Third scan: %d files, reused %d, want 6 and 3", third.FileCount, third.DirsReused)
+	}
+
+	// Removing a subdirectory drops it from the cache
+	if err := os.RemoveAll(filepath.Join(tmpDir, "dir1", "subdir1")); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(filepath.Join(tmpDir, "dir1"), old.Add(time.Minute), old.Add(time.Minute))
+
+	fourth := scanIncremental(t, scanner, cfg)
+	if fourth.FileCount != 5 || fourth.DirCount != 2 {
+		t.Errorf("Fourth scan counts = %d files, %d dirs, want 5 and 2", fourth.FileCount, fourth.DirCount)
+	}
+	if _, ok := repo.dirCache[tmpDir][filepath.Join("dir1", "subdir1")]; ok {
+		t.Error("Removed directory should be dropped from the cache")
+	}
+}
+
+func TestPathScanner_Incremental_FullWalkCatchesHiddenChanges(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	old := time.Now().Add(-time.Hour)
+	ageDirs(t, tmpDir, old)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second, Incremental: true, FullScanInterval: time.Hour}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	scanIncremental(t, scanner, cfg)
+
+	// A change that leaves the directory mtime untouched is invisible to incremental scans
+	if err := os.WriteFile(filepath.Join(tmpDir, "dir2", "hidden.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ageDirs(t, tmpDir, old)
+
+	if stats := scanIncremental(t, scanner, cfg); stats.FileCount != 5 {
+		t.Errorf("Incremental scan counted %d files, want the cached 5", stats.FileCount)
+	}
+
+	// Once the full scan interval passes, a full walk picks it up
+	scanner.mu.Lock()
+	scanner.lastFull[tmpDir] = time.Now().Add(-2 * time.Hour)
+	scanner.mu.Unlock()
+
+	stats := scanIncremental(t, scanner, cfg)
+	if stats.ScanMode != models.ScanModeFull || stats.FileCount != 6 {
+		t.Errorf("Verification scan: mode %s, %d files, want full and 6", stats.ScanMode, stats.FileCount)
+	}
+}
+
+func TestPathScanner_Incremental_RestoresCacheAfterRestart(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	ageDirs(t, tmpDir, time.Now().Add(-time.Hour))
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second, Incremental: true}
+	first := scanIncremental(t, NewPathScanner(repo, []PathConfig{cfg}), cfg)
+	repo.savedStats = append(repo.savedStats, first)
+
+	stats := scanIncremental(t, NewPathScanner(repo, []PathConfig{cfg}), cfg)
+	if stats.ScanMode != models.ScanModeIncremental || stats.DirsReused != 4 {
+		t.Errorf("After restart: mode %s, reused %d, want incremental and 4", stats.ScanMode, stats.DirsReused)
+	}
+	if stats.LastFullScan == nil || !stats.LastFullScan.Equal(*first.LastFullScan) {
+		t.Errorf("LastFullScan = %v, want %v", stats.LastFullScan, first.LastFullScan)
+	}
+}
+
+func TestPathScanner_Incremental_RereadsRecentlyModifiedDirs(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second, Incremental: true}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	scanIncremental(t, scanner, cfg)
+
+	// Directories modified within the racy window are never trusted
+	stats := scanIncremental(t, scanner, cfg)
+	if stats.DirsReused != 0 || stats.FileCount != 5 {
+		t.Errorf("Reused %d dirs with %d files, want 0 and 5", stats.DirsReused, stats.FileCount)
+	}
+}
@@ -0,0 +1,295 @@
+package path
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// maxScanJobs caps both the job queue and the number of jobs remembered;
+// the oldest finished jobs are forgotten first
+const maxScanJobs = 100
+
+// scanRetryInterval is how often a job retries a path that the scheduler
+// is scanning at the same time
+const scanRetryInterval = 200 * time.Millisecond
+
+// scanJob is the scanner's record of a manual scan job. All fields except
+// ctx and cancel are guarded by PathScanner.mu.
+type scanJob struct {
+	job       models.ScanJob
+	progress  *scanProgress // Live counts of the path being walked
+	filesDone int64         // Files counted in finished paths
+	dirsDone  int64         // Directories counted in finished paths
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// SubmitScan queues a background scan of paths and returns the new job
+func (s *PathScanner) SubmitScan(paths []string) (*models.ScanJob, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no paths provided")
+	}
+	if s.jobCtx.Err() != nil {
+		return nil, errors.New("scanner stopped")
+	}
+	for _, path := range paths {
+		if s.configFor(path) == nil {
+			return nil, fmt.Errorf("%w: %s", models.ErrPathNotConfigured, path)
+		}
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan job ID: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(s.jobCtx)
+	j := &scanJob{
+		job: models.ScanJob{
+			ID:        id,
+			Paths:     append([]string(nil), paths...),
+			Status:    models.ScanJobQueued,
+			CreatedAt: time.Now(),
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	s.jobOnce.Do(func() {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runJobs()
+		}()
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case s.jobQueue <- j:
+	default:
+		cancel()
+		return nil, fmt.Errorf("too many queued scan jobs (max %d)", maxScanJobs)
+	}
+
+	s.jobs[id] = j
+	s.jobOrder = append(s.jobOrder, id)
+	s.pruneJobsLocked()
+
+	return j.snapshotLocked(), nil
+}
+
+// GetScanJob returns the current state of a scan job
+func (s *PathScanner) GetScanJob(id string) (*models.ScanJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, models.ErrScanJobNotFound
+	}
+	return j.snapshotLocked(), nil
+}
+
+// CancelScanJob cancels a queued or running scan job. Cancelling a job
+// that has already finished leaves it unchanged.
+func (s *PathScanner) CancelScanJob(id string) (*models.ScanJob, error) {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return nil, models.ErrScanJobNotFound
+	}
+	if j.job.Status == models.ScanJobQueued {
+		j.finishLocked(models.ScanJobCancelled, "cancelled before start")
+	}
+	s.mu.Unlock()
+
+	// A running job notices the cancellation and records its own final state
+	j.cancel()
+
+	return s.GetScanJob(id)
+}
+
+// AdoptJobs takes over the jobs of a scanner that was stopped, so that
+// their IDs stay queryable after a config reload replaces the scanner.
+// Stop has already cancelled the jobs that were queued or running.
+func (s *PathScanner) AdoptJobs(old *PathScanner) {
+	old.mu.Lock()
+	order := append([]string(nil), old.jobOrder...)
+	jobs := make(map[string]*scanJob, len(old.jobs))
+	for id, j := range old.jobs {
+		j.finishLocked(models.ScanJobCancelled, "scanner stopped")
+		jobs[id] = j
+	}
+	old.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, j := range jobs {
+		s.jobs[id] = j
+	}
+	s.jobOrder = append(order, s.jobOrder...)
+	s.pruneJobsLocked()
+}
+
+// runJobs executes queued jobs one at a time until Stop is called
+func (s *PathScanner) runJobs() {
+	for {
+		select {
+		case <-s.jobCtx.Done():
+			s.cancelQueuedJobs()
+			return
+		case j := <-s.jobQueue:
+			s.runJob(j)
+		}
+	}
+}
+
+// runJob scans each of a job's paths in order, saving stats as it goes
+func (s *PathScanner) runJob(j *scanJob) {
+	defer j.cancel()
+
+	s.mu.Lock()
+	if j.job.Status != models.ScanJobQueued {
+		// Cancelled while waiting in the queue
+		s.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	j.job.Status = models.ScanJobRunning
+	j.job.StartedAt = &now
+	s.mu.Unlock()
+
+	for _, path := range j.job.Paths {
+		cfg := s.configFor(path)
+		if cfg == nil {
+			s.finishJob(j, models.ScanJobFailed, fmt.Sprintf("no configuration found for path: %s", path))
+			return
+		}
+
+		progress := &scanProgress{}
+		s.mu.Lock()
+		j.job.CurrentPath = path
+		j.progress = progress
+		s.mu.Unlock()
+
+		stats, err := s.scanWhenIdle(j.ctx, *cfg, progress)
+		if j.ctx.Err() != nil {
+			s.finishJob(j, models.ScanJobCancelled, "scan cancelled")
+			return
+		}
+		if err == nil {
+			err = s.saveStats(j.ctx, stats)
+		}
+		if err != nil {
+			s.finishJob(j, models.ScanJobFailed, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		j.progress = nil
+		j.filesDone += stats.FileCount
+		j.dirsDone += stats.DirCount
+		j.job.Results = append(j.job.Results, stats)
+		s.mu.Unlock()
+	}
+
+	s.finishJob(j, models.ScanJobCompleted, "")
+}
+
+// scanWhenIdle scans a path, waiting for a scheduled scan of the same path
+// to finish instead of failing
+func (s *PathScanner) scanWhenIdle(ctx context.Context, cfg PathConfig, progress *scanProgress) (*models.PathStats, error) {
+	for {
+		stats, err := s.scanPath(ctx, cfg, progress)
+		if !errors.Is(err, errAlreadyScanning) {
+			return stats, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(scanRetryInterval):
+		}
+	}
+}
+
+// finishJob records a job's final state
+func (s *PathScanner) finishJob(j *scanJob, status, errMsg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.finishLocked(status, errMsg)
+}
+
+// cancelQueuedJobs marks every job still waiting in the queue as cancelled
+func (s *PathScanner) cancelQueuedJobs() {
+	for {
+		select {
+		case j := <-s.jobQueue:
+			s.finishJob(j, models.ScanJobCancelled, "scanner stopped")
+		default:
+			return
+		}
+	}
+}
+
+// pruneJobsLocked forgets the oldest finished jobs beyond maxScanJobs
+func (s *PathScanner) pruneJobsLocked() {
+	for i := 0; len(s.jobOrder) > maxScanJobs && i < len(s.jobOrder); {
+		id := s.jobOrder[i]
+		if !s.jobs[id].job.Done() {
+			i++
+			continue
+		}
+		delete(s.jobs, id)
+		s.jobOrder = append(s.jobOrder[:i], s.jobOrder[i+1:]...)
+	}
+}
+
+// finishLocked moves the job to a final state, folding in live counts
+func (j *scanJob) finishLocked(status, errMsg string) {
+	if j.job.Done() {
+		return
+	}
+	if j.progress != nil {
+		j.filesDone += j.progress.files.Load()
+		j.dirsDone += j.progress.dirs.Load()
+		j.progress = nil
+	}
+	now := time.Now()
+	j.job.Status = status
+	j.job.Error = errMsg
+	j.job.CurrentPath = ""
+	j.job.FinishedAt = &now
+}
+
+// snapshotLocked returns a copy of the job with live counts filled in
+func (j *scanJob) snapshotLocked() *models.ScanJob {
+	snap := j.job
+	snap.Paths = append([]string(nil), j.job.Paths...)
+	snap.Results = append([]*models.PathStats(nil), j.job.Results...)
+	snap.FilesScanned = j.filesDone
+	snap.DirsScanned = j.dirsDone
+	if j.progress != nil {
+		snap.FilesScanned += j.progress.files.Load()
+		snap.DirsScanned += j.progress.dirs.Load()
+	}
+	return &snap
+}
+
+// newJobID returns a random 16 character hex ID
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,186 @@
+package path
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// waitForJob polls a job until cond holds or the deadline passes
+func waitForJob(t *testing.T, s *PathScanner, id string, cond func(*models.ScanJob) bool) *models.ScanJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := s.GetScanJob(id)
+		if err != nil {
+			t.Fatalf("GetScanJob() error = %v", err)
+		}
+		if cond(job) {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s stuck in state %+v", id, job)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func isDone(job *models.ScanJob) bool { return job.Done() }
+
+func TestPathScanner_SubmitScan_CompletesInBackground(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	defer scanner.Stop()
+
+	job, err := scanner.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	if job.ID == "" || job.Status != models.ScanJobQueued {
+		t.Errorf("Expected a queued job with an ID, got %+v", job)
+	}
+
+	job = waitForJob(t, scanner, job.ID, isDone)
+
+	if job.Status != models.ScanJobCompleted {
+		t.Fatalf("Status = %s, want completed (%s)", job.Status, job.Error)
+	}
+	if job.FilesScanned != 5 || job.DirsScanned != 3 {
+		t.Errorf("Counts = %d files, %d dirs, want 5 and 3", job.FilesScanned, job.DirsScanned)
+	}
+	if len(job.Results) != 1 || job.Results[0].Path != tmpDir {
+		t.Errorf("Expected one result for %s, got %+v", tmpDir, job.Results)
+	}
+	if job.StartedAt == nil || job.FinishedAt == nil {
+		t.Error("Expected start and finish times")
+	}
+	if len(repo.savedStats) != 1 {
+		t.Errorf("Expected stats to be saved once, got %d", len(repo.savedStats))
+	}
+}
+
+func TestPathScanner_SubmitScan_UnknownPath(t *testing.T) {
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{{Path: t.TempDir()}})
+	defer scanner.Stop()
+
+	if _, err := scanner.SubmitScan([]string{"/not/monitored"}); !errors.Is(err, models.ErrPathNotConfigured) {
+		t.Errorf("SubmitScan() error = %v, want ErrPathNotConfigured", err)
+	}
+	if _, err := scanner.GetScanJob("nope"); !errors.Is(err, models.ErrScanJobNotFound) {
+		t.Errorf("GetScanJob() error = %v, want ErrScanJobNotFound", err)
+	}
+}
+
+func TestPathScanner_SubmitScan_WaitsForScheduledScan(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 30 * time.Second}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	defer scanner.Stop()
+
+	// Pretend the scheduler is in the middle of scanning the path
+	scanner.mu.Lock()
+	scanner.scanning[tmpDir] = true
+	scanner.mu.Unlock()
+
+	job, err := scanner.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	waitForJob(t, scanner, job.ID, func(j *models.ScanJob) bool { return j.Status == models.ScanJobRunning })
+
+	time.Sleep(2 * scanRetryInterval)
+	scanner.mu.Lock()
+	scanner.scanning[tmpDir] = false
+	scanner.mu.Unlock()
+
+	job = waitForJob(t, scanner, job.ID, isDone)
+	if job.Status != models.ScanJobCompleted {
+		t.Errorf("Status = %s, want completed (%s)", job.Status, job.Error)
+	}
+}
+
+func TestPathScanner_CancelScanJob_StopsHungScan(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	hangFS(t)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: time.Minute}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	defer scanner.Stop()
+
+	running, err := scanner.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	queued, err := scanner.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	waitForJob(t, scanner, running.ID, func(j *models.ScanJob) bool { return j.Status == models.ScanJobRunning })
+
+	// Cancelling a queued job takes effect immediately
+	job, err := scanner.CancelScanJob(queued.ID)
+	if err != nil {
+		t.Fatalf("CancelScanJob() error = %v", err)
+	}
+	if job.Status != models.ScanJobCancelled {
+		t.Errorf("Queued job status = %s, want cancelled", job.Status)
+	}
+
+	// Cancelling a running job interrupts the walk
+	if _, err := scanner.CancelScanJob(running.ID); err != nil {
+		t.Fatalf("CancelScanJob() error = %v", err)
+	}
+	job = waitForJob(t, scanner, running.ID, isDone)
+	if job.Status != models.ScanJobCancelled {
+		t.Errorf("Running job status = %s, want cancelled", job.Status)
+	}
+	if len(repo.savedStats) != 0 {
+		t.Errorf("Cancelled scans must not save stats, got %d", len(repo.savedStats))
+	}
+}
+
+func TestPathScanner_AdoptJobs_KeepsJobsAfterReload(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	hangFS(t)
+
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: time.Minute}
+	old := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+
+	running, err := old.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	queued, err := old.SubmitScan([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("SubmitScan() error = %v", err)
+	}
+	waitForJob(t, old, running.ID, func(j *models.ScanJob) bool { return j.Status == models.ScanJobRunning })
+
+	// A reload stops the scanner and starts a new one
+	old.Stop()
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	defer scanner.Stop()
+	scanner.AdoptJobs(old)
+
+	for _, id := range []string{running.ID, queued.ID} {
+		job, err := scanner.GetScanJob(id)
+		if err != nil {
+			t.Fatalf("GetScanJob(%s) error = %v", id, err)
+		}
+		if job.Status != models.ScanJobCancelled || job.FinishedAt == nil {
+			t.Errorf("Job %s = %+v, want cancelled", id, job)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package path
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// Symlink handling modes
+const (
+	SymlinksCount  = "count"  // Count links as files without following them (default)
+	SymlinksSkip   = "skip"   // Ignore links entirely
+	SymlinksFollow = "follow" // Follow links, descending into linked directories once
+)
+
+// regexPrefix marks an include or exclude pattern as a regular expression
+// matched against the path relative to the monitored root
+const regexPrefix = "re:"
+
+// pathPattern is one compiled include or exclude pattern
+type pathPattern struct {
+	raw     string
+	include bool
+	re      *regexp.Regexp
+	base    bool // Matched against the entry name instead of its relative path
+}
+
+// pathMatcher decides which entries a walk visits, following a path's
+// include, exclude and hidden-file rules
+type pathMatcher struct {
+	root       string
+	patterns   []pathPattern // Include patterns first, then exclude patterns
+	includes   int           // Number of include patterns
+	skipHidden bool
+}
+
+// newPathMatcher compiles the include and exclude patterns of cfg
+func newPathMatcher(cfg PathConfig) (*pathMatcher, error) {
+	m := &pathMatcher{root: cfg.Path, skipHidden: cfg.SkipHidden}
+	for _, raw := range cfg.Include {
+		p, err := compilePattern(raw, true)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, p)
+	}
+	m.includes = len(m.patterns)
+	for _, raw := range cfg.Exclude {
+		p, err := compilePattern(raw, false)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, p)
+	}
+	return m, nil
+}
+
+// compilePattern compiles a glob or re: pattern. Globs containing a slash
+// match the whole relative path; others match the entry name only.
+func compilePattern(raw string, include bool) (pathPattern, error) {
+	p := pathPattern{raw: raw, include: include}
+	var err error
+	if expr, ok := strings.CutPrefix(raw, regexPrefix); ok {
+		p.re, err = regexp.Compile(expr)
+	} else {
+		p.base = !strings.Contains(raw, "/")
+		p.re, err = GlobToRegexp(raw)
+	}
+	if err != nil {
+		return p, fmt.Errorf("invalid pattern %q: %w", raw, err)
+	}
+	return p, nil
+}
+
+// GlobToRegexp translates a glob into an anchored regular expression.
+// '*' and '?' stay within one path segment, '**' spans segments and a
+// leading '**/' also matches no directory at all. Character classes follow
+// filepath.Match, also accepting '!' for negation.
+func GlobToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			class, n, err := classToRegexp(glob[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			b.WriteString(class)
+			i += n
+		case '\\':
+			if i+1 == len(glob) {
+				return nil, fmt.Errorf("trailing escape")
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// classToRegexp translates the character class at the start of s, just
+// after its '[', and returns the bytes of s it used
+func classToRegexp(s string) (string, int, error) {
+	var b strings.Builder
+	b.WriteString("[")
+	i := 0
+	if i < len(s) && (s[i] == '^' || s[i] == '!') {
+		b.WriteString("^")
+		i++
+	}
+	for ranges := 0; ; ranges++ {
+		if i < len(s) && s[i] == ']' && ranges > 0 {
+			b.WriteString("]")
+			return b.String(), i + 1, nil
+		}
+		lo, n, err := classChar(s[i:])
+		if err != nil {
+			return "", 0, err
+		}
+		i += n
+		hi := lo
+		if i < len(s) && s[i] == '-' {
+			if hi, n, err = classChar(s[i+1:]); err != nil {
+				return "", 0, err
+			}
+			i += 1 + n
+			if lo > hi {
+				return "", 0, fmt.Errorf("invalid character class range %c-%c", lo, hi)
+			}
+		}
+		b.WriteString(quoteClassChar(lo))
+		if hi != lo {
+			b.WriteString("-" + quoteClassChar(hi))
+		}
+	}
+}
+
+// classChar reads a possibly escaped character of a class range
+func classChar(s string) (rune, int, error) {
+	n := 0
+	if len(s) > 0 && (s[0] == '-' || s[0] == ']') {
+		return 0, 0, fmt.Errorf("invalid character class")
+	}
+	if len(s) > 0 && s[0] == '\\' {
+		n++
+	}
+	if n == len(s) {
+		return 0, 0, fmt.Errorf("unterminated character class")
+	}
+	r, size := utf8.DecodeRuneInString(s[n:])
+	return r, n + size, nil
+}
+
+// quoteClassChar quotes a character for use inside a regexp class, where
+// '-' is special too
+func quoteClassChar(r rune) string {
+	if r == '-' {
+		return `\-`
+	}
+	return regexp.QuoteMeta(string(r))
+}
+
+// match reports whether an entry is visible to the walk and the index of
+// the pattern that decided it (-1 when no pattern matched). Excludes apply
+// to files and directories; includes only select files, so directories are
+// always descended into unless excluded.
+func (m *pathMatcher) match(path string, isDir bool) (bool, int) {
+	name := filepath.Base(path)
+	if m.skipHidden && strings.HasPrefix(name, ".") {
+		return false, -1
+	}
+	if len(m.patterns) == 0 {
+		return true, -1
+	}
+
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := m.includes; i < len(m.patterns); i++ {
+		if m.patterns[i].matches(name, rel) {
+			return false, i
+		}
+	}
+	if isDir || m.includes == 0 {
+		return true, -1
+	}
+	for i := 0; i < m.includes; i++ {
+		if m.patterns[i].matches(name, rel) {
+			return true, i
+		}
+	}
+	return false, -1
+}
+
+func (p *pathPattern) matches(name, rel string) bool {
+	if p.base {
+		return p.re.MatchString(name)
+	}
+	return p.re.MatchString(rel)
+}
+
+// results pairs each pattern with its match count
+func (m *pathMatcher) results(counts []int64) []models.PatternMatch {
+	if len(m.patterns) == 0 {
+		return nil
+	}
+	out := make([]models.PatternMatch, len(m.patterns))
+	for i, p := range m.patterns {
+		kind := models.PatternExclude
+		if p.include {
+			kind = models.PatternInclude
+		}
+		out[i] = models.PatternMatch{Pattern: p.raw, Kind: kind}
+		if i < len(counts) {
+			out[i].Count = counts[i]
+		}
+	}
+	return out
+}
+
+// rulesKey fingerprints the settings that decide which entries a walk
+// visits, so that cached directory counts are dropped when they change
+func rulesKey(cfg PathConfig) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%q|%q|%v|%s|%v|%d", cfg.Include, cfg.Exclude,
+		cfg.SkipHidden, cfg.Symlinks, cfg.OneFilesystem, cfg.MaxDepth)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,203 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*.csv", "a.csv", true},
+		{"*.csv", "in/a.csv", false},
+		{"*/tmp/*", "in/tmp/a.csv", true},
+		{"*/tmp/*", "in/tmp/sub/a.csv", false},
+		{"**/tmp/*", "tmp/a.csv", true},
+		{"**/tmp/*", "a/b/tmp/c", true},
+		{"in/**", "in/a/b/c.csv", true},
+		{"in/**", "out/a.csv", false},
+		{"part-?.csv", "part-1.csv", true},
+		{"part-?.csv", "part-10.csv", false},
+		{"[!.]*", ".hidden", false},
+		{"[a-c]*", "batch", true},
+		{`\*.csv`, "*.csv", true},
+		{`\*.csv`, "a.csv", false},
+		{`*.[\]]`, "a.]", true},
+		{`*.[\]]`, "a.\\", false},
+		{`[\-]x`, "-x", true},
+		{`[\-]x`, "\\x", false},
+		{`[a\-c]x`, "bx", false},
+		{`[.+]x`, "+x", true},
+		{`[.+]x`, "ax", false},
+		{`[^a]x`, "bx", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.path, func(t *testing.T) {
+			re, err := GlobToRegexp(tt.glob)
+			if err != nil {
+				t.Fatalf("GlobToRegexp(%q) error = %v", tt.glob, err)
+			}
+			if got := re.MatchString(tt.path); got != tt.match {
+				t.Errorf("match(%q) = %v, want %v", tt.path, got, tt.match)
+			}
+		})
+	}
+
+	// Patterns filepath.Match rejects, and reversed ranges, which it
+	// would accept without ever matching them
+	for _, glob := range []string{"[a-", "[]a]", "[-a]", "[a-]", `a\`, "*.[z-a]"} {
+		if _, err := GlobToRegexp(glob); err == nil {
+			t.Errorf("Expected error for %q", glob)
+		}
+	}
+}
+
+// setupMatchDir creates:
+//
+//	root/
+//	  in/a.csv in/b.csv in/notes.txt
+//	  in/tmp/c.csv
+//	  .cache/d.csv
+//	  .e.csv
+func setupMatchDir(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"in/tmp", ".cache"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"in/a.csv", "in/b.csv", "in/notes.txt", "in/tmp/c.csv", ".cache/d.csv", ".e.csv"} {
+		if err := os.WriteFile(filepath.Join(root, file), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestWalker_IncludeSelectsFilesOnly(t *testing.T) {
+	root := setupMatchDir(t)
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	w := mustWalker(t, scanner, PathConfig{Path: root, Include: []string{"*.csv"}, Exclude: []string{"**/tmp"}})
+
+	got := collectWalk(t, w)
+	want := []string{".cache", ".cache/d.csv", ".e.csv", "in", "in/a.csv", "in/b.csv"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+
+	counts := w.match.results(w.matchCounts())
+	wantCounts := []models.PatternMatch{
+		{Pattern: "*.csv", Kind: models.PatternInclude, Count: 4},
+		{Pattern: "**/tmp", Kind: models.PatternExclude, Count: 1},
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(wantCounts) {
+		t.Errorf("pattern matches = %v, want %v", counts, wantCounts)
+	}
+}
+
+func TestWalker_RegexAndSkipHidden(t *testing.T) {
+	root := setupMatchDir(t)
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	w := mustWalker(t, scanner, PathConfig{Path: root, Include: []string{`re:^in/[ab]\.csv$`}, SkipHidden: true})
+
+	got := collectWalk(t, w)
+	want := []string{"in", "in/a.csv", "in/b.csv", "in/tmp"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+}
+
+func TestNewPathMatcher_InvalidPattern(t *testing.T) {
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	if _, err := scanner.newWalker(PathConfig{Path: "/data", Exclude: []string{"re:(tmp"}}, ""); err == nil {
+		t.Error("Expected error for invalid regex pattern")
+	}
+}
+
+func TestWalker_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	target := t.TempDir()
+	os.WriteFile(filepath.Join(target, "linked.csv"), nil, 0644)
+	os.WriteFile(filepath.Join(root, "a.csv"), nil, 0644)
+	if err := os.Symlink(target, filepath.Join(root, "data")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	// A link back to the root would loop forever if followed blindly
+	os.Symlink(root, filepath.Join(target, "back"))
+
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{"", []string{"a.csv", "data"}},
+		{SymlinksCount, []string{"a.csv", "data"}},
+		{SymlinksSkip, []string{"a.csv"}},
+		{SymlinksFollow, []string{"a.csv", "data", "data/back", "data/linked.csv"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode "+tt.mode, func(t *testing.T) {
+			w := mustWalker(t, scanner, PathConfig{Path: root, Symlinks: tt.mode})
+			got := collectWalk(t, w)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathScanner_ReportsPatternMatches(t *testing.T) {
+	root := setupMatchDir(t)
+	cfg := PathConfig{Path: root, Timeout: 30 * time.Second, Include: []string{"*.csv"}, SkipHidden: true}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if stats.FileCount != 3 || stats.DirCount != 2 {
+		t.Errorf("counts = %d files, %d dirs, want 3 and 2", stats.FileCount, stats.DirCount)
+	}
+	if len(stats.PatternMatches) != 1 || stats.PatternMatches[0].Count != 3 {
+		t.Errorf("pattern matches = %v, want *.csv matching 3", stats.PatternMatches)
+	}
+}
+
+func TestPathScanner_Incremental_RulesChangeDropsCache(t *testing.T) {
+	root := setupMatchDir(t)
+	ageDirs(t, root, time.Now().Add(-time.Hour))
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{Path: root, Timeout: 30 * time.Second, Incremental: true}
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	scanIncremental(t, scanner, cfg)
+
+	cfg.Include = []string{"*.csv"}
+	stats := scanIncremental(t, scanner, cfg)
+	if stats.DirsReused != 0 {
+		t.Errorf("DirsReused = %d after a rules change, want 0", stats.DirsReused)
+	}
+	if stats.FileCount != 5 {
+		t.Errorf("FileCount = %d, want 5 CSV files", stats.FileCount)
+	}
+
+	again := scanIncremental(t, scanner, cfg)
+	if again.DirsReused == 0 || again.FileCount != 5 {
+		t.Errorf("Expected the rescan to reuse dirs with 5 files, got reused %d, files %d", again.DirsReused, again.FileCount)
+	}
+	if len(again.PatternMatches) != 1 || again.PatternMatches[0].Count != 5 {
+		t.Errorf("pattern matches from cache = %v, want *.csv matching 5", again.PatternMatches)
+	}
+}
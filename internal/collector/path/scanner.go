@@ -2,11 +2,14 @@ package path
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
@@ -17,34 +20,106 @@ type PathsRepository interface {
 	SavePathStats(ctx context.Context, stats *models.PathStats) error
 	GetLatestPathStats(ctx context.Context) ([]*models.PathStats, error)
 	GetPathStats(ctx context.Context, path string) (*models.PathStats, error)
+	SavePathViolations(ctx context.Context, path string, violations []*models.PathViolation) error
+	SavePathEvents(ctx context.Context, events []*models.PathEvent) error
+	LoadDirCache(ctx context.Context, path string) ([]models.DirCacheEntry, error)
+	UpdateDirCache(ctx context.Context, path string, changed []models.DirCacheEntry, removed []string) error
+	SavePathBreakdown(ctx context.Context, path string, usage []*models.PathDirUsage) error
+	DeletePath(ctx context.Context, path string) error
 }
 
 // PathConfig represents configuration for a monitored path
 type PathConfig struct {
-	Path         string
-	ScanInterval time.Duration
-	MaxDepth     int
-	Exclude      []string
-	Timeout      time.Duration
+	Path             string
+	ScanInterval     time.Duration
+	MaxDepth         int
+	Include          []string // Only count files matching one of these patterns (empty = all files)
+	Exclude          []string // Skip entries matching any of these patterns
+	Timeout          time.Duration
+	MaxFileAge       time.Duration     // Files older than this are stuck (0 = disabled)
+	MaxFileCount     int64             // Expected upper bound on file count (0 = disabled)
+	Track            bool              // Keep a file manifest and emit change events between scans
+	Workers          int               // Directories read concurrently (0 = default)
+	MaxOpsPerSec     int               // Filesystem ops/sec limit (0 = automatic for network filesystems)
+	Incremental      bool              // Only re-read directories whose mtime changed since the last walk
+	FullScanInterval time.Duration     // How often an incremental path gets a full walk (0 = default)
+	SkipHidden       bool              // Skip files and directories whose name starts with a dot
+	Symlinks         string            // SymlinksCount, SymlinksSkip or SymlinksFollow ("" = count)
+	OneFilesystem    bool              // Do not descend into directories on other filesystems
+	Buckets          map[string]string // Named file patterns counted with their total size (name -> pattern)
+	BreakdownDepth   int               // Record subtree sizes of directories down to this depth (0 = disabled)
+	ExpandInterval   time.Duration     // How often a glob template path is re-expanded (0 = default)
+	Watch            bool              // Keep counts current from filesystem notifications between scans
+	ExpectedCadence  time.Duration     // How often a new file is expected to arrive (0 = freshness not tracked)
+}
+
+// maxViolationFiles caps how many stuck files are recorded per scan
+const maxViolationFiles = 100
+
+// walkResult holds everything collected during a single walk
+type walkResult struct {
+	fileCount  int64
+	dirCount   int64
+	staleCount int64                   // Files older than MaxFileAge
+	staleFiles []*models.PathViolation // Oldest stale files, capped at maxViolationFiles
+	newest     time.Time               // Modification time of the newest file (only with an expected cadence)
+	manifest   manifest                // File manifest (only when tracking)
+	sizeBytes  int64                   // Total file size (only when tracking)
+	oldest     time.Time               // Modification time of the oldest file (only when tracking)
+	buckets    []models.PathBucket     // Counts per bucket (only when buckets are configured)
+	breakdown  *breakdown              // Subtree sizes (only when a breakdown depth is configured)
 }
 
 // PathScanner monitors filesystem paths and collects statistics
 type PathScanner struct {
-	repo      PathsRepository
-	paths     []PathConfig
-	scanning  map[string]bool // Track which paths are currently being scanned
-	cancel    context.CancelFunc
-	wg        sync.WaitGroup
-	mu        sync.Mutex
+	repo         PathsRepository
+	paths        []PathConfig                               // Concrete paths from the configuration
+	templates    []PathConfig                               // Glob templates, expanded into concrete paths at runtime
+	expanded     map[string]*expandedPath                   // Paths currently matched by a template
+	scanning     map[string]bool                            // Track which paths are currently being scanned
+	manifests    map[string]manifest                        // Last complete manifest per tracked path
+	dirCaches    map[string]map[string]models.DirCacheEntry // Directory cache per incremental path, as persisted
+	lastFull     map[string]time.Time                       // Last full walk per incremental path
+	quarantined  map[string]bool                            // Paths on a stale mount, skipped until a probe succeeds
+	probes       map[string]chan struct{}                   // Outstanding probe per path, closed when its stat returns
+	probeTimeout time.Duration                              // How long a probe stat may take before the mount is stale
+	jobs         map[string]*scanJob                        // Manual scan jobs by ID
+	jobOrder     []string                                   // Job IDs, oldest first
+	jobQueue     chan *scanJob                              // Jobs waiting for the job runner
+	jobOnce      sync.Once                                  // Starts the job runner on first submission
+	jobCtx       context.Context                            // Parent of all job contexts, cancelled by Stop
+	jobCancel    context.CancelFunc
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	mu           sync.Mutex
 }
 
-// NewPathScanner creates a new path scanner
+// NewPathScanner creates a new path scanner. Paths containing glob
+// characters are templates: each directory they match is monitored with the
+// template's settings.
 func NewPathScanner(repo PathsRepository, paths []PathConfig) *PathScanner {
-	return &PathScanner{
-		repo:     repo,
-		paths:    paths,
-		scanning: make(map[string]bool),
+	s := &PathScanner{
+		repo:         repo,
+		expanded:     make(map[string]*expandedPath),
+		scanning:     make(map[string]bool),
+		manifests:    make(map[string]manifest),
+		dirCaches:    make(map[string]map[string]models.DirCacheEntry),
+		lastFull:     make(map[string]time.Time),
+		quarantined:  make(map[string]bool),
+		probes:       make(map[string]chan struct{}),
+		probeTimeout: defaultProbeTimeout,
+		jobs:         make(map[string]*scanJob),
+		jobQueue:     make(chan *scanJob, maxScanJobs),
+	}
+	for _, cfg := range paths {
+		if isPathTemplate(cfg.Path) {
+			s.templates = append(s.templates, cfg)
+		} else {
+			s.paths = append(s.paths, cfg)
+		}
 	}
+	s.jobCtx, s.jobCancel = context.WithCancel(context.Background())
+	return s
 }
 
 // Start begins periodic path scanning
@@ -68,9 +143,18 @@ func (s *PathScanner) Start(ctx context.Context) {
 			s.scanLoop(ctx, pathCfg)
 		}(cfg)
 	}
+
+	// Expand each template; matched paths get scan loops of their own
+	for _, tmpl := range s.templates {
+		s.wg.Add(1)
+		go func(tmpl PathConfig) {
+			defer s.wg.Done()
+			s.expandLoop(ctx, tmpl)
+		}(tmpl)
+	}
 }
 
-// Stop stops all path scanning
+// Stop stops all path scanning and cancels pending scan jobs
 func (s *PathScanner) Stop() {
 	s.mu.Lock()
 	if s.cancel != nil {
@@ -78,17 +162,27 @@ func (s *PathScanner) Stop() {
 		s.cancel = nil
 	}
 	s.mu.Unlock()
+	s.jobCancel()
 
 	s.wg.Wait()
 }
 
+// errAlreadyScanning is returned by ScanPath when another scan of the path is in progress
+var errAlreadyScanning = errors.New("already being scanned")
+
 // ScanPath performs a single scan of a path
 func (s *PathScanner) ScanPath(ctx context.Context, cfg PathConfig) (*models.PathStats, error) {
+	return s.scanPath(ctx, cfg, nil)
+}
+
+// scanPath performs a single scan of a path, reporting live counts to
+// progress when it is not nil
+func (s *PathScanner) scanPath(ctx context.Context, cfg PathConfig, progress *scanProgress) (*models.PathStats, error) {
 	// Check if already scanning this path
 	s.mu.Lock()
 	if s.scanning[cfg.Path] {
 		s.mu.Unlock()
-		return nil, fmt.Errorf("path %s is already being scanned", cfg.Path)
+		return nil, fmt.Errorf("path %s is %w", cfg.Path, errAlreadyScanning)
 	}
 	s.scanning[cfg.Path] = true
 	s.mu.Unlock()
@@ -110,49 +204,176 @@ func (s *PathScanner) ScanPath(ctx context.Context, cfg PathConfig) (*models.Pat
 		CollectedAt: time.Now(),
 	}
 
+	// A quarantined path is only walked again once its mount answers
+	if s.isQuarantined(cfg.Path) {
+		if err := s.probe(cfg.Path); err != nil {
+			stats.Status = "STALE"
+			stats.ErrorMessage = err.Error()
+			stats.ScanDurationMs = time.Since(startTime).Milliseconds()
+			stats.CollectedAt = time.Now()
+			return stats, nil
+		}
+		s.setQuarantined(cfg.Path, false)
+	}
+
 	// Perform the scan
-	fileCount, dirCount, err := s.walkPath(scanCtx, cfg)
+	fsType := detectFsType(scanCtx, cfg.Path)
+	w, err := s.newWalker(cfg, fsType)
+	if err != nil {
+		stats.Status = "ERROR"
+		stats.ErrorMessage = err.Error()
+		stats.CollectedAt = time.Now()
+		return stats, nil
+	}
+	w.progress = progress
+	var full bool
+	if cfg.Incremental {
+		full = s.startIncremental(ctx, cfg, w, startTime)
+	}
+	res, err := s.walkPath(scanCtx, w)
 	duration := time.Since(startTime)
 
-	stats.FileCount = fileCount
-	stats.DirCount = dirCount
+	stats.FileCount = res.fileCount
+	stats.DirCount = res.dirCount
 	stats.ScanDurationMs = duration.Milliseconds()
+	stats.FsType = fsType
+	stats.FsOps = w.ops.Load()
+	stats.OpsLimit = opsLimit(cfg, fsType)
+	stats.ThrottledMs = w.throttledFor().Milliseconds()
+	stats.PatternMatches = w.match.results(w.matchCounts())
+	stats.Buckets = res.buckets
 
 	if err != nil {
-		if scanCtx.Err() == context.DeadlineExceeded {
-			stats.Status = "ERROR"
-			stats.ErrorMessage = "scan timeout exceeded"
+		if scanCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			// Tell a slow walk from a mount that stopped answering
+			if probeErr := s.probe(cfg.Path); probeErr != nil {
+				s.setQuarantined(cfg.Path, true)
+				stats.Status = "STALE"
+				stats.ErrorMessage = probeErr.Error()
+			} else {
+				stats.Status = "TIMEOUT"
+				stats.ErrorMessage = "scan timeout exceeded"
+			}
 		} else {
 			stats.Status = "ERROR"
 			stats.ErrorMessage = err.Error()
 		}
 	} else {
 		stats.Status = "OK"
+		evaluateSLA(cfg, stats, res, startTime)
 	}
 
 	stats.CollectedAt = time.Now()
 
+	// Only a complete walk replaces the stored breakdown
+	if res.breakdown != nil && err == nil {
+		stats.Breakdown = res.breakdown.result(cfg.Path, stats.CollectedAt)
+	}
+
+	if cfg.Incremental {
+		s.finishIncremental(ctx, cfg, w, stats, full, err == nil)
+	}
+
+	// Report totals and diff against the previous manifest; partial walks
+	// are never diffed so that a timeout does not show up as mass removal
+	if cfg.Track && err == nil {
+		stats.SizeBytes = res.sizeBytes
+		if !res.oldest.IsZero() {
+			oldest := res.oldest
+			stats.OldestFileAt = &oldest
+		}
+
+		s.mu.Lock()
+		prev, hasPrev := s.manifests[cfg.Path]
+		s.manifests[cfg.Path] = res.manifest
+		s.mu.Unlock()
+
+		if hasPrev {
+			stats.Events = diffManifests(cfg.Path, prev, res.manifest, stats.CollectedAt)
+		}
+	}
+
 	return stats, nil
 }
 
-// ScanPaths implements the PathScanner interface for the API server.
-// It wraps TriggerScan with a background context.
-func (s *PathScanner) ScanPaths(paths []string) error {
-	return s.TriggerScan(context.Background(), paths)
+// evaluateSLA applies the max_file_age, max_file_count and expected_cadence
+// expectations to a successful scan. Exceeding an expectation marks the path
+// WARNING; exceeding it by a factor of two or more marks it CRITICAL.
+func evaluateSLA(cfg PathConfig, stats *models.PathStats, res *walkResult, now time.Time) {
+	var reasons []string
+	severity := 0 // 0=OK, 1=WARNING, 2=CRITICAL
+
+	if cfg.ExpectedCadence > 0 {
+		if reason := evaluateFreshness(cfg, stats, res, now); reason != "" {
+			reasons = append(reasons, reason)
+			if stats.Freshness == models.FreshnessStale {
+				severity = 2
+			} else {
+				severity = 1
+			}
+		}
+	}
+
+	if cfg.MaxFileAge > 0 && res.staleCount > 0 {
+		oldest := now.Sub(res.staleFiles[0].ModTime)
+		reasons = append(reasons, fmt.Sprintf("%d files older than %s (oldest %s)",
+			res.staleCount, cfg.MaxFileAge, oldest.Truncate(time.Second)))
+		if oldest >= 2*cfg.MaxFileAge {
+			severity = 2
+		} else {
+			severity = max(severity, 1)
+		}
+		stats.Violations = res.staleFiles
+	}
+
+	if cfg.MaxFileCount > 0 && res.fileCount > cfg.MaxFileCount {
+		reasons = append(reasons, fmt.Sprintf("file count %d exceeds max %d",
+			res.fileCount, cfg.MaxFileCount))
+		if res.fileCount >= 2*cfg.MaxFileCount {
+			severity = 2
+		} else {
+			severity = max(severity, 1)
+		}
+	}
+
+	switch severity {
+	case 1:
+		stats.Status = "WARNING"
+	case 2:
+		stats.Status = "CRITICAL"
+	}
+	stats.StatusReason = strings.Join(reasons, "; ")
+}
+
+// evaluateFreshness compares the newest file of a scan with the expected
+// cadence, returning why the path is not fresh
+func evaluateFreshness(cfg PathConfig, stats *models.PathStats, res *walkResult, now time.Time) string {
+	if res.newest.IsZero() {
+		stats.Freshness = models.FreshnessStale
+		return fmt.Sprintf("no files (expected a new file every %s)", cfg.ExpectedCadence)
+	}
+
+	newest := res.newest
+	age := max(now.Sub(newest), 0)
+	stats.NewestFileAt = &newest
+	stats.DataAgeSec = int64(age / time.Second)
+
+	switch {
+	case age <= cfg.ExpectedCadence:
+		stats.Freshness = models.FreshnessFresh
+		return ""
+	case age < 2*cfg.ExpectedCadence:
+		stats.Freshness = models.FreshnessLate
+	default:
+		stats.Freshness = models.FreshnessStale
+	}
+	return fmt.Sprintf("no new file for %s (expected every %s)", age.Truncate(time.Second), cfg.ExpectedCadence)
 }
 
 // TriggerScan manually triggers a scan for specific paths
 func (s *PathScanner) TriggerScan(ctx context.Context, paths []string) error {
 	for _, path := range paths {
-		// Find the config for this path
-		var cfg *PathConfig
-		for i := range s.paths {
-			if s.paths[i].Path == path {
-				cfg = &s.paths[i]
-				break
-			}
-		}
-
+		cfg := s.configFor(path)
 		if cfg == nil {
 			return fmt.Errorf("no configuration found for path: %s", path)
 		}
@@ -162,106 +383,227 @@ func (s *PathScanner) TriggerScan(ctx context.Context, paths []string) error {
 			return err
 		}
 
-		if err := s.repo.SavePathStats(ctx, stats); err != nil {
-			return fmt.Errorf("failed to save path stats: %w", err)
+		if err := s.saveStats(ctx, stats); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// scanLoop runs the periodic scanning for a single path
+// configFor returns the configuration of a monitored path, or nil if it is not monitored
+func (s *PathScanner) configFor(path string) *PathConfig {
+	for i := range s.paths {
+		if s.paths[i].Path == path {
+			return &s.paths[i]
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.expanded[path]; ok {
+		cfg := e.cfg
+		return &cfg
+	}
+	return nil
+}
+
+// saveStats persists scan statistics together with the offending files list
+func (s *PathScanner) saveStats(ctx context.Context, stats *models.PathStats) error {
+	if err := s.repo.SavePathStats(ctx, stats); err != nil {
+		return fmt.Errorf("failed to save path stats: %w", err)
+	}
+	if err := s.repo.SavePathViolations(ctx, stats.Path, stats.Violations); err != nil {
+		return fmt.Errorf("failed to save path violations: %w", err)
+	}
+	if len(stats.Events) > 0 {
+		if err := s.repo.SavePathEvents(ctx, stats.Events); err != nil {
+			return fmt.Errorf("failed to save path events: %w", err)
+		}
+	}
+	if stats.Breakdown != nil {
+		if err := s.repo.SavePathBreakdown(ctx, stats.Path, stats.Breakdown); err != nil {
+			return fmt.Errorf("failed to save path breakdown: %w", err)
+		}
+	}
+	return nil
+}
+
+// scanLoop runs the periodic scanning for a single path. A watched path
+// is watched from the end of each successful scan until the next one.
 func (s *PathScanner) scanLoop(ctx context.Context, cfg PathConfig) {
 	ticker := time.NewTicker(cfg.ScanInterval)
 	defer ticker.Stop()
 
-	// Scan immediately on start
-	stats, _ := s.ScanPath(ctx, cfg)
-	if stats != nil {
-		_ = s.repo.SavePathStats(ctx, stats)
+	var watch *pathWatch
+	defer func() { watch.stop() }()
+
+	scan := func() {
+		// The full scan resynchronises whatever the watch counted
+		watch.stop()
+		watch = nil
+
+		stats, _ := s.ScanPath(ctx, cfg)
+		if stats == nil {
+			return
+		}
+		if cfg.Watch && scanSucceeded(stats.Status) {
+			watch = s.startWatch(ctx, cfg, stats)
+		}
+		_ = s.saveStats(ctx, stats)
+		if watch != nil {
+			watch.start(ctx)
+		}
 	}
 
+	// Scan immediately on start
+	scan()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			stats, _ := s.ScanPath(ctx, cfg)
-			if stats != nil {
-				_ = s.repo.SavePathStats(ctx, stats)
-			}
+			scan()
 		}
 	}
 }
 
-// walkPath walks the directory tree and counts files and directories
-func (s *PathScanner) walkPath(ctx context.Context, cfg PathConfig) (fileCount, dirCount int64, err error) {
+// scanSucceeded reports whether a scan status comes from a complete walk
+func scanSucceeded(status string) bool {
+	return status == "OK" || status == "WARNING" || status == "CRITICAL"
+}
+
+// walkPath walks the directory tree, counts files and directories,
+// collects files that exceed the configured max file age and builds
+// the file manifest for tracked paths
+func (s *PathScanner) walkPath(ctx context.Context, w *walker) (*walkResult, error) {
 	var mu sync.Mutex
-	baseDepth := strings.Count(cfg.Path, string(filepath.Separator))
+	cfg := w.cfg
+	res := &walkResult{buckets: w.buckets.empty()}
+	if cfg.Track {
+		res.manifest = make(manifest)
+	}
+	if cfg.BreakdownDepth > 0 {
+		res.breakdown = newBreakdown(cfg.BreakdownDepth)
+	}
+	needInfo := cfg.MaxFileAge > 0 || cfg.ExpectedCadence > 0 || cfg.Track || w.buckets != nil || res.breakdown != nil
+	now := time.Now()
 
-	err = filepath.WalkDir(cfg.Path, func(path string, d fs.DirEntry, err error) error {
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
+	err := w.walk(ctx, func(path string, d fs.DirEntry) error {
+		// Count files and directories
+		mu.Lock()
+		if d.IsDir() {
+			res.dirCount++
+			if res.breakdown != nil {
+				res.breakdown.add(relPath(cfg.Path, path), true, 0)
+			}
+		} else {
+			res.fileCount++
 		}
+		mu.Unlock()
+		w.progress.add(d.IsDir())
 
-		if err != nil {
-			// Skip paths we can't access
+		if !needInfo || d.IsDir() {
 			return nil
 		}
+		info, err := w.info(ctx, d)
+		if err != nil {
+			// File vanished between readdir and stat, or the walk was cancelled
+			return ctx.Err()
+		}
 
-		// Skip the root path itself
-		if path == cfg.Path {
-			return nil
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cfg.MaxFileAge > 0 && now.Sub(info.ModTime()) > cfg.MaxFileAge {
+			res.addStale(&models.PathViolation{
+				Path:       cfg.Path,
+				FilePath:   path,
+				SizeBytes:  info.Size(),
+				ModTime:    info.ModTime(),
+				Reason:     fmt.Sprintf("older than %s", cfg.MaxFileAge),
+				DetectedAt: now,
+			})
 		}
 
-		// Check max depth
-		if cfg.MaxDepth > 0 {
-			currentDepth := strings.Count(path, string(filepath.Separator))
-			relativeDepth := currentDepth - baseDepth
-			if relativeDepth > cfg.MaxDepth {
-				if d.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
+		if cfg.ExpectedCadence > 0 && info.ModTime().After(res.newest) {
+			res.newest = info.ModTime()
 		}
 
-		// Check exclude patterns
-		if s.shouldExclude(path, cfg.Exclude) {
-			if d.IsDir() {
-				return filepath.SkipDir
+		if cfg.Track {
+			if rel, err := filepath.Rel(cfg.Path, path); err == nil {
+				res.manifest[rel] = fileMeta{size: info.Size(), modTime: info.ModTime()}
+			}
+			res.sizeBytes += info.Size()
+			if res.oldest.IsZero() || info.ModTime().Before(res.oldest) {
+				res.oldest = info.ModTime()
 			}
-			return nil
 		}
 
-		// Count files and directories
-		mu.Lock()
-		if d.IsDir() {
-			dirCount++
-		} else {
-			fileCount++
+		if w.buckets != nil {
+			w.buckets.add(res.buckets, path, info.Size())
+		}
+
+		if res.breakdown != nil {
+			res.breakdown.add(relPath(cfg.Path, path), false, info.Size())
 		}
-		mu.Unlock()
 
 		return nil
 	})
 
-	return fileCount, dirCount, err
+	// Directories reused from the incremental cache were never passed to fn
+	if w.cache != nil {
+		res.fileCount += w.cache.files.Load()
+		res.dirCount += w.cache.dirs.Load()
+	}
+
+	res.trimStale()
+	return res, err
 }
 
-// shouldExclude checks if a path matches any exclude pattern
-func (s *PathScanner) shouldExclude(path string, excludePatterns []string) bool {
-	base := filepath.Base(path)
+// newWalker builds a walker for cfg, throttled according to the path's
+// configured limit or, failing that, its filesystem type
+func (s *PathScanner) newWalker(cfg PathConfig, fsType string) (*walker, error) {
+	match, err := newPathMatcher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := newBucketSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	w := &walker{
+		cfg:     cfg,
+		workers: cfg.Workers,
+		match:   match,
+		matches: make([]atomic.Int64, len(match.patterns)),
+		buckets: buckets,
+	}
+	if w.workers <= 0 {
+		w.workers = defaultWalkWorkers
+	}
+	if limit := opsLimit(cfg, fsType); limit > 0 {
+		w.limiter = newRateLimiter(limit)
+	}
+	return w, nil
+}
 
-	for _, pattern := range excludePatterns {
-		matched, err := filepath.Match(pattern, base)
-		if err == nil && matched {
-			return true
-		}
+// addStale records a stale file, periodically trimming to the oldest entries
+func (r *walkResult) addStale(v *models.PathViolation) {
+	r.staleCount++
+	r.staleFiles = append(r.staleFiles, v)
+	if len(r.staleFiles) >= 2*maxViolationFiles {
+		r.trimStale()
 	}
+}
 
-	return false
+// trimStale sorts stale files oldest first and keeps at most maxViolationFiles
+func (r *walkResult) trimStale() {
+	sort.Slice(r.staleFiles, func(i, j int) bool {
+		return r.staleFiles[i].ModTime.Before(r.staleFiles[j].ModTime)
+	})
+	if len(r.staleFiles) > maxViolationFiles {
+		r.staleFiles = r.staleFiles[:maxViolationFiles]
+	}
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,11 +15,19 @@ import (
 
 // MockPathsRepository is a mock implementation of the PathsRepository for testing
 type MockPathsRepository struct {
-	savedStats []*models.PathStats
-	saveError  error
+	savedStats      []*models.PathStats
+	savedViolations map[string][]*models.PathViolation
+	savedEvents     []*models.PathEvent
+	dirCache        map[string]map[string]models.DirCacheEntry
+	savedBreakdown  map[string][]*models.PathDirUsage
+	deletedPaths    []string
+	saveError       error
+	mu              sync.Mutex // Guards the fields above against concurrent scan loops
 }
 
 func (m *MockPathsRepository) SavePathStats(ctx context.Context, stats *models.PathStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.saveError != nil {
 		return m.saveError
 	}
@@ -26,10 +36,14 @@ func (m *MockPathsRepository) SavePathStats(ctx context.Context, stats *models.P
 }
 
 func (m *MockPathsRepository) GetLatestPathStats(ctx context.Context) ([]*models.PathStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.savedStats, nil
 }
 
 func (m *MockPathsRepository) GetPathStats(ctx context.Context, path string) (*models.PathStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, stats := range m.savedStats {
 		if stats.Path == path {
 			return stats, nil
@@ -38,6 +52,78 @@ func (m *MockPathsRepository) GetPathStats(ctx context.Context, path string) (*m
 	return nil, fmt.Errorf("path not found")
 }
 
+func (m *MockPathsRepository) SavePathViolations(ctx context.Context, path string, violations []*models.PathViolation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.savedViolations == nil {
+		m.savedViolations = make(map[string][]*models.PathViolation)
+	}
+	m.savedViolations[path] = violations
+	return nil
+}
+
+func (m *MockPathsRepository) SavePathEvents(ctx context.Context, events []*models.PathEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.savedEvents = append(m.savedEvents, events...)
+	return nil
+}
+
+func (m *MockPathsRepository) LoadDirCache(ctx context.Context, path string) ([]models.DirCacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []models.DirCacheEntry
+	for _, e := range m.dirCache[path] {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (m *MockPathsRepository) UpdateDirCache(ctx context.Context, path string, changed []models.DirCacheEntry, removed []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.dirCache == nil {
+		m.dirCache = make(map[string]map[string]models.DirCacheEntry)
+	}
+	if m.dirCache[path] == nil {
+		m.dirCache[path] = make(map[string]models.DirCacheEntry)
+	}
+	for _, e := range changed {
+		m.dirCache[path][e.Dir] = e
+	}
+	for _, dir := range removed {
+		delete(m.dirCache[path], dir)
+	}
+	return nil
+}
+
+func (m *MockPathsRepository) SavePathBreakdown(ctx context.Context, path string, usage []*models.PathDirUsage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.savedBreakdown == nil {
+		m.savedBreakdown = make(map[string][]*models.PathDirUsage)
+	}
+	m.savedBreakdown[path] = usage
+	return nil
+}
+
+func (m *MockPathsRepository) DeletePath(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deletedPaths = append(m.deletedPaths, path)
+	var kept []*models.PathStats
+	for _, stats := range m.savedStats {
+		if stats.Path != path {
+			kept = append(kept, stats)
+		}
+	}
+	m.savedStats = kept
+	delete(m.savedViolations, path)
+	delete(m.dirCache, path)
+	delete(m.savedBreakdown, path)
+	return nil
+}
+
 // setupTestDir creates a temporary directory structure for testing
 func setupTestDir(t *testing.T) string {
 	tmpDir, err := os.MkdirTemp("", "etlmon-test-*")
@@ -243,7 +329,7 @@ func TestPathScanner_ScanOnce_RecordsDuration(t *testing.T) {
 	}
 }
 
-func TestPathScanner_ScanOnce_TimesOut_SetsErrorStatus(t *testing.T) {
+func TestPathScanner_ScanOnce_TimesOut_SetsTimeoutStatus(t *testing.T) {
 	// Create a large directory that will take time to scan
 	tmpDir := setupTestDir(t)
 	defer os.RemoveAll(tmpDir)
@@ -261,13 +347,13 @@ func TestPathScanner_ScanOnce_TimesOut_SetsErrorStatus(t *testing.T) {
 	ctx := context.Background()
 	stats, err := scanner.ScanPath(ctx, cfg)
 
-	// Should return stats with ERROR status (not a Go error)
+	// Should return stats with TIMEOUT status (not a Go error)
 	if err != nil {
 		t.Fatalf("ScanPath() should not return error, got: %v", err)
 	}
 
-	if stats.Status != "ERROR" {
-		t.Errorf("Status = %s, want ERROR", stats.Status)
+	if stats.Status != "TIMEOUT" {
+		t.Errorf("Status = %s, want TIMEOUT", stats.Status)
 	}
 
 	if stats.ErrorMessage == "" {
@@ -367,3 +453,351 @@ func TestPathScanner_Start_ScansAtInterval(t *testing.T) {
 		t.Errorf("Expected at least 2 scans, got %d", len(repo.savedStats))
 	}
 }
+
+// ageFiles sets the modification time of the given files (relative to dir) to age ago
+func ageFiles(t *testing.T, dir string, age time.Duration, names ...string) {
+	t.Helper()
+	mtime := time.Now().Add(-age)
+	for _, name := range names {
+		if err := os.Chtimes(filepath.Join(dir, name), mtime, mtime); err != nil {
+			t.Fatalf("Failed to age file %s: %v", name, err)
+		}
+	}
+}
+
+func TestPathScanner_ScanOnce_MaxFileAge_FlagsStuckFiles(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	ageFiles(t, tmpDir, 90*time.Minute, "file5.txt", filepath.Join("dir1", "file1.txt"))
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+		MaxFileAge:   1 * time.Hour,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	if stats.Status != "WARNING" {
+		t.Errorf("Status = %s, want WARNING", stats.Status)
+	}
+	if stats.StatusReason == "" {
+		t.Error("StatusReason should explain the violation")
+	}
+	if len(stats.Violations) != 2 {
+		t.Fatalf("Violations = %d, want 2", len(stats.Violations))
+	}
+	for _, v := range stats.Violations {
+		if v.Path != tmpDir {
+			t.Errorf("Violation path = %s, want %s", v.Path, tmpDir)
+		}
+		if time.Since(v.ModTime) < time.Hour {
+			t.Errorf("Violation %s is not older than max_file_age", v.FilePath)
+		}
+	}
+}
+
+func TestPathScanner_ScanOnce_MaxFileAge_CriticalWhenTwiceExceeded(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	ageFiles(t, tmpDir, 3*time.Hour, "file5.txt")
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+		MaxFileAge:   1 * time.Hour,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	if stats.Status != "CRITICAL" {
+		t.Errorf("Status = %s, want CRITICAL", stats.Status)
+	}
+}
+
+func TestPathScanner_ScanOnce_MaxFileCount_SetsSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxCount int64
+		want     string
+	}{
+		{"within limit", 5, "OK"},
+		{"exceeded", 4, "WARNING"},
+		{"exceeded twice", 2, "CRITICAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := setupTestDir(t)
+			defer os.RemoveAll(tmpDir)
+
+			repo := &MockPathsRepository{}
+			cfg := PathConfig{
+				Path:         tmpDir,
+				ScanInterval: 1 * time.Minute,
+				MaxDepth:     10,
+				Timeout:      30 * time.Second,
+				MaxFileCount: tt.maxCount,
+			}
+
+			scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+			stats, err := scanner.ScanPath(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("ScanPath() error = %v", err)
+			}
+
+			if stats.Status != tt.want {
+				t.Errorf("Status = %s, want %s (reason %q)", stats.Status, tt.want, stats.StatusReason)
+			}
+			if tt.want == "OK" && stats.StatusReason != "" {
+				t.Errorf("StatusReason = %q, want empty", stats.StatusReason)
+			}
+		})
+	}
+}
+
+func TestPathScanner_ScanOnce_ExpectedCadence_ReportsFreshness(t *testing.T) {
+	tests := []struct {
+		name      string
+		newestAge time.Duration
+		want      string
+		status    string
+	}{
+		{"fresh", 5 * time.Minute, models.FreshnessFresh, "OK"},
+		{"late", 20 * time.Minute, models.FreshnessLate, "WARNING"},
+		{"stale", 45 * time.Minute, models.FreshnessStale, "CRITICAL"},
+	}
+
+	files := []string{
+		filepath.Join("dir1", "file1.txt"),
+		filepath.Join("dir1", "file2.log"),
+		filepath.Join("dir1", "subdir1", "file3.txt"),
+		filepath.Join("dir2", "file4.tmp"),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := setupTestDir(t)
+			defer os.RemoveAll(tmpDir)
+
+			// The newest file decides freshness, wherever it sits in the tree
+			ageFiles(t, tmpDir, 3*time.Hour, files...)
+			ageFiles(t, tmpDir, tt.newestAge, "file5.txt")
+
+			cfg := PathConfig{
+				Path:            tmpDir,
+				ScanInterval:    1 * time.Minute,
+				MaxDepth:        10,
+				Timeout:         30 * time.Second,
+				ExpectedCadence: 15 * time.Minute,
+			}
+			scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+
+			stats, err := scanner.ScanPath(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("ScanPath() error = %v", err)
+			}
+
+			if stats.Freshness != tt.want || stats.Status != tt.status {
+				t.Errorf("Freshness = %s with status %s, want %s with %s (reason %q)",
+					stats.Freshness, stats.Status, tt.want, tt.status, stats.StatusReason)
+			}
+			if stats.NewestFileAt == nil {
+				t.Fatal("NewestFileAt should be set")
+			}
+			if age := time.Duration(stats.DataAgeSec) * time.Second; age < tt.newestAge-time.Minute || age > tt.newestAge+time.Minute {
+				t.Errorf("DataAgeSec = %d, want about %v", stats.DataAgeSec, tt.newestAge)
+			}
+		})
+	}
+}
+
+func TestPathScanner_ScanOnce_ExpectedCadence_EmptyPathIsStale(t *testing.T) {
+	cfg := PathConfig{
+		Path:            t.TempDir(),
+		ScanInterval:    1 * time.Minute,
+		Timeout:         30 * time.Second,
+		ExpectedCadence: 15 * time.Minute,
+	}
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	if stats.Freshness != models.FreshnessStale || stats.NewestFileAt != nil {
+		t.Errorf("Freshness = %s with newest %v, want STALE without a newest file", stats.Freshness, stats.NewestFileAt)
+	}
+	if !strings.Contains(stats.StatusReason, "no files") {
+		t.Errorf("StatusReason = %q, want it to mention the missing files", stats.StatusReason)
+	}
+}
+
+func TestPathScanner_TriggerScan_SavesViolations(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	ageFiles(t, tmpDir, 2*time.Hour, "file5.txt")
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+		MaxFileAge:   30 * time.Minute,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+
+	if err := scanner.TriggerScan(context.Background(), []string{tmpDir}); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+
+	violations := repo.savedViolations[tmpDir]
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 saved violation, got %d", len(violations))
+	}
+	if violations[0].FilePath != filepath.Join(tmpDir, "file5.txt") {
+		t.Errorf("Violation file = %s, want file5.txt", violations[0].FilePath)
+	}
+}
+
+func TestPathScanner_TriggerScan_Track_EmitsEventsBetweenScans(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+		Track:        true,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	ctx := context.Background()
+
+	// First scan only records the baseline
+	if err := scanner.TriggerScan(ctx, []string{tmpDir}); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+	if len(repo.savedEvents) != 0 {
+		t.Fatalf("Expected no events after baseline scan, got %d", len(repo.savedEvents))
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.csv"), []byte("a,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "file5.txt"), []byte("changed content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "dir2", "file4.tmp")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scanner.TriggerScan(ctx, []string{tmpDir}); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, e := range repo.savedEvents {
+		if e.Path != tmpDir {
+			t.Errorf("Event path = %s, want %s", e.Path, tmpDir)
+		}
+		got[e.FileName] = e.EventType
+	}
+	want := map[string]string{
+		"new.csv":                          models.PathEventCreated,
+		"file5.txt":                        models.PathEventModified,
+		filepath.Join("dir2", "file4.tmp"): models.PathEventRemoved,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d events, got %+v", len(want), got)
+	}
+	for name, eventType := range want {
+		if got[name] != eventType {
+			t.Errorf("Event for %s = %q, want %q", name, got[name], eventType)
+		}
+	}
+}
+
+func TestPathScanner_ScanOnce_WithoutTrack_EmitsNoEvents(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	ctx := context.Background()
+
+	scanner.ScanPath(ctx, cfg)
+	os.WriteFile(filepath.Join(tmpDir, "new.csv"), []byte("a,b"), 0644)
+	stats, err := scanner.ScanPath(ctx, cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	if len(stats.Events) != 0 {
+		t.Errorf("Expected no events for untracked path, got %d", len(stats.Events))
+	}
+}
+
+func TestPathScanner_ScanOnce_RecordsThrottleStats(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	repo := &MockPathsRepository{}
+	cfg := PathConfig{
+		Path:         tmpDir,
+		ScanInterval: 1 * time.Minute,
+		MaxDepth:     10,
+		Timeout:      30 * time.Second,
+		MaxFileAge:   time.Hour,
+		MaxOpsPerSec: 1000,
+	}
+
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	// 4 readdirs (root + 3 dirs) and 5 stats for the age check
+	if stats.FsOps != 9 {
+		t.Errorf("FsOps = %d, want 9", stats.FsOps)
+	}
+	if stats.OpsLimit != 1000 {
+		t.Errorf("OpsLimit = %d, want 1000", stats.OpsLimit)
+	}
+	if stats.FileCount != 5 || stats.DirCount != 3 {
+		t.Errorf("Counts = %d files, %d dirs, want 5 and 3", stats.FileCount, stats.DirCount)
+	}
+}
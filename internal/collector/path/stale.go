@@ -0,0 +1,101 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// defaultProbeTimeout bounds how long a mount may take to answer a stat of
+// the monitored path before it is considered stale
+const defaultProbeTimeout = 5 * time.Second
+
+// Filesystem calls, replaceable in tests to simulate a hung mount
+var (
+	readDir  = os.ReadDir
+	statPath = os.Stat
+)
+
+// errStaleMount is returned by probe when the path's filesystem does not respond
+var errStaleMount = errors.New("stale mount: filesystem not responding")
+
+// fsCall runs a filesystem operation in its own goroutine so that a call
+// blocked on an unresponsive mount cannot hold the caller past ctx. The
+// goroutine itself stays blocked until the kernel returns.
+func fsCall[T any](ctx context.Context, op func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		v   T
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		v, err := op()
+		ch <- result{v, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// probe checks whether the filesystem holding path answers a stat within
+// the probe timeout. At most one probe per path is outstanding: while a
+// previous stat is still blocked, later probes wait on that same call
+// instead of piling up more blocked goroutines.
+func (s *PathScanner) probe(path string) error {
+	s.mu.Lock()
+	done, inFlight := s.probes[path]
+	if !inFlight {
+		ch := make(chan struct{})
+		stat := statPath
+		go func() {
+			// Any answer, including an error such as ENOENT, means the mount responds
+			stat(path)
+			close(ch)
+		}()
+		done = ch
+		s.probes[path] = ch
+	}
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.probeTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		s.mu.Lock()
+		delete(s.probes, path)
+		s.mu.Unlock()
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w (no answer within %s)", errStaleMount, s.probeTimeout)
+	}
+}
+
+// isQuarantined reports whether path is waiting for a successful probe
+func (s *PathScanner) isQuarantined(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.quarantined[path]
+}
+
+// setQuarantined puts path into or takes it out of quarantine
+func (s *PathScanner) setQuarantined(path string, quarantined bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if quarantined {
+		s.quarantined[path] = true
+	} else {
+		delete(s.quarantined, path)
+	}
+}
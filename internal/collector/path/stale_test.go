@@ -0,0 +1,154 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// hangFS makes readdir and stat block until the returned release func is
+// called, simulating an NFS server that stopped answering
+func hangFS(t *testing.T) (release func()) {
+	t.Helper()
+	blocked := make(chan struct{})
+	origReadDir, origStat := readDir, statPath
+
+	readDir = func(name string) ([]os.DirEntry, error) {
+		<-blocked
+		return origReadDir(name)
+	}
+	statPath = func(name string) (os.FileInfo, error) {
+		<-blocked
+		return origStat(name)
+	}
+
+	released := false
+	release = func() {
+		if !released {
+			released = true
+			close(blocked)
+		}
+	}
+	t.Cleanup(func() {
+		release()
+		readDir, statPath = origReadDir, origStat
+	})
+	return release
+}
+
+func newStaleTestScanner(cfg PathConfig) *PathScanner {
+	scanner := NewPathScanner(&MockPathsRepository{}, []PathConfig{cfg})
+	scanner.probeTimeout = 50 * time.Millisecond
+	return scanner
+}
+
+func TestPathScanner_ScanOnce_HungMount_ReturnsStaleAtDeadline(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	hangFS(t)
+
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 100 * time.Millisecond}
+	scanner := newStaleTestScanner(cfg)
+
+	start := time.Now()
+	stats, err := scanner.ScanPath(context.Background(), cfg)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("ScanPath() took %v, expected it to return at the deadline", elapsed)
+	}
+	if stats.Status != "STALE" {
+		t.Errorf("Status = %s, want STALE", stats.Status)
+	}
+	if !scanner.isQuarantined(tmpDir) {
+		t.Error("Expected path to be quarantined")
+	}
+
+	// The scanning flag must be released so later scans can run
+	scanner.mu.Lock()
+	scanning := scanner.scanning[tmpDir]
+	scanner.mu.Unlock()
+	if scanning {
+		t.Error("Expected scanning flag to be cleared")
+	}
+}
+
+func TestPathScanner_ScanOnce_Quarantined_SkipsWalkUntilProbeSucceeds(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	release := hangFS(t)
+
+	cfg := PathConfig{Path: tmpDir, MaxDepth: 10, Timeout: 5 * time.Second}
+	scanner := newStaleTestScanner(cfg)
+	scanner.setQuarantined(tmpDir, true)
+
+	// Mount still hung: the scan reports STALE after the probe timeout, not the scan timeout
+	start := time.Now()
+	stats, _ := scanner.ScanPath(context.Background(), cfg)
+	if stats.Status != "STALE" {
+		t.Errorf("Status = %s, want STALE", stats.Status)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Quarantined scan took %v, expected only the probe timeout", elapsed)
+	}
+
+	if _, err := scanner.ListFiles(context.Background(), tmpDir, models.PathFilesQuery{}); !errors.Is(err, errStaleMount) {
+		t.Errorf("ListFiles() on quarantined path error = %v, want stale mount", err)
+	}
+
+	// Mount recovers: the pending probe completes and the path is scanned again
+	release()
+	stats, _ = scanner.ScanPath(context.Background(), cfg)
+	if stats.Status != "OK" {
+		t.Errorf("Status = %s, want OK after recovery (%s)", stats.Status, stats.ErrorMessage)
+	}
+	if stats.FileCount != 5 {
+		t.Errorf("FileCount = %d, want 5", stats.FileCount)
+	}
+	if scanner.isQuarantined(tmpDir) {
+		t.Error("Expected path to leave quarantine")
+	}
+}
+
+func TestPathScanner_Probe_ReusesInFlightStat(t *testing.T) {
+	tmpDir := t.TempDir()
+	hangFS(t)
+
+	scanner := newStaleTestScanner(PathConfig{Path: tmpDir})
+
+	for i := 0; i < 3; i++ {
+		if err := scanner.probe(tmpDir); !errors.Is(err, errStaleMount) {
+			t.Fatalf("probe() error = %v, want stale mount", err)
+		}
+	}
+
+	scanner.mu.Lock()
+	n := len(scanner.probes)
+	scanner.mu.Unlock()
+	if n != 1 {
+		t.Errorf("Expected a single outstanding probe, got %d", n)
+	}
+}
+
+func TestFsCall_ReturnsAtDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	_, err := fsCall(ctx, func() (int, error) {
+		<-block
+		return 0, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("fsCall() error = %v, want DeadlineExceeded", err)
+	}
+}
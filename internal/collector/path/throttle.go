@@ -0,0 +1,117 @@
+package path
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/mountinfo"
+)
+
+// defaultNetworkOpsPerSec is the ops/sec limit applied automatically to
+// paths on network filesystems that do not configure their own limit
+const defaultNetworkOpsPerSec = 500
+
+// procMountsPath is the mount table used for filesystem type detection
+var procMountsPath = "/proc/mounts"
+
+// rateLimiter is a token bucket allowing rate operations per second with a
+// burst of up to one second's worth of operations
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a limiter for opsPerSec operations per second
+func newRateLimiter(opsPerSec int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(opsPerSec),
+		tokens: float64(opsPerSec),
+		last:   time.Now(),
+	}
+}
+
+// wait takes one token, sleeping until it is available. It returns how
+// long the caller was held back.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		return delay, ctx.Err()
+	}
+}
+
+// opsLimit returns the effective ops/sec limit for a path on fsType.
+// An explicit limit always wins; network filesystems get a default.
+func opsLimit(cfg PathConfig, fsType string) int {
+	if cfg.MaxOpsPerSec > 0 {
+		return cfg.MaxOpsPerSec
+	}
+	if mountinfo.IsNetwork(fsType) {
+		return defaultNetworkOpsPerSec
+	}
+	return 0
+}
+
+// detectFsType returns the type of the filesystem holding path, using the
+// longest matching mount point in /proc/mounts. It returns an empty string
+// when the mount table is unavailable.
+func detectFsType(ctx context.Context, path string) string {
+	f, err := os.Open(procMountsPath)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	// Resolving symlinks touches the target filesystem, which may hang
+	resolved, err := fsCall(ctx, func() (string, error) {
+		return filepath.EvalSymlinks(path)
+	})
+	if err == nil {
+		path = resolved
+	}
+	path = filepath.Clean(path)
+
+	var best, fsType string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Format: device mountpoint fstype options dump pass
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		mountPoint := mountinfo.Unescape(fields[1])
+		if !mountinfo.Contains(mountPoint, path) || len(mountPoint) < len(best) {
+			continue
+		}
+		best, fsType = mountPoint, fields[2]
+	}
+	return fsType
+}
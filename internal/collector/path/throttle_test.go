@@ -0,0 +1,97 @@
+package path
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_AllowsBurstThenWaits(t *testing.T) {
+	l := newRateLimiter(10)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		if waited, err := l.wait(ctx); err != nil || waited != 0 {
+			t.Fatalf("op %d within burst: waited %v, err %v", i, waited, err)
+		}
+	}
+
+	waited, err := l.wait(ctx)
+	if err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+	if waited <= 0 || waited > 200*time.Millisecond {
+		t.Errorf("Expected roughly 100ms wait after burst, got %v", waited)
+	}
+}
+
+func TestRateLimiter_CancelledContext(t *testing.T) {
+	l := newRateLimiter(1)
+	l.wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := l.wait(ctx); err != context.Canceled {
+		t.Errorf("wait() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestDetectFsType_LongestMountPrefix(t *testing.T) {
+	mounts := filepath.Join(t.TempDir(), "mounts")
+	content := `/dev/sda1 / ext4 rw,relatime 0 0
+filer:/export /mnt/filer nfs4 rw,vers=4.2 0 0
+//smb/share /mnt/filer\040share cifs rw 0 0
+/dev/sdb1 /mnt/filer/local xfs rw 0 0
+`
+	if err := os.WriteFile(mounts, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := procMountsPath
+	procMountsPath = mounts
+	defer func() { procMountsPath = orig }()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/var/data", "ext4"},
+		{"/mnt/filer", "nfs4"},
+		{"/mnt/filer/incoming", "nfs4"},
+		{"/mnt/filer share/x", "cifs"},
+		{"/mnt/filer/local/spool", "xfs"},
+		{"/mnt/filerx", "ext4"},
+	}
+
+	for _, tt := range tests {
+		if got := detectFsType(context.Background(), tt.path); got != tt.want {
+			t.Errorf("detectFsType(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestOpsLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    PathConfig
+		fsType string
+		want   int
+	}{
+		{"local unthrottled", PathConfig{}, "ext4", 0},
+		{"nfs automatic", PathConfig{}, "nfs4", defaultNetworkOpsPerSec},
+		{"cifs automatic", PathConfig{}, "cifs", defaultNetworkOpsPerSec},
+		{"explicit limit wins", PathConfig{MaxOpsPerSec: 50}, "nfs", 50},
+		{"explicit limit on local disk", PathConfig{MaxOpsPerSec: 1000}, "xfs", 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opsLimit(tt.cfg, tt.fsType); got != tt.want {
+				t.Errorf("opsLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
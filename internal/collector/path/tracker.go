@@ -0,0 +1,59 @@
+package path
+
+import (
+	"sort"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// fileMeta is the manifest entry kept for each tracked file
+type fileMeta struct {
+	size    int64
+	modTime time.Time
+}
+
+// manifest maps file names (relative to the monitored path) to their metadata
+type manifest map[string]fileMeta
+
+// diffManifests compares two consecutive manifests of a path and returns
+// created, modified and removed events ordered by file name
+func diffManifests(path string, prev, curr manifest, detectedAt time.Time) []*models.PathEvent {
+	var events []*models.PathEvent
+
+	for name, cur := range curr {
+		old, existed := prev[name]
+		switch {
+		case !existed:
+			events = append(events, newPathEvent(path, name, models.PathEventCreated, cur, detectedAt))
+		case old.size != cur.size || !old.modTime.Equal(cur.modTime):
+			events = append(events, newPathEvent(path, name, models.PathEventModified, cur, detectedAt))
+		}
+	}
+
+	for name, old := range prev {
+		if _, exists := curr[name]; !exists {
+			events = append(events, newPathEvent(path, name, models.PathEventRemoved, old, detectedAt))
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].FileName != events[j].FileName {
+			return events[i].FileName < events[j].FileName
+		}
+		return events[i].EventType < events[j].EventType
+	})
+
+	return events
+}
+
+func newPathEvent(path, name, eventType string, meta fileMeta, detectedAt time.Time) *models.PathEvent {
+	return &models.PathEvent{
+		Path:       path,
+		FileName:   name,
+		EventType:  eventType,
+		SizeBytes:  meta.size,
+		ModTime:    meta.modTime,
+		DetectedAt: detectedAt,
+	}
+}
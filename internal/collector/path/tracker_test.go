@@ -0,0 +1,63 @@
+package path
+
+import (
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestDiffManifests_DetectsCreatedModifiedRemoved(t *testing.T) {
+	t0 := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	detected := t0.Add(time.Minute)
+
+	prev := manifest{
+		"keep.csv":  {size: 10, modTime: t0},
+		"grow.csv":  {size: 10, modTime: t0},
+		"touch.csv": {size: 10, modTime: t0},
+		"gone.csv":  {size: 42, modTime: t0},
+	}
+	curr := manifest{
+		"keep.csv":  {size: 10, modTime: t0},
+		"grow.csv":  {size: 20, modTime: t0},
+		"touch.csv": {size: 10, modTime: t0.Add(time.Second)},
+		"new.csv":   {size: 5, modTime: t0},
+	}
+
+	events := diffManifests("/data/input", prev, curr, detected)
+
+	want := []struct {
+		name      string
+		eventType string
+	}{
+		{"gone.csv", models.PathEventRemoved},
+		{"grow.csv", models.PathEventModified},
+		{"new.csv", models.PathEventCreated},
+		{"touch.csv", models.PathEventModified},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i, w := range want {
+		if events[i].FileName != w.name || events[i].EventType != w.eventType {
+			t.Errorf("events[%d] = %s %s, want %s %s", i, events[i].FileName, events[i].EventType, w.name, w.eventType)
+		}
+		if events[i].Path != "/data/input" || !events[i].DetectedAt.Equal(detected) {
+			t.Errorf("events[%d] has unexpected path or detection time: %+v", i, events[i])
+		}
+	}
+
+	// Removed files keep their last known metadata
+	if events[0].SizeBytes != 42 {
+		t.Errorf("Removed event size = %d, want 42", events[0].SizeBytes)
+	}
+}
+
+func TestDiffManifests_IdenticalManifests_NoEvents(t *testing.T) {
+	t0 := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	m := manifest{"a.csv": {size: 1, modTime: t0}}
+
+	if events := diffManifests("/data/input", m, m, t0); len(events) != 0 {
+		t.Errorf("Expected no events, got %+v", events)
+	}
+}
@@ -0,0 +1,377 @@
+package path
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// defaultWalkWorkers is the number of directories read concurrently when a
+// path does not configure its own worker count
+const defaultWalkWorkers = 4
+
+// walkFunc is called for every entry below the walk root. It may be called
+// concurrently from several workers.
+type walkFunc func(path string, d fs.DirEntry) error
+
+// walker reads a directory tree with a bounded pool of workers, applying a
+// path's depth, matching, symlink and filesystem rules and an optional
+// filesystem ops/sec limit
+type walker struct {
+	cfg      PathConfig
+	workers  int
+	limiter  *rateLimiter // nil = unthrottled
+	match    *pathMatcher
+	buckets  *bucketSet    // Named file buckets (nil = none configured)
+	progress *scanProgress // Live counts for scan jobs (nil = not reported)
+	cache    *dirCache     // Directory mtime cache for incremental walks (nil = read every directory)
+
+	ops       atomic.Int64   // readdir and stat calls issued
+	throttled atomic.Int64   // nanoseconds spent waiting on the limiter
+	matches   []atomic.Int64 // Entries matched per pattern of match
+
+	rootDev    uint64   // Device of the walk root (one_filesystem)
+	hasRootDev bool     // rootDev is known
+	visited    sync.Map // Directories reached through followed symlinks, by fileID
+}
+
+// scanProgress holds live file and directory counts of a running walk
+type scanProgress struct {
+	files atomic.Int64
+	dirs  atomic.Int64
+}
+
+// add counts one visited entry; a nil progress ignores it
+func (p *scanProgress) add(isDir bool) {
+	if p == nil {
+		return
+	}
+	if isDir {
+		p.dirs.Add(1)
+	} else {
+		p.files.Add(1)
+	}
+}
+
+// addCounts counts entries taken from the directory cache; a nil progress ignores them
+func (p *scanProgress) addCounts(files, dirs int64) {
+	if p == nil {
+		return
+	}
+	p.files.Add(files)
+	p.dirs.Add(dirs)
+}
+
+// dirItem is a directory waiting to be read
+type dirItem struct {
+	path  string
+	depth int
+}
+
+// dirListing is the outcome of reading one directory
+type dirListing struct {
+	subdirs []dirItem // Subdirectories to descend into
+	files   int64     // Visible files
+	dirs    int64     // Visible subdirectories, including those beyond max depth
+	read    bool      // False when the directory could not be read
+	matches []int64   // Entries matched per pattern (nil when no pattern matched)
+}
+
+// countMatch records an entry matched by pattern idx
+func (w *walker) countMatch(l *dirListing, idx int) {
+	if idx < 0 {
+		return
+	}
+	if l.matches == nil {
+		l.matches = make([]int64, len(w.matches))
+	}
+	l.matches[idx]++
+	w.matches[idx].Add(1)
+}
+
+// addMatches adds the pattern counts of a reused directory to the walk totals
+func (w *walker) addMatches(counts []int64) {
+	for i, n := range counts {
+		if i < len(w.matches) {
+			w.matches[i].Add(n)
+		}
+	}
+}
+
+// matchCounts returns the walk's per-pattern totals
+func (w *walker) matchCounts() []int64 {
+	counts := make([]int64, len(w.matches))
+	for i := range w.matches {
+		counts[i] = w.matches[i].Load()
+	}
+	return counts
+}
+
+// walk visits the tree under cfg.Path. Unreadable entries are skipped; the
+// first error returned by fn or the context stops the walk.
+func (w *walker) walk(ctx context.Context, fn walkFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if w.cfg.OneFilesystem || w.cfg.Symlinks == SymlinksFollow {
+		if err := w.statRoot(ctx); err != nil {
+			return err
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		cond     = sync.NewCond(&mu)
+		stack    = []dirItem{{path: w.cfg.Path}}
+		pending  = 1 // directories queued or being read
+		firstErr error
+	)
+
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+		cond.Broadcast()
+	}
+
+	// Wake idle workers when the context is cancelled from outside
+	stop := context.AfterFunc(ctx, func() {
+		mu.Lock()
+		cond.Broadcast()
+		mu.Unlock()
+	})
+	defer stop()
+
+	workers := w.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				for len(stack) == 0 && pending > 0 && ctx.Err() == nil {
+					cond.Wait()
+				}
+				if len(stack) == 0 || ctx.Err() != nil {
+					mu.Unlock()
+					return
+				}
+				dir := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				mu.Unlock()
+
+				subdirs, err := w.readDir(ctx, dir, fn)
+				if err != nil {
+					fail(err)
+					return
+				}
+
+				mu.Lock()
+				stack = append(stack, subdirs...)
+				pending += len(subdirs) - 1
+				mu.Unlock()
+				cond.Broadcast()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// readDir lists one directory, calls fn for each visible entry and returns
+// the subdirectories that should be descended into
+func (w *walker) readDir(ctx context.Context, dir dirItem, fn walkFunc) ([]dirItem, error) {
+	if w.cache != nil {
+		return w.readDirCached(ctx, dir, fn)
+	}
+	l, err := w.listDir(ctx, dir, fn)
+	return l.subdirs, err
+}
+
+// listDir reads one directory and calls fn for each visible entry
+func (w *walker) listDir(ctx context.Context, dir dirItem, fn walkFunc) (dirListing, error) {
+	var l dirListing
+	if err := w.wait(ctx); err != nil {
+		return l, err
+	}
+	read := readDir
+	entries, err := fsCall(ctx, func() ([]os.DirEntry, error) {
+		return read(dir.path)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return l, ctxErr
+		}
+		// Skip directories we can't read
+		return l, nil
+	}
+
+	depth := dir.depth + 1
+	for _, d := range entries {
+		if err := ctx.Err(); err != nil {
+			return l, err
+		}
+
+		path := filepath.Join(dir.path, d.Name())
+
+		followed := false
+		if d.Type()&fs.ModeSymlink != 0 {
+			switch w.cfg.Symlinks {
+			case SymlinksSkip:
+				continue
+			case SymlinksFollow:
+				if d, followed, err = w.follow(ctx, path, d); err != nil {
+					return l, err
+				}
+			}
+		}
+
+		visible, idx := w.match.match(path, d.IsDir())
+		w.countMatch(&l, idx)
+		if !visible {
+			continue
+		}
+
+		if err := fn(path, d); err != nil {
+			return l, err
+		}
+		if !d.IsDir() {
+			l.files++
+			continue
+		}
+		l.dirs++
+		// Only descend while children stay within max depth
+		if w.cfg.MaxDepth > 0 && depth >= w.cfg.MaxDepth {
+			continue
+		}
+		enter, err := w.descend(ctx, d, followed)
+		if err != nil {
+			return l, err
+		}
+		if enter {
+			l.subdirs = append(l.subdirs, dirItem{path: path, depth: depth})
+		}
+	}
+	l.read = true
+	return l, nil
+}
+
+// statRoot records the device of the walk root, and marks the root as
+// visited so that a symlink back to it is not followed
+func (w *walker) statRoot(ctx context.Context) error {
+	if err := w.wait(ctx); err != nil {
+		return err
+	}
+	stat := statPath
+	info, err := fsCall(ctx, func() (fs.FileInfo, error) {
+		return stat(w.cfg.Path)
+	})
+	if err != nil {
+		// An unreadable root is skipped by the walk itself
+		return ctx.Err()
+	}
+	if dev, ino, ok := fileID(info); ok {
+		w.rootDev, w.hasRootDev = dev, true
+		w.visited.Store([2]uint64{dev, ino}, true)
+	}
+	return nil
+}
+
+// follow resolves a symlink entry to its target. A dangling link is kept
+// as the link itself and counted as a file.
+func (w *walker) follow(ctx context.Context, path string, d fs.DirEntry) (fs.DirEntry, bool, error) {
+	if err := w.wait(ctx); err != nil {
+		return d, false, err
+	}
+	stat := statPath
+	info, err := fsCall(ctx, func() (fs.FileInfo, error) {
+		return stat(path)
+	})
+	if err != nil {
+		return d, false, ctx.Err()
+	}
+	return fs.FileInfoToDirEntry(info), true, nil
+}
+
+// descend reports whether the walk enters a visible subdirectory. With
+// one_filesystem it must be on the root's device, and a directory reached
+// through a symlink is entered only the first time, which breaks link loops.
+func (w *walker) descend(ctx context.Context, d fs.DirEntry, followed bool) (bool, error) {
+	if !followed && !w.cfg.OneFilesystem {
+		return true, nil
+	}
+
+	var info fs.FileInfo
+	var err error
+	if followed {
+		info, err = d.Info() // Target already stat'ed by follow
+	} else {
+		info, err = w.info(ctx, d)
+	}
+	if err != nil {
+		return false, ctx.Err()
+	}
+	dev, ino, ok := fileID(info)
+	if !ok {
+		return true, nil
+	}
+	if w.cfg.OneFilesystem && w.hasRootDev && dev != w.rootDev {
+		return false, nil
+	}
+	if followed {
+		if _, seen := w.visited.LoadOrStore([2]uint64{dev, ino}, true); seen {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fileID returns the device and inode of a file
+func fileID(info fs.FileInfo) (dev, ino uint64, ok bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return uint64(st.Dev), uint64(st.Ino), true
+}
+
+// info stats a directory entry, counting it against the ops/sec limit
+func (w *walker) info(ctx context.Context, d fs.DirEntry) (fs.FileInfo, error) {
+	if err := w.wait(ctx); err != nil {
+		return nil, err
+	}
+	return fsCall(ctx, d.Info)
+}
+
+// wait records a filesystem operation, blocking until the limiter allows it
+func (w *walker) wait(ctx context.Context) error {
+	w.ops.Add(1)
+	if w.limiter == nil {
+		return nil
+	}
+	waited, err := w.limiter.wait(ctx)
+	w.throttled.Add(int64(waited))
+	return err
+}
+
+// throttledFor returns the total time spent waiting on the limiter
+func (w *walker) throttledFor() time.Duration {
+	return time.Duration(w.throttled.Load())
+}
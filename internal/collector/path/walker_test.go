@@ -0,0 +1,121 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collectWalk runs a walker and returns the relative paths it visited
+func collectWalk(t *testing.T, w *walker) []string {
+	t.Helper()
+	var mu sync.Mutex
+	var visited []string
+	err := w.walk(context.Background(), func(path string, d fs.DirEntry) error {
+		rel, _ := filepath.Rel(w.cfg.Path, path)
+		mu.Lock()
+		visited = append(visited, rel)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk() error = %v", err)
+	}
+	sort.Strings(visited)
+	return visited
+}
+
+func TestWalker_ParallelMatchesSequential(t *testing.T) {
+	tmpDir := t.TempDir()
+	for i := 0; i < 20; i++ {
+		dir := filepath.Join(tmpDir, fmt.Sprintf("d%02d", i), "sub")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 5; j++ {
+			os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.csv", j)), nil, 0644)
+		}
+	}
+
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	sequential := mustWalker(t, scanner, PathConfig{Path: tmpDir, Workers: 1})
+	parallel := mustWalker(t, scanner, PathConfig{Path: tmpDir, Workers: 8})
+
+	want := collectWalk(t, sequential)
+	got := collectWalk(t, parallel)
+
+	if len(want) != 20*2+20*5 {
+		t.Fatalf("sequential walk visited %d entries, want %d", len(want), 20*2+20*5)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("parallel walk differs from sequential walk:\n got %v\nwant %v", got, want)
+	}
+	if parallel.ops.Load() != sequential.ops.Load() {
+		t.Errorf("ops: parallel %d, sequential %d", parallel.ops.Load(), sequential.ops.Load())
+	}
+}
+
+// mustWalker builds an unthrottled walker for cfg, failing the test on invalid patterns
+func mustWalker(t *testing.T, s *PathScanner, cfg PathConfig) *walker {
+	t.Helper()
+	w, err := s.newWalker(cfg, "")
+	if err != nil {
+		t.Fatalf("newWalker() error = %v", err)
+	}
+	return w
+}
+
+func TestWalker_RespectsMaxDepthAndExclude(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	w := mustWalker(t, scanner, PathConfig{Path: tmpDir, MaxDepth: 1, Exclude: []string{"dir2"}})
+
+	got := collectWalk(t, w)
+	want := []string{"dir1", "file5.txt"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+}
+
+func TestWalker_StopsOnCallbackError(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	w := mustWalker(t, scanner, PathConfig{Path: tmpDir, Workers: 4})
+
+	boom := fmt.Errorf("boom")
+	err := w.walk(context.Background(), func(path string, d fs.DirEntry) error {
+		return boom
+	})
+	if err != boom {
+		t.Errorf("walk() error = %v, want %v", err, boom)
+	}
+}
+
+func TestWalker_Throttled_RecordsWaitTime(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+	// 3 directories plus the root need 4 readdirs; a 2 ops/sec bucket must wait
+	w := mustWalker(t, scanner, PathConfig{Path: tmpDir, MaxOpsPerSec: 2})
+
+	start := time.Now()
+	collectWalk(t, w)
+
+	if w.throttledFor() == 0 {
+		t.Error("Expected throttled time to be recorded")
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Errorf("Expected walk to be slowed down by the limiter, took %v", elapsed)
+	}
+}
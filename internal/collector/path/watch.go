@@ -0,0 +1,302 @@
+package path
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/mountinfo"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// watchDebounce is how long notifications are collected before the affected
+// directories are re-read and the new counts stored
+const watchDebounce = 100 * time.Millisecond
+
+// errWatchLimit reports that the kernel limit on watches or watchers is exhausted
+var errWatchLimit = errors.New("watch limit reached")
+
+// dirWatcher reports entries created, deleted or moved in watched directories
+type dirWatcher interface {
+	// Add starts watching a directory
+	Add(dir string) error
+	// Remove stops watching a directory
+	Remove(dir string)
+	// Changes delivers directories whose entries changed. An empty string
+	// means notifications were lost and every directory must be re-read.
+	// The channel is closed when the watcher fails or is closed.
+	Changes() <-chan string
+	// Err returns why Changes was closed, or nil after Close
+	Err() error
+	Close() error
+}
+
+// newWatcher creates the platform's directory watcher
+var newWatcher = newDirWatcher
+
+// pathWatch keeps the file and directory counts of a watched path current
+// between full scans
+type pathWatch struct {
+	s       *PathScanner
+	w       *walker
+	watcher dirWatcher
+	dirs    map[string]*watchedDir // Watched directories by path
+	stats   models.PathStats       // Last stored stats, with live counts
+	cancel  context.CancelFunc
+	done    chan struct{} // Closed when run has returned
+}
+
+// watchedDir holds the visible entries directly in one watched directory
+type watchedDir struct {
+	depth   int
+	files   int64
+	dirs    int64
+	subdirs []string
+}
+
+// startWatch watches every directory of a successfully scanned path and
+// records the outcome in stats. It returns nil when the path falls back to
+// periodic scans; otherwise the watch must be started once stats is stored.
+func (s *PathScanner) startWatch(ctx context.Context, cfg PathConfig, stats *models.PathStats) *pathWatch {
+	pw, err := s.newPathWatch(ctx, cfg, stats.FsType)
+	if err != nil {
+		stats.WatchState = models.WatchFallback
+		stats.WatchError = err.Error()
+		return nil
+	}
+	stats.WatchState = models.WatchActive
+	pw.stats = *stats
+	pw.stats.Violations, pw.stats.Events, pw.stats.Breakdown = nil, nil, nil
+	return pw
+}
+
+// newPathWatch sets up watches on the directories of a path and counts their entries
+func (s *PathScanner) newPathWatch(ctx context.Context, cfg PathConfig, fsType string) (*pathWatch, error) {
+	// Changes made by other hosts are never reported on a network filesystem
+	if mountinfo.IsNetwork(fsType) {
+		return nil, fmt.Errorf("watching is not supported on %s", fsType)
+	}
+	w, err := s.newWalker(cfg, fsType)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OneFilesystem {
+		if err := w.statRoot(ctx); err != nil {
+			return nil, err
+		}
+	}
+	watcher, err := newWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	pw := &pathWatch{
+		s:       s,
+		w:       w,
+		watcher: watcher,
+		dirs:    make(map[string]*watchedDir),
+		done:    make(chan struct{}),
+	}
+	if err := pw.add(ctx, dirItem{path: cfg.Path}); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return pw, nil
+}
+
+// start stores counts that changed since the scan and keeps them current
+// until stop is called or ctx is cancelled
+func (pw *pathWatch) start(ctx context.Context) {
+	ctx, pw.cancel = context.WithCancel(ctx)
+	pw.publish(ctx)
+	go pw.run(ctx)
+}
+
+// stop ends the watch and waits for it to return; a nil watch is ignored
+func (pw *pathWatch) stop() {
+	if pw == nil {
+		return
+	}
+	pw.cancel()
+	<-pw.done
+}
+
+// run re-reads the directories that changed, batching notifications for
+// watchDebounce, until ctx is cancelled or the watch fails
+func (pw *pathWatch) run(ctx context.Context) {
+	defer close(pw.done)
+	defer pw.watcher.Close()
+
+	pending := make(map[string]bool)
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case dir, ok := <-pw.watcher.Changes():
+			if !ok {
+				err := pw.watcher.Err()
+				if err == nil {
+					err = errors.New("watcher closed")
+				}
+				pw.fallback(ctx, err)
+				return
+			}
+			pending[dir] = true
+			if flush == nil {
+				flush = time.After(watchDebounce)
+			}
+		case <-flush:
+			flush = nil
+			if err := pw.update(ctx, pending); err != nil {
+				if ctx.Err() == nil {
+					pw.fallback(ctx, err)
+				}
+				return
+			}
+			clear(pending)
+			pw.publish(ctx)
+		}
+	}
+}
+
+// update re-reads the changed directories, or every directory after lost notifications
+func (pw *pathWatch) update(ctx context.Context, changed map[string]bool) error {
+	var dirs []string
+	if changed[""] {
+		for dir := range pw.dirs {
+			dirs = append(dirs, dir)
+		}
+	} else {
+		for dir := range changed {
+			dirs = append(dirs, dir)
+		}
+	}
+	// Parents first, so that a re-read subtree is not read twice
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if err := pw.relist(ctx, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relist re-reads one watched directory, watching subdirectories that
+// appeared and dropping those that went away
+func (pw *pathWatch) relist(ctx context.Context, dir string) error {
+	wd, ok := pw.dirs[dir]
+	if !ok {
+		// Dropped along with a parent, or never readable
+		return nil
+	}
+	l, err := pw.w.listDir(ctx, dirItem{path: dir, depth: wd.depth}, skipEntry)
+	if err != nil {
+		return err
+	}
+	if !l.read && dir != pw.w.cfg.Path {
+		pw.drop(dir)
+		return nil
+	}
+
+	current := make(map[string]bool, len(l.subdirs))
+	for _, sub := range l.subdirs {
+		current[sub.path] = true
+	}
+	// Drop before adding: a directory renamed in place keeps its watch descriptor
+	for _, sub := range wd.subdirs {
+		if !current[sub] {
+			pw.drop(sub)
+		}
+	}
+	wd.files, wd.dirs, wd.subdirs = l.files, l.dirs, wd.subdirs[:0]
+	for _, sub := range l.subdirs {
+		wd.subdirs = append(wd.subdirs, sub.path)
+		if _, ok := pw.dirs[sub.path]; ok {
+			continue
+		}
+		if err := pw.add(ctx, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add watches a directory and its subtree and counts their entries. The
+// watch is set up before the directory is read, so that no entry created
+// in between goes unnoticed. A directory that cannot be watched is skipped
+// like an unreadable one, unless the watch limit is exhausted.
+func (pw *pathWatch) add(ctx context.Context, dir dirItem) error {
+	if err := pw.watcher.Add(dir.path); err != nil {
+		if errors.Is(err, errWatchLimit) {
+			return err
+		}
+		return ctx.Err()
+	}
+	l, err := pw.w.listDir(ctx, dir, skipEntry)
+	if err != nil {
+		return err
+	}
+
+	wd := &watchedDir{depth: dir.depth, files: l.files, dirs: l.dirs}
+	pw.dirs[dir.path] = wd
+	for _, sub := range l.subdirs {
+		wd.subdirs = append(wd.subdirs, sub.path)
+		if err := pw.add(ctx, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drop stops watching a directory and its subtree
+func (pw *pathWatch) drop(dir string) {
+	wd, ok := pw.dirs[dir]
+	if !ok {
+		return
+	}
+	for _, sub := range wd.subdirs {
+		pw.drop(sub)
+	}
+	delete(pw.dirs, dir)
+	pw.watcher.Remove(dir)
+}
+
+// counts returns the files and directories in all watched directories
+func (pw *pathWatch) counts() (files, dirs int64) {
+	for _, wd := range pw.dirs {
+		files += wd.files
+		dirs += wd.dirs
+	}
+	return files, dirs
+}
+
+// publish stores the live counts when they differ from the stored ones.
+// Status is left as the last full scan evaluated it.
+func (pw *pathWatch) publish(ctx context.Context) {
+	files, dirs := pw.counts()
+	if files == pw.stats.FileCount && dirs == pw.stats.DirCount {
+		return
+	}
+	pw.stats.FileCount, pw.stats.DirCount = files, dirs
+	pw.stats.CollectedAt = time.Now()
+	stats := pw.stats
+	_ = pw.s.repo.SavePathStats(ctx, &stats)
+}
+
+// fallback stores that the path is back on periodic scans, and why
+func (pw *pathWatch) fallback(ctx context.Context, err error) {
+	pw.stats.WatchState = models.WatchFallback
+	pw.stats.WatchError = err.Error()
+	pw.stats.CollectedAt = time.Now()
+	stats := pw.stats
+	_ = pw.s.repo.SavePathStats(ctx, &stats)
+}
+
+// skipEntry is the walkFunc of directory listings that only need counts
+func skipEntry(string, fs.DirEntry) error {
+	return nil
+}
@@ -0,0 +1,152 @@
+//go:build linux
+
+package path
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// inotifyMask selects the notifications that change a directory's entries
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM |
+	syscall.IN_MOVED_TO | syscall.IN_ONLYDIR
+
+// inotifyWatcher is a dirWatcher backed by one inotify instance
+type inotifyWatcher struct {
+	fd      int
+	file    *os.File // fd, read through the runtime poller so that Close interrupts a read
+	changes chan string
+	closing chan struct{}
+	once    sync.Once
+	err     error // Why changes was closed; written before closing it
+
+	mu   sync.Mutex
+	dirs map[int32]string // Watched directory by watch descriptor
+	wds  map[string]int32 // Watch descriptor by directory
+}
+
+// newDirWatcher creates an inotify instance
+func newDirWatcher() (dirWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err == syscall.EMFILE {
+		return nil, fmt.Errorf("inotify instance limit reached (fs.inotify.max_user_instances): %w", errWatchLimit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("inotify: %w", err)
+	}
+
+	w := &inotifyWatcher{
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan string, 64),
+		closing: make(chan struct{}),
+		dirs:    make(map[int32]string),
+		wds:     make(map[string]int32),
+	}
+	go w.read()
+	return w, nil
+}
+
+func (w *inotifyWatcher) Add(dir string) error {
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, inotifyMask)
+	if err == syscall.ENOSPC {
+		return fmt.Errorf("inotify watch limit reached (fs.inotify.max_user_watches): %w", errWatchLimit)
+	}
+	if err != nil {
+		return fmt.Errorf("watch %s: %w", dir, err)
+	}
+
+	w.mu.Lock()
+	w.dirs[int32(wd)] = dir
+	w.wds[dir] = int32(wd)
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *inotifyWatcher) Remove(dir string) {
+	w.mu.Lock()
+	wd, ok := w.wds[dir]
+	if ok {
+		delete(w.wds, dir)
+		// A renamed directory keeps its descriptor; only unmap the old name
+		if w.dirs[wd] == dir {
+			delete(w.dirs, wd)
+		}
+	}
+	w.mu.Unlock()
+
+	if ok {
+		_, _ = syscall.InotifyRmWatch(w.fd, uint32(wd))
+	}
+}
+
+func (w *inotifyWatcher) Changes() <-chan string {
+	return w.changes
+}
+
+func (w *inotifyWatcher) Err() error {
+	return w.err
+}
+
+func (w *inotifyWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.closing)
+		err = w.file.Close()
+	})
+	return err
+}
+
+// read decodes notifications into changed directories until the watcher is closed
+func (w *inotifyWatcher) read() {
+	defer close(w.changes)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.err = fmt.Errorf("inotify read: %w", err)
+			}
+			return
+		}
+
+		for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			off += syscall.SizeofInotifyEvent + int(ev.Len)
+
+			var dir string
+			switch {
+			case ev.Mask&syscall.IN_Q_OVERFLOW != 0:
+				dir = ""
+			case ev.Mask&syscall.IN_IGNORED != 0:
+				// The directory was deleted or its watch removed
+				w.mu.Lock()
+				if d, ok := w.dirs[ev.Wd]; ok && w.wds[d] == ev.Wd {
+					delete(w.wds, d)
+				}
+				delete(w.dirs, ev.Wd)
+				w.mu.Unlock()
+				continue
+			default:
+				w.mu.Lock()
+				d, ok := w.dirs[ev.Wd]
+				w.mu.Unlock()
+				if !ok {
+					continue
+				}
+				dir = d
+			}
+
+			select {
+			case w.changes <- dir:
+			case <-w.closing:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package path
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestPathScanner_Watch_Inotify(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, root, "in")
+
+	repo := &MockPathsRepository{}
+	startWatchScanner(t, repo, PathConfig{Path: root, ScanInterval: time.Hour, Timeout: 30 * time.Second, Watch: true})
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.WatchState == models.WatchActive })
+
+	for _, name := range []string{"1.csv", "2.csv"} {
+		if err := os.WriteFile(filepath.Join(root, "in", name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdirs(t, root, "in/sub")
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 2 && s.DirCount == 2 })
+
+	// Entries created in a directory that appeared after the scan are seen too
+	if err := os.WriteFile(filepath.Join(root, "in", "sub", "3.csv"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 3 })
+
+	if err := os.Rename(filepath.Join(root, "in", "1.csv"), filepath.Join(root, "1.csv")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "in", "sub")); err != nil {
+		t.Fatal(err)
+	}
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 2 && s.DirCount == 1 })
+}
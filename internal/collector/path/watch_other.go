@@ -0,0 +1,11 @@
+//go:build !linux
+
+package path
+
+import "errors"
+
+// newDirWatcher reports that watching is unavailable, so watched paths
+// fall back to periodic scans
+func newDirWatcher() (dirWatcher, error) {
+	return nil, errors.New("watching is only supported on linux")
+}
@@ -0,0 +1,171 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// fakeWatcher is a dirWatcher driven by the test, with an optional watch limit
+type fakeWatcher struct {
+	mu      sync.Mutex
+	watched map[string]bool
+	limit   int // Watches allowed before errWatchLimit (0 = unlimited)
+	changes chan string
+}
+
+func newFakeWatcher(limit int) *fakeWatcher {
+	return &fakeWatcher{watched: make(map[string]bool), limit: limit, changes: make(chan string, 16)}
+}
+
+func (f *fakeWatcher) Add(dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.limit > 0 && len(f.watched) >= f.limit {
+		return fmt.Errorf("fake: %w", errWatchLimit)
+	}
+	f.watched[dir] = true
+	return nil
+}
+
+func (f *fakeWatcher) Remove(dir string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.watched, dir)
+}
+
+func (f *fakeWatcher) isWatched(dir string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.watched[dir]
+}
+
+func (f *fakeWatcher) Changes() <-chan string { return f.changes }
+func (f *fakeWatcher) Err() error             { return nil }
+func (f *fakeWatcher) Close() error           { return nil }
+
+// useWatcher makes the scanner use w for the duration of the test
+func useWatcher(t *testing.T, w dirWatcher) {
+	t.Helper()
+	orig := newWatcher
+	newWatcher = func() (dirWatcher, error) { return w, nil }
+	t.Cleanup(func() { newWatcher = orig })
+}
+
+// waitForStats polls the stored stats of a path until cond holds or the deadline passes
+func waitForStats(t *testing.T, repo *MockPathsRepository, path string, cond func(*models.PathStats) bool) *models.PathStats {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats := latestStats(repo, path)
+		if stats != nil && cond(stats) {
+			return stats
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for stats of %s, last %+v", path, stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// latestStats returns the most recently saved stats of a path
+func latestStats(repo *MockPathsRepository, path string) *models.PathStats {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for i := len(repo.savedStats) - 1; i >= 0; i-- {
+		if repo.savedStats[i].Path == path {
+			return repo.savedStats[i]
+		}
+	}
+	return nil
+}
+
+func startWatchScanner(t *testing.T, repo *MockPathsRepository, cfg PathConfig) {
+	t.Helper()
+	scanner := NewPathScanner(repo, []PathConfig{cfg})
+	ctx, cancel := context.WithCancel(context.Background())
+	scanner.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		scanner.Stop()
+	})
+}
+
+func TestPathScanner_Watch_RelistsChangedDirectories(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, root, "a", "b")
+	os.WriteFile(filepath.Join(root, "a", "1.csv"), nil, 0644)
+
+	fw := newFakeWatcher(0)
+	useWatcher(t, fw)
+	repo := &MockPathsRepository{}
+	startWatchScanner(t, repo, PathConfig{Path: root, ScanInterval: time.Hour, Timeout: 30 * time.Second, Watch: true})
+
+	stats := waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.WatchState == models.WatchActive })
+	if stats.FileCount != 1 || stats.DirCount != 2 {
+		t.Fatalf("Expected 1 file and 2 dirs after the scan, got %d and %d", stats.FileCount, stats.DirCount)
+	}
+
+	// A new subtree is counted and watched once its parent is reported
+	mkdirs(t, root, "a/new")
+	os.WriteFile(filepath.Join(root, "a", "new", "2.csv"), nil, 0644)
+	os.WriteFile(filepath.Join(root, "a", "new", "3.csv"), nil, 0644)
+	fw.changes <- filepath.Join(root, "a")
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 3 && s.DirCount == 3 })
+	if !fw.isWatched(filepath.Join(root, "a", "new")) {
+		t.Error("Expected the new directory to be watched")
+	}
+
+	// A removed subtree is no longer counted or watched
+	if err := os.RemoveAll(filepath.Join(root, "a")); err != nil {
+		t.Fatal(err)
+	}
+	fw.changes <- root
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 0 && s.DirCount == 1 })
+	if fw.isWatched(filepath.Join(root, "a", "new")) {
+		t.Error("Expected the removed directory to be unwatched")
+	}
+
+	// Lost notifications re-read every directory
+	os.WriteFile(filepath.Join(root, "b", "4.csv"), nil, 0644)
+	fw.changes <- ""
+	waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.FileCount == 1 })
+}
+
+func TestPathScanner_Watch_FallsBackAtWatchLimit(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, root, "a", "b", "c")
+
+	useWatcher(t, newFakeWatcher(2))
+	repo := &MockPathsRepository{}
+	startWatchScanner(t, repo, PathConfig{Path: root, ScanInterval: time.Hour, Timeout: 30 * time.Second, Watch: true})
+
+	stats := waitForStats(t, repo, root, func(s *models.PathStats) bool { return s.WatchState != "" })
+	if stats.WatchState != models.WatchFallback || !strings.Contains(stats.WatchError, "watch limit") {
+		t.Errorf("Expected fallback at the watch limit, got %q (%q)", stats.WatchState, stats.WatchError)
+	}
+	if stats.DirCount != 3 {
+		t.Errorf("Expected the full scan counts to be kept, got %d dirs", stats.DirCount)
+	}
+}
+
+func TestPathScanner_StartWatch_NetworkFilesystemFallsBack(t *testing.T) {
+	root := t.TempDir()
+	useWatcher(t, newFakeWatcher(0))
+	scanner := NewPathScanner(&MockPathsRepository{}, nil)
+
+	stats := &models.PathStats{Path: root, Status: "OK", FsType: "nfs4"}
+	if pw := scanner.startWatch(context.Background(), PathConfig{Path: root, Watch: true}, stats); pw != nil {
+		t.Fatal("Expected no watch on a network filesystem")
+	}
+	if stats.WatchState != models.WatchFallback || !strings.Contains(stats.WatchError, "nfs4") {
+		t.Errorf("Expected fallback naming the filesystem, got %q (%q)", stats.WatchState, stats.WatchError)
+	}
+}
@@ -0,0 +1,177 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// Repository defines the interface for storing pipeline samples
+type Repository interface {
+	SavePipelineSamples(ctx context.Context, pipeline string, samples []*models.PipelineStage) error
+	RetainPipelines(ctx context.Context, pipelines []string) error
+}
+
+// PathsRepository defines the interface for reading the scans of stage paths
+type PathsRepository interface {
+	GetPathStats(ctx context.Context, path string) (*models.PathStats, error)
+	CountPathEvents(ctx context.Context, path string, since, until time.Time) (created, removed int64, err error)
+}
+
+// PipelineConfig represents a named group of paths that files move through in order
+type PipelineConfig struct {
+	Name     string
+	Stages   []StageConfig
+	Interval time.Duration // How often the stages are sampled
+}
+
+// StageConfig represents one stage of a pipeline
+type StageConfig struct {
+	Name string
+	Path string // Monitored path holding the stage's files; must be tracked
+}
+
+// Sampler periodically records the state of every stage of each pipeline
+// together with the files that entered and left it since the previous sample
+type Sampler struct {
+	repo      Repository
+	paths     PathsRepository
+	pipelines []PipelineConfig
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+}
+
+// NewSampler creates a new pipeline sampler
+func NewSampler(repo Repository, paths PathsRepository, pipelines []PipelineConfig) *Sampler {
+	return &Sampler{
+		repo:      repo,
+		paths:     paths,
+		pipelines: pipelines,
+	}
+}
+
+// Start removes the stored samples of pipelines that are no longer
+// configured and begins periodic sampling
+func (s *Sampler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("sampler already started")
+	}
+
+	// Create cancellable context
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	names := make([]string, len(s.pipelines))
+	for i, p := range s.pipelines {
+		names[i] = p.Name
+	}
+	if err := s.repo.RetainPipelines(ctx, names); err != nil {
+		return err
+	}
+
+	for _, p := range s.pipelines {
+		s.wg.Add(1)
+		go func(p PipelineConfig) {
+			defer s.wg.Done()
+			s.sampleLoop(ctx, p)
+		}(p)
+	}
+
+	return nil
+}
+
+// Stop stops all pipeline sampling
+func (s *Sampler) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// sampleLoop runs the periodic sampling of a single pipeline. Each sample
+// counts the file events detected since the previous one; the first counts
+// those of the preceding interval.
+func (s *Sampler) sampleLoop(ctx context.Context, p PipelineConfig) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	last := time.Now().Add(-p.Interval)
+	sample := func() {
+		now := time.Now()
+		if err := s.SampleOnce(ctx, p, last, now); err == nil {
+			last = now
+		}
+	}
+
+	// Sample immediately on start
+	sample()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sample()
+		}
+	}
+}
+
+// SampleOnce samples every stage of a pipeline and stores the result
+func (s *Sampler) SampleOnce(ctx context.Context, p PipelineConfig, since, now time.Time) error {
+	samples, err := s.Sample(ctx, p, since, now)
+	if err != nil {
+		return fmt.Errorf("pipeline %s: %w", p.Name, err)
+	}
+	if err := s.repo.SavePipelineSamples(ctx, p.Name, samples); err != nil {
+		return fmt.Errorf("failed to save pipeline samples: %w", err)
+	}
+	return nil
+}
+
+// Sample returns one sample per stage, in stage order, from the last scan of
+// each stage path and the file events detected after since and up to now
+func (s *Sampler) Sample(ctx context.Context, p PipelineConfig, since, now time.Time) ([]*models.PipelineStage, error) {
+	samples := make([]*models.PipelineStage, 0, len(p.Stages))
+	for i, stage := range p.Stages {
+		sample := &models.PipelineStage{
+			Pipeline:    p.Name,
+			Stage:       stage.Name,
+			Position:    i,
+			Path:        stage.Path,
+			CollectedAt: now,
+		}
+
+		stats, err := s.paths.GetPathStats(ctx, stage.Path)
+		if err != nil {
+			return nil, err
+		}
+		// A stage path that has not been scanned yet is sampled empty
+		if stats != nil {
+			sample.FileCount = stats.FileCount
+			sample.SizeBytes = stats.SizeBytes
+			sample.OldestFileAt = stats.OldestFileAt
+			sample.Status = stats.Status
+			if stats.OldestFileAt != nil {
+				sample.OldestAgeSec = int64(max(now.Sub(*stats.OldestFileAt), 0) / time.Second)
+			}
+		}
+
+		sample.Arrived, sample.Departed, err = s.paths.CountPathEvents(ctx, stage.Path, since, now)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
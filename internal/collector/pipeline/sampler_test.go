@@ -0,0 +1,177 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// MockRepository is a mock implementation of the Repository for testing
+type MockRepository struct {
+	mu       sync.Mutex
+	saved    map[string][][]*models.PipelineStage
+	retained []string
+}
+
+func (m *MockRepository) SavePipelineSamples(ctx context.Context, pipeline string, samples []*models.PipelineStage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.saved == nil {
+		m.saved = make(map[string][][]*models.PipelineStage)
+	}
+	m.saved[pipeline] = append(m.saved[pipeline], samples)
+	return nil
+}
+
+func (m *MockRepository) RetainPipelines(ctx context.Context, pipelines []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retained = pipelines
+	return nil
+}
+
+func (m *MockRepository) rounds(pipeline string) [][]*models.PipelineStage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saved[pipeline]
+}
+
+// eventWindow records the arguments of a CountPathEvents call
+type eventWindow struct {
+	path         string
+	since, until time.Time
+}
+
+// MockPathsRepository serves fixed stats and event counts per path
+type MockPathsRepository struct {
+	mu      sync.Mutex
+	stats   map[string]*models.PathStats
+	created map[string]int64
+	removed map[string]int64
+	windows []eventWindow
+	err     error
+}
+
+func (m *MockPathsRepository) GetPathStats(ctx context.Context, path string) (*models.PathStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.stats[path], nil
+}
+
+func (m *MockPathsRepository) CountPathEvents(ctx context.Context, path string, since, until time.Time) (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.windows = append(m.windows, eventWindow{path, since, until})
+	return m.created[path], m.removed[path], nil
+}
+
+var ingest = PipelineConfig{
+	Name: "ingest",
+	Stages: []StageConfig{
+		{Name: "inbox", Path: "/data/inbox"},
+		{Name: "processing", Path: "/data/processing"},
+		{Name: "done", Path: "/data/done"},
+	},
+	Interval: time.Minute,
+}
+
+func TestSampler_Sample_ReportsEachStage(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-90 * time.Minute)
+	paths := &MockPathsRepository{
+		stats: map[string]*models.PathStats{
+			"/data/inbox":      {Path: "/data/inbox", FileCount: 40, SizeBytes: 4096, OldestFileAt: &oldest, Status: "OK"},
+			"/data/processing": {Path: "/data/processing", FileCount: 2, Status: "WARNING"},
+		},
+		created: map[string]int64{"/data/inbox": 12, "/data/processing": 10, "/data/done": 9},
+		removed: map[string]int64{"/data/inbox": 10, "/data/processing": 9},
+	}
+	s := NewSampler(&MockRepository{}, paths, []PipelineConfig{ingest})
+
+	samples, err := s.Sample(context.Background(), ingest, now.Add(-time.Minute), now)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+
+	if len(samples) != 3 {
+		t.Fatalf("Expected 3 stages, got %d", len(samples))
+	}
+	inbox := samples[0]
+	if inbox.Stage != "inbox" || inbox.Position != 0 || inbox.FileCount != 40 || inbox.SizeBytes != 4096 {
+		t.Errorf("Unexpected inbox sample: %+v", inbox)
+	}
+	if inbox.OldestAgeSec != 5400 || inbox.Arrived != 12 || inbox.Departed != 10 {
+		t.Errorf("Expected oldest age 5400s, 12 in and 10 out, got %d, %d and %d", inbox.OldestAgeSec, inbox.Arrived, inbox.Departed)
+	}
+	if samples[1].Status != "WARNING" || samples[1].Position != 1 {
+		t.Errorf("Expected processing WARNING at position 1, got %+v", samples[1])
+	}
+	// A stage that has not been scanned yet is sampled empty
+	done := samples[2]
+	if done.FileCount != 0 || done.Status != "" || done.Arrived != 9 || done.Pipeline != "ingest" {
+		t.Errorf("Unexpected done sample: %+v", done)
+	}
+	for _, w := range paths.windows {
+		if !w.since.Equal(now.Add(-time.Minute)) || !w.until.Equal(now) {
+			t.Errorf("Expected events counted over the last minute, got %v to %v", w.since, w.until)
+		}
+	}
+}
+
+func TestSampler_SampleOnce_SavesNothingOnError(t *testing.T) {
+	repo := &MockRepository{}
+	paths := &MockPathsRepository{err: errors.New("database is locked")}
+	s := NewSampler(repo, paths, []PipelineConfig{ingest})
+
+	now := time.Now()
+	if err := s.SampleOnce(context.Background(), ingest, now.Add(-time.Minute), now); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if len(repo.rounds("ingest")) != 0 {
+		t.Error("Expected no samples to be saved")
+	}
+}
+
+func TestSampler_Start_ContinuesEventWindows(t *testing.T) {
+	repo := &MockRepository{}
+	paths := &MockPathsRepository{}
+	p := ingest
+	p.Interval = 20 * time.Millisecond
+	s := NewSampler(repo, paths, []PipelineConfig{p})
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(repo.rounds("ingest")) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Stop()
+
+	if len(repo.retained) != 1 || repo.retained[0] != "ingest" {
+		t.Errorf("Expected ingest to be retained, got %v", repo.retained)
+	}
+	if len(repo.rounds("ingest")) < 3 {
+		t.Fatal("Expected at least 3 sampling rounds")
+	}
+
+	// Each round counts events from where the previous one stopped
+	paths.mu.Lock()
+	stages := len(p.Stages)
+	for i := stages; i+stages <= len(paths.windows); i += stages {
+		if !paths.windows[i].since.Equal(paths.windows[i-stages].until) {
+			t.Errorf("Round %d starts at %v, previous ended at %v", i/stages, paths.windows[i].since, paths.windows[i-stages].until)
+		}
+	}
+	paths.mu.Unlock()
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Errorf("Expected restart after Stop to succeed, got %v", err)
+	}
+	s.Stop()
+}
@@ -0,0 +1,183 @@
+package process
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// containerPrefixes are the prefixes container runtimes give the cgroups
+// of their containers under the systemd cgroup driver
+var containerPrefixes = []string{"docker-", "cri-containerd-", "crio-", "libpod-"}
+
+// parseCgroup returns a process's cgroup from /proc/[pid]/cgroup: the
+// unified (v2) hierarchy when there is one, otherwise the v1 systemd or
+// memory hierarchy
+func parseCgroup(data string) string {
+	var v1 string
+	for _, line := range strings.Split(data, "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			return parts[2]
+		case parts[1] == "name=systemd":
+			v1 = parts[2]
+		case v1 == "" && strings.Contains(","+parts[1]+",", ",memory,"):
+			v1 = parts[2]
+		}
+	}
+	return v1
+}
+
+// classifyCgroup finds the container or systemd unit a cgroup belongs to.
+// It returns the kind, the name and the cgroup of the container or unit
+// itself, which processes in nested cgroups share; kind is empty for
+// processes in neither, such as kernel threads.
+func classifyCgroup(cgroup string) (kind, name, groupPath string) {
+	segments := strings.Split(strings.Trim(cgroup, "/"), "/")
+
+	// A container is the innermost one, so that a container inside a
+	// kubepods or docker.service cgroup is not attributed to the unit
+	for i := len(segments) - 1; i >= 0; i-- {
+		if id, ok := containerID(segments[i]); ok {
+			return models.ProcessGroupContainer, id[:12], "/" + strings.Join(segments[:i+1], "/")
+		}
+	}
+	for _, suffix := range []string{".service", ".scope"} {
+		for i := len(segments) - 1; i >= 0; i-- {
+			if strings.HasSuffix(segments[i], suffix) {
+				return models.ProcessGroupUnit, segments[i], "/" + strings.Join(segments[:i+1], "/")
+			}
+		}
+	}
+	return "", "", ""
+}
+
+// containerID extracts the 64-character container ID from a cgroup path
+// segment such as "docker-<id>.scope" or plain "<id>"
+func containerID(segment string) (string, bool) {
+	id := strings.TrimSuffix(segment, ".scope")
+	for _, prefix := range containerPrefixes {
+		if trimmed, ok := strings.CutPrefix(id, prefix); ok {
+			id = trimmed
+			break
+		}
+	}
+	if len(id) != 64 {
+		return "", false
+	}
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+// cgroupReader groups processes by unit and container and reads the cgroup
+// v2 memory and CPU accounting of each group
+type cgroupReader struct {
+	root string // cgroup v2 mount, empty where there is none
+
+	mu     sync.Mutex
+	prev   map[string]uint64 // cpu.stat usage_usec per group cgroup at the previous read
+	prevAt time.Time
+}
+
+// newCgroupReader creates a reader for the cgroup hierarchy mounted at root
+func newCgroupReader(root string) *cgroupReader {
+	return &cgroupReader{
+		root: root,
+		prev: make(map[string]uint64),
+	}
+}
+
+// groups sums procs per unit and container, busiest first. The cgroup CPU%
+// covers the time since the previous call and is 0 for a group seen for
+// the first time; on cgroup v1 hosts the cgroup figures stay 0.
+func (r *cgroupReader) groups(procs []*models.ProcessInfo, now time.Time) []*models.ProcessGroup {
+	byPath := make(map[string]*models.ProcessGroup)
+	for _, proc := range procs {
+		kind, name, path := classifyCgroup(proc.Cgroup)
+		if kind == "" {
+			continue
+		}
+		g, ok := byPath[path]
+		if !ok {
+			g = &models.ProcessGroup{Kind: kind, Name: name, Cgroup: path, CollectedAt: now}
+			byPath[path] = g
+		}
+		g.Processes++
+		g.CPUPercent += proc.CPUPercent
+		g.MemRSS += proc.MemRSS
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interval := now.Sub(r.prevAt).Seconds()
+	usage := make(map[string]uint64, len(byPath))
+	groups := make([]*models.ProcessGroup, 0, len(byPath))
+	for path, g := range byPath {
+		if r.root != "" {
+			dir := filepath.Join(r.root, path)
+			g.MemoryCurrent = readCgroupInt(filepath.Join(dir, "memory.current"))
+			if usec, ok := readCPUUsage(filepath.Join(dir, "cpu.stat")); ok {
+				usage[path] = usec
+				if prev, ok := r.prev[path]; ok && interval > 0 && usec >= prev {
+					g.CgroupCPUPercent = float64(usec-prev) / 1e6 / interval * 100
+				}
+			}
+		}
+		groups = append(groups, g)
+	}
+	r.prev = usage
+	r.prevAt = now
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].CPUPercent != groups[j].CPUPercent {
+			return groups[i].CPUPercent > groups[j].CPUPercent
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// readCgroupInt reads a single-value cgroup file such as memory.current,
+// treating a missing or unparseable file as 0
+func readCgroupInt(path string) int64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	return parseInt(strings.TrimSpace(string(data)))
+}
+
+// readCPUUsage reads usage_usec from a cgroup v2 cpu.stat file
+func readCPUUsage(path string) (uint64, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "usage_usec "); ok {
+			usec, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+			return usec, err == nil
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,131 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+const testContainerID = "4f1c2d3e4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+
+func TestParseCgroup(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unified", "0::/system.slice/airflow-scheduler.service\n", "/system.slice/airflow-scheduler.service"},
+		{
+			"hybrid prefers unified",
+			"12:memory:/system.slice/nifi.service\n1:name=systemd:/system.slice/nifi.service\n0::/system.slice/nifi.service\n",
+			"/system.slice/nifi.service",
+		},
+		{"v1 systemd", "4:cpu,cpuacct:/\n1:name=systemd:/docker/" + testContainerID + "\n", "/docker/" + testContainerID},
+		{"v1 memory", "9:memory:/kubepods/burstable/pod1/" + testContainerID + "\n", "/kubepods/burstable/pod1/" + testContainerID},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		if got := parseCgroup(tt.data); got != tt.want {
+			t.Errorf("%s: parseCgroup = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyCgroup(t *testing.T) {
+	short := testContainerID[:12]
+	tests := []struct {
+		cgroup   string
+		kind     string
+		name     string
+		groupDir string
+	}{
+		{"/system.slice/airflow-scheduler.service", models.ProcessGroupUnit, "airflow-scheduler.service", "/system.slice/airflow-scheduler.service"},
+		{"/system.slice/nifi.service/worker", models.ProcessGroupUnit, "nifi.service", "/system.slice/nifi.service"},
+		{"/user.slice/user-1000.slice/user@1000.service/app.slice/etl.service", models.ProcessGroupUnit, "etl.service", "/user.slice/user-1000.slice/user@1000.service/app.slice/etl.service"},
+		{"/user.slice/user-1000.slice/session-3.scope", models.ProcessGroupUnit, "session-3.scope", "/user.slice/user-1000.slice/session-3.scope"},
+		{"/system.slice/docker-" + testContainerID + ".scope", models.ProcessGroupContainer, short, "/system.slice/docker-" + testContainerID + ".scope"},
+		{"/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + testContainerID + ".scope", models.ProcessGroupContainer, short, "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + testContainerID + ".scope"},
+		{"/docker/" + testContainerID, models.ProcessGroupContainer, short, "/docker/" + testContainerID},
+		{"/machine.slice/libpod-" + testContainerID + ".scope/container", models.ProcessGroupContainer, short, "/machine.slice/libpod-" + testContainerID + ".scope"},
+		{"/", "", "", ""},
+		{"", "", "", ""},
+	}
+	for _, tt := range tests {
+		kind, name, groupDir := classifyCgroup(tt.cgroup)
+		if kind != tt.kind || name != tt.name || groupDir != tt.groupDir {
+			t.Errorf("classifyCgroup(%q) = %q, %q, %q; want %q, %q, %q", tt.cgroup, kind, name, groupDir, tt.kind, tt.name, tt.groupDir)
+		}
+	}
+}
+
+// writeCgroup writes the accounting files of a fake cgroup v2 group
+func writeCgroup(t *testing.T, root, cgroup string, memory int64, usageUsec string) {
+	t.Helper()
+	dir := filepath.Join(root, cgroup)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"memory.current": strconv.FormatInt(memory, 10) + "\n",
+		"cpu.stat":       "usage_usec " + usageUsec + "\nuser_usec 1\nsystem_usec 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCgroupReader_Groups(t *testing.T) {
+	root := t.TempDir()
+	nifi := "/system.slice/nifi.service"
+	container := "/system.slice/docker-" + testContainerID + ".scope"
+	writeCgroup(t, root, nifi, 3<<30, "1000000")
+	writeCgroup(t, root, container, 512<<20, "500000")
+
+	procs := []*models.ProcessInfo{
+		{PID: 10, Cgroup: nifi, CPUPercent: 30, MemRSS: 1 << 30},
+		{PID: 11, Cgroup: nifi + "/bootstrap", CPUPercent: 5, MemRSS: 100 << 20},
+		{PID: 20, Cgroup: container, CPUPercent: 40, MemRSS: 200 << 20},
+		{PID: 2, Cgroup: "/"}, // kernel thread
+	}
+	r := newCgroupReader(root)
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	groups := r.groups(procs, start)
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %+v", groups)
+	}
+	c, n := groups[0], groups[1]
+	if c.Kind != models.ProcessGroupContainer || c.Name != testContainerID[:12] || c.Processes != 1 {
+		t.Errorf("Expected the busier container first, got %+v", c)
+	}
+	if n.Kind != models.ProcessGroupUnit || n.Name != "nifi.service" || n.Processes != 2 || n.CPUPercent != 35 || n.MemRSS != 1<<30+100<<20 {
+		t.Errorf("Unexpected nifi group: %+v", n)
+	}
+	if n.MemoryCurrent != 3<<30 || n.CgroupCPUPercent != 0 {
+		t.Errorf("Expected memory.current without CPU%% on the first read, got %d and %.1f", n.MemoryCurrent, n.CgroupCPUPercent)
+	}
+
+	// 2.5 CPU seconds over 10s
+	writeCgroup(t, root, nifi, 3<<30, "3500000")
+	groups = r.groups(procs, start.Add(10*time.Second))
+	if got := groups[1].CgroupCPUPercent; got != 25 {
+		t.Errorf("Expected cgroup CPU of 25%%, got %.1f", got)
+	}
+}
+
+func TestCgroupReader_Groups_NoCgroupFS(t *testing.T) {
+	procs := []*models.ProcessInfo{{PID: 10, Cgroup: "/system.slice/nifi.service", CPUPercent: 30}}
+
+	groups := newCgroupReader("").groups(procs, time.Now())
+
+	if len(groups) != 1 || groups[0].MemoryCurrent != 0 || groups[0].CPUPercent != 30 {
+		t.Errorf("Expected process totals only, got %+v", groups)
+	}
+}
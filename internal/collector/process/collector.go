@@ -1,13 +1,10 @@
 package process
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os/exec"
-	"path/filepath"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,12 +17,31 @@ type ProcessRepository interface {
 	SaveProcessInfo(ctx context.Context, info *models.ProcessInfo) error
 	GetLatestProcessInfo(ctx context.Context) ([]*models.ProcessInfo, error)
 	ClearAll(ctx context.Context) error
+	SaveProcessWatches(ctx context.Context, watches []*models.ProcessWatch) error
+	SaveProcessEvents(ctx context.Context, events []*models.ProcessEvent) error
+	SaveProcessWatchSamples(ctx context.Context, samples []*models.ProcessWatchSample, retention time.Duration) error
+	SaveProcessTree(ctx context.Context, procs []*models.ProcessInfo) error
+	SaveProcessGroups(ctx context.Context, groups []*models.ProcessGroup) error
 }
 
 // Config holds process monitoring configuration
 type Config struct {
-	Patterns []string // process name patterns to monitor (empty = top N by CPU)
-	TopN     int      // max processes to keep (default: 50)
+	Patterns         []string      // process name patterns to monitor (empty = top N by CPU)
+	TopN             int           // max processes to keep (default: 50)
+	HistoryRetention time.Duration // how long watch samples are kept (default: 7 days)
+	Watches          []WatchConfig
+}
+
+// processSource lists the running processes; it is /proc on Linux and
+// ps elsewhere
+type processSource interface {
+	read(now time.Time) ([]*models.ProcessInfo, error)
+}
+
+// fdCounter is implemented by sources that can count a process's open
+// file descriptors
+type fdCounter interface {
+	openFDs(pid int) (int, bool)
 }
 
 // Collector collects process statistics
@@ -33,21 +49,35 @@ type Collector struct {
 	repo     ProcessRepository
 	interval time.Duration
 	config   Config
-	compiled []*regexp.Regexp // pre-compiled pattern regexes
+	compiled []processPattern // pre-compiled patterns
+	source   processSource
+	tracker  *watchTracker
+	cgroups  *cgroupReader
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
 	mu       sync.Mutex
 }
 
+// cmdlinePrefix marks a pattern matched against the command line instead
+// of the process name
+const cmdlinePrefix = "cmdline:"
+
+// processPattern is a compiled process filter pattern
+type processPattern struct {
+	re      *regexp.Regexp
+	cmdline bool // match the command line rather than the name
+}
+
 // compilePatterns converts glob patterns to compiled regexes
-func compilePatterns(patterns []string) []*regexp.Regexp {
-	var compiled []*regexp.Regexp
+func compilePatterns(patterns []string) []processPattern {
+	var compiled []processPattern
 	for _, pattern := range patterns {
-		re, err := globToRegex(pattern)
+		cmdline := strings.HasPrefix(pattern, cmdlinePrefix)
+		re, err := globToRegex(strings.TrimPrefix(pattern, cmdlinePrefix))
 		if err != nil {
 			continue // skip invalid patterns
 		}
-		compiled = append(compiled, re)
+		compiled = append(compiled, processPattern{re: re, cmdline: cmdline})
 	}
 	return compiled
 }
@@ -57,11 +87,17 @@ func NewCollector(repo ProcessRepository, interval time.Duration, cfg Config) *C
 	if cfg.TopN <= 0 {
 		cfg.TopN = 50
 	}
+	if cfg.HistoryRetention <= 0 {
+		cfg.HistoryRetention = 7 * 24 * time.Hour
+	}
 	return &Collector{
 		repo:     repo,
 		interval: interval,
 		config:   cfg,
 		compiled: compilePatterns(cfg.Patterns),
+		source:   newProcessSource(),
+		tracker:  newWatchTracker(),
+		cgroups:  newCgroupReader(cgroupRoot),
 	}
 }
 
@@ -113,19 +149,56 @@ func (c *Collector) collectLoop(ctx context.Context) {
 
 // CollectOnce performs a single collection of process info
 func (c *Collector) CollectOnce(ctx context.Context) error {
-	procs, err := c.getProcesses()
+	now := time.Now()
+	all, err := c.source.read(now)
 	if err != nil {
 		return fmt.Errorf("failed to get processes: %w", err)
 	}
 
+	// Busiest first, so that TopN keeps them
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].CPUPercent > all[j].CPUPercent
+	})
+
 	// Filter by patterns if configured
+	procs := all
 	if len(c.compiled) > 0 {
 		procs = c.filterByPatterns(procs)
 	}
 
-	// Limit to TopN
+	// Limit to TopN; capped so that appending watched processes cannot
+	// overwrite the rest of all
 	if len(procs) > c.config.TopN {
-		procs = procs[:c.config.TopN]
+		procs = procs[:c.config.TopN:c.config.TopN]
+	}
+
+	// Watches see every process, and their matches are always kept
+	watches := make([]*models.ProcessWatch, len(c.config.Watches))
+	samples := make([]*models.ProcessWatchSample, len(c.config.Watches))
+	counter, _ := c.source.(fdCounter)
+	var events []*models.ProcessEvent
+	kept := make(map[int]bool, len(procs))
+	for _, proc := range procs {
+		kept[proc.PID] = true
+	}
+	for i, w := range c.config.Watches {
+		var matched []*models.ProcessInfo
+		watches[i], matched = w.evaluate(all, now)
+		events = append(events, c.tracker.track(w.Name, matched, now)...)
+		samples[i] = sample(w.Name, matched, now)
+		if counter != nil {
+			for _, proc := range matched {
+				if n, ok := counter.openFDs(proc.PID); ok {
+					samples[i].OpenFDs += n
+				}
+			}
+		}
+		for _, proc := range matched {
+			if !kept[proc.PID] {
+				kept[proc.PID] = true
+				procs = append(procs, proc)
+			}
+		}
 	}
 
 	// Clear old entries before saving new ones
@@ -139,83 +212,26 @@ func (c *Collector) CollectOnce(ctx context.Context) error {
 		}
 	}
 
-	return nil
-}
-
-// getProcesses runs ps command and parses output
-func (c *Collector) getProcesses() ([]*models.ProcessInfo, error) {
-	// ps aux output is sorted by default (varies by OS)
-	// Using ps with custom format for consistent cross-platform parsing
-	out, err := exec.Command("ps", "-eo", "pid,user,pcpu,rss,state,etime,comm").Output()
-	if err != nil {
-		return nil, fmt.Errorf("ps command failed: %w", err)
-	}
-
-	var procs []*models.ProcessInfo
-	scanner := bufio.NewScanner(strings.NewReader(string(out)))
-
-	// Skip header line
-	if scanner.Scan() {
-		// header consumed
-	}
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		proc, err := parsePsLine(line)
-		if err != nil {
-			continue // skip unparseable lines
-		}
-		procs = append(procs, proc)
+	if err := c.repo.SaveProcessWatches(ctx, watches); err != nil {
+		return fmt.Errorf("failed to save process watches: %w", err)
 	}
-
-	return procs, nil
-}
-
-// parsePsLine parses a single line from ps -eo output
-func parsePsLine(line string) (*models.ProcessInfo, error) {
-	// Fields: PID USER %CPU RSS STATE ELAPSED COMMAND
-	// The command may contain spaces, so we split carefully
-	fields := strings.Fields(line)
-	if len(fields) < 7 {
-		return nil, fmt.Errorf("insufficient fields: %s", line)
+	if err := c.repo.SaveProcessEvents(ctx, events); err != nil {
+		return fmt.Errorf("failed to save process events: %w", err)
 	}
-
-	pid, err := strconv.Atoi(fields[0])
-	if err != nil {
-		return nil, fmt.Errorf("invalid PID: %s", fields[0])
+	if err := c.repo.SaveProcessWatchSamples(ctx, samples, c.config.HistoryRetention); err != nil {
+		return fmt.Errorf("failed to save process watch samples: %w", err)
 	}
 
-	cpu, err := strconv.ParseFloat(fields[2], 64)
-	if err != nil {
-		cpu = 0
+	// The tree needs every process, not only the kept ones, to link
+	// children to their parents
+	if err := c.repo.SaveProcessTree(ctx, all); err != nil {
+		return fmt.Errorf("failed to save process tree: %w", err)
 	}
-
-	rss, err := strconv.ParseInt(fields[3], 10, 64)
-	if err != nil {
-		rss = 0
+	if err := c.repo.SaveProcessGroups(ctx, c.cgroups.groups(all, now)); err != nil {
+		return fmt.Errorf("failed to save process groups: %w", err)
 	}
 
-	// Convert state code to human-readable
-	status := parseState(fields[4])
-
-	// Extract basename from command path (fields[6] is the comm field)
-	// On macOS, comm can include path prefixes like ./ or full paths
-	commandName := filepath.Base(fields[6])
-
-	return &models.ProcessInfo{
-		PID:         pid,
-		User:        fields[1],
-		CPUPercent:  cpu,
-		MemRSS:      rss * 1024, // ps reports RSS in KB, convert to bytes
-		Status:      status,
-		Elapsed:     fields[5],
-		Name:        commandName,
-		CollectedAt: time.Now(),
-	}, nil
+	return nil
 }
 
 // parseState converts ps state codes to human-readable status
@@ -275,14 +291,19 @@ func globToRegex(pattern string) (*regexp.Regexp, error) {
 	return regexp.Compile(result.String())
 }
 
-// filterByPatterns filters processes by pre-compiled glob patterns
+// filterByPatterns filters processes by pre-compiled glob patterns, matched
+// against the process name, or the command line with a cmdline: prefix
 // Supports glob-style patterns: *, ?
-// Examples: java, *nifi*, java*, *daemon
+// Examples: java, java*, *daemon, cmdline:*nifi*
 func (c *Collector) filterByPatterns(procs []*models.ProcessInfo) []*models.ProcessInfo {
 	var filtered []*models.ProcessInfo
 	for _, proc := range procs {
-		for _, re := range c.compiled {
-			if re.MatchString(proc.Name) {
+		for _, p := range c.compiled {
+			target := proc.Name
+			if p.cmdline {
+				target = proc.Cmdline
+			}
+			if target != "" && p.re.MatchString(target) {
 				filtered = append(filtered, proc)
 				break
 			}
@@ -0,0 +1,251 @@
+package process
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// MockRepository is a mock implementation of the ProcessRepository for testing
+type MockRepository struct {
+	mu      sync.Mutex
+	saved   []*models.ProcessInfo
+	watches []*models.ProcessWatch
+	events  []*models.ProcessEvent
+	samples []*models.ProcessWatchSample
+	tree    []*models.ProcessInfo
+	groups  []*models.ProcessGroup
+}
+
+func (m *MockRepository) SaveProcessInfo(ctx context.Context, info *models.ProcessInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = append(m.saved, info)
+	return nil
+}
+
+func (m *MockRepository) GetLatestProcessInfo(ctx context.Context) ([]*models.ProcessInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saved, nil
+}
+
+func (m *MockRepository) ClearAll(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = nil
+	return nil
+}
+
+func (m *MockRepository) SaveProcessWatches(ctx context.Context, watches []*models.ProcessWatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watches = watches
+	return nil
+}
+
+func (m *MockRepository) SaveProcessEvents(ctx context.Context, events []*models.ProcessEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *MockRepository) SaveProcessWatchSamples(ctx context.Context, samples []*models.ProcessWatchSample, retention time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = append(m.samples, samples...)
+	return nil
+}
+
+func (m *MockRepository) SaveProcessTree(ctx context.Context, procs []*models.ProcessInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tree = procs
+	return nil
+}
+
+func (m *MockRepository) SaveProcessGroups(ctx context.Context, groups []*models.ProcessGroup) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.groups = groups
+	return nil
+}
+
+// staticSource serves a fixed process list
+type staticSource []*models.ProcessInfo
+
+func (s staticSource) read(now time.Time) ([]*models.ProcessInfo, error) {
+	return append([]*models.ProcessInfo(nil), s...), nil
+}
+
+func TestCollector_CollectOnce_KeepsBusiestProcesses(t *testing.T) {
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{TopN: 2})
+	c.source = staticSource{
+		{PID: 1, Name: "init", CPUPercent: 0.1},
+		{PID: 2, Name: "java", CPUPercent: 90},
+		{PID: 3, Name: "python3", CPUPercent: 40},
+	}
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	if len(repo.saved) != 2 || repo.saved[0].PID != 2 || repo.saved[1].PID != 3 {
+		t.Errorf("Expected the two busiest processes, got %+v", repo.saved)
+	}
+	if len(repo.tree) != 3 {
+		t.Errorf("Expected every process in the tree, got %d", len(repo.tree))
+	}
+}
+
+func TestCollector_CollectOnce_PatternsMatchName(t *testing.T) {
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{Patterns: []string{"*nifi*"}})
+	c.source = staticSource{
+		{PID: 10, Name: "java", Cmdline: "java -cp /opt/nifi/lib/* org.apache.nifi.NiFi"},
+		{PID: 12, Name: "nifi.sh"},
+	}
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	// Without the cmdline: prefix, only the name is matched
+	if len(repo.saved) != 1 || repo.saved[0].PID != 12 {
+		t.Errorf("Expected only nifi.sh to match, got %+v", repo.saved)
+	}
+}
+
+func TestCollector_CollectOnce_PatternsMatchCmdline(t *testing.T) {
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{Patterns: []string{"cmdline:*nifi*"}})
+	c.source = staticSource{
+		{PID: 10, Name: "java", Cmdline: "java -cp /opt/nifi/lib/* org.apache.nifi.NiFi"},
+		{PID: 11, Name: "java", Cmdline: "java -jar /opt/kafka/connect.jar"},
+		{PID: 12, Name: "nifi.sh"},
+	}
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	if len(repo.saved) != 1 || repo.saved[0].PID != 10 {
+		t.Errorf("Expected only the NiFi JVM to match, got %+v", repo.saved)
+	}
+}
+
+func TestCollector_CollectOnce_EvaluatesWatches(t *testing.T) {
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{
+		TopN: 1,
+		Watches: []WatchConfig{
+			{Name: "loaders", Match: regexp.MustCompile(`loader\.jar`), User: "etl", Min: 2},
+			{Name: "scheduler", Match: regexp.MustCompile(`scheduler\.py`), Min: 1},
+			{Name: "workers", Match: regexp.MustCompile(`^etl_worker`), Min: 1, Max: 2},
+			{Name: "cron", Match: regexp.MustCompile(`^cron`), Min: 1},
+		},
+	})
+	c.source = staticSource{
+		{PID: 1, Name: "cron", CPUPercent: 0.1},
+		{PID: 2, Name: "postgres", CPUPercent: 95},
+		{PID: 10, Name: "java", User: "etl", Cmdline: "java -jar /opt/etl/loader.jar"},
+		{PID: 11, Name: "java", User: "root", Cmdline: "java -jar /opt/etl/loader.jar"},
+		{PID: 20, Name: "etl_worker", Cmdline: "etl_worker --queue a"},
+		{PID: 21, Name: "etl_worker", Cmdline: "etl_worker --queue b"},
+		{PID: 22, Name: "etl_worker", Cmdline: "etl_worker --queue c"},
+	}
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	if len(repo.watches) != 4 {
+		t.Fatalf("Expected 4 watches, got %d", len(repo.watches))
+	}
+	loaders, scheduler, workers, cron := repo.watches[0], repo.watches[1], repo.watches[2], repo.watches[3]
+	if loaders.Status != models.ProcessWatchDegraded || loaders.Count != 1 || loaders.PIDs[0] != 10 {
+		t.Errorf("Expected loaders DEGRADED with only the etl user's process, got %+v", loaders)
+	}
+	if scheduler.Status != models.ProcessWatchDown || scheduler.Count != 0 || scheduler.PIDs == nil {
+		t.Errorf("Expected scheduler DOWN with an empty PID list, got %+v", scheduler)
+	}
+	if workers.Status != models.ProcessWatchDegraded || workers.Reason != "3 running, expected at most 2" {
+		t.Errorf("Expected workers DEGRADED above max, got %+v", workers)
+	}
+	// A process without a command line is matched by name
+	if cron.Status != models.ProcessWatchUp || cron.Reason != "" {
+		t.Errorf("Expected cron UP, got %+v", cron)
+	}
+
+	// Watched processes are kept beyond TopN
+	if len(repo.saved) != 6 || repo.saved[0].PID != 2 {
+		t.Errorf("Expected the busiest process and the 5 watched ones, got %d", len(repo.saved))
+	}
+}
+
+func TestCollector_CollectOnce_RecordsWatchEvents(t *testing.T) {
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{
+		Watches: []WatchConfig{{Name: "workers", Match: regexp.MustCompile(`^etl_worker`), Min: 1}},
+	})
+	started := time.Now().Add(-time.Hour)
+	restarted := time.Now()
+	c.source = staticSource{{PID: 20, Name: "etl_worker", StartTime: &started}}
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	c.source = staticSource{{PID: 21, Name: "etl_worker", StartTime: &restarted}}
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	if len(repo.events) != 2 || repo.events[0].PID != 20 || repo.events[1].PID != 21 || !repo.events[1].Restart {
+		t.Errorf("Expected PID 20 to exit and be restarted as 21, got %+v", repo.events)
+	}
+	if repo.watches[0].Status != models.ProcessWatchUp {
+		t.Errorf("Expected the watch to be UP after the restart, got %s", repo.watches[0].Status)
+	}
+}
+
+func TestCollector_CollectOnce_SamplesWatches(t *testing.T) {
+	root := newFakeProcfs(t,
+		fakeProc{pid: 20, comm: "etl_worker", state: "S", ppid: 1, threads: 4, uid: "0", rssKB: 1024},
+		fakeProc{pid: 21, comm: "etl_worker", state: "S", ppid: 1, threads: 6, uid: "0", rssKB: 2048},
+		fakeProc{pid: 30, comm: "postgres", state: "S", ppid: 1, threads: 1, uid: "0", rssKB: 4096},
+	)
+	writeDetails(t, root, 20, map[int]string{0: "/dev/null", 1: "/dev/null"}, tcpHeader, tcpHeader, testLimits)
+	writeDetails(t, root, 21, map[int]string{0: "/dev/null", 1: "/dev/null", 2: "/dev/null"}, tcpHeader, tcpHeader, testLimits)
+
+	repo := &MockRepository{}
+	c := NewCollector(repo, time.Minute, Config{
+		Watches: []WatchConfig{
+			{Name: "workers", Match: regexp.MustCompile(`^etl_worker`), Min: 1},
+			{Name: "scheduler", Match: regexp.MustCompile(`scheduler\.py`), Min: 1},
+		},
+	})
+	c.source = newProcReader(root)
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+
+	if len(repo.samples) != 2 {
+		t.Fatalf("Expected a sample per watch, got %d", len(repo.samples))
+	}
+	workers, scheduler := repo.samples[0], repo.samples[1]
+	if workers.Watch != "workers" || workers.Count != 2 || workers.MemRSS != 3072*1024 ||
+		workers.Threads != 10 || workers.OpenFDs != 5 {
+		t.Errorf("Expected workers summed over both processes, got %+v", workers)
+	}
+	// A watch without processes is still sampled, so that gaps show as zero
+	if scheduler.Count != 0 || scheduler.MemRSS != 0 || scheduler.CollectedAt.IsZero() {
+		t.Errorf("Expected an empty scheduler sample, got %+v", scheduler)
+	}
+}
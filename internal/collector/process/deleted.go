@@ -0,0 +1,329 @@
+package process
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/etlmon/etlmon/internal/mountinfo"
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// maxDeletedFiles caps the files listed in a deleted files report; mounts
+// and processes always cover every file
+const maxDeletedFiles = 100
+
+// deletedSuffix is what the kernel appends to the link target of a
+// descriptor whose file was unlinked
+const deletedSuffix = " (deleted)"
+
+// deletedStatTimeout bounds the stat of a deleted file, which goes to the
+// file's filesystem and can hang on an unresponsive network mount
+var deletedStatTimeout = 2 * time.Second
+
+// statFile is os.Stat, replaceable in tests
+var statFile = os.Stat
+
+// errUnresponsive is returned by statDeleted when the file's filesystem
+// does not answer
+var errUnresponsive = errors.New("filesystem not responding")
+
+// fileKey identifies a file across the processes holding it
+type fileKey struct {
+	dev, ino uint64
+}
+
+// DeletedFiles scans every process's descriptors for deleted files that
+// are still open, whose space the filesystem cannot free until they are
+// closed. A file held by several processes is counted once per mount.
+// Processes the node may not inspect are counted in Unreadable, and files
+// on a filesystem that does not answer in Unresponsive; after the first
+// such file, the rest of that mount is skipped for the scan.
+func (i *Inspector) DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	if i.root == "" {
+		return nil, fmt.Errorf("deleted files: %w", errors.ErrUnsupported)
+	}
+
+	pids, err := listPIDs(i.root)
+	if err != nil {
+		return nil, err
+	}
+	mounts, err := readMountDevices(filepath.Join(i.root, "self", "mountinfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.DeletedFilesReport{
+		Mounts:      []models.DeletedFilesMount{},
+		Processes:   []models.DeletedFilesProcess{},
+		Files:       []models.DeletedFile{},
+		CollectedAt: time.Now(),
+	}
+	files := make(map[fileKey]*models.DeletedFile)
+	hung := make(map[string]bool) // mount points that did not answer
+	for _, pid := range pids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(i.root, strconv.Itoa(pid), "fd")
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrPermission) {
+			report.Unreadable++
+			continue
+		}
+		if err != nil {
+			continue // exited
+		}
+
+		proc := models.DeletedFilesProcess{PID: pid}
+		held := make(map[fileKey]bool)
+		for _, entry := range entries {
+			fdPath := filepath.Join(dir, entry.Name())
+			target, err := os.Readlink(fdPath)
+			if err != nil || !strings.HasSuffix(target, deletedSuffix) || strings.HasPrefix(target, "/memfd:") {
+				continue
+			}
+			// Stat follows the descriptor to the unlinked inode
+			mount := mountOf(target, mounts)
+			if mount != "" && hung[mount] {
+				report.Unresponsive++
+				continue
+			}
+			info, err := i.statDeleted(ctx, fdPath)
+			if errors.Is(err, errUnresponsive) {
+				if mount != "" {
+					hung[mount] = true
+				}
+				report.Unresponsive++
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			st, ok := info.Sys().(*syscall.Stat_t)
+			if !ok {
+				continue
+			}
+			key := fileKey{dev: uint64(st.Dev), ino: uint64(st.Ino)}
+			if held[key] {
+				continue // the same file open twice
+			}
+			held[key] = true
+
+			file, ok := files[key]
+			if !ok {
+				file = &models.DeletedFile{
+					Path:       strings.TrimSuffix(target, deletedSuffix),
+					Size:       info.Size(),
+					MountPoint: mounts[key.dev],
+				}
+				files[key] = file
+			}
+			file.PIDs = append(file.PIDs, pid)
+			proc.Bytes += info.Size()
+			proc.Files++
+		}
+		if proc.Files > 0 {
+			proc.Name = readProcessName(filepath.Join(i.root, strconv.Itoa(pid)))
+			report.Processes = append(report.Processes, proc)
+		}
+	}
+
+	report.TotalFiles = len(files)
+	byMount := make(map[string]*models.DeletedFilesMount)
+	for _, file := range files {
+		report.TotalBytes += file.Size
+		report.Files = append(report.Files, *file)
+		if file.MountPoint == "" {
+			continue
+		}
+		m, ok := byMount[file.MountPoint]
+		if !ok {
+			m = &models.DeletedFilesMount{MountPoint: file.MountPoint}
+			byMount[file.MountPoint] = m
+		}
+		m.Bytes += file.Size
+		m.Files++
+	}
+	for _, m := range byMount {
+		report.Mounts = append(report.Mounts, *m)
+	}
+
+	sort.Slice(report.Mounts, func(a, b int) bool {
+		if report.Mounts[a].Bytes != report.Mounts[b].Bytes {
+			return report.Mounts[a].Bytes > report.Mounts[b].Bytes
+		}
+		return report.Mounts[a].MountPoint < report.Mounts[b].MountPoint
+	})
+	sort.SliceStable(report.Processes, func(a, b int) bool {
+		return report.Processes[a].Bytes > report.Processes[b].Bytes
+	})
+	sort.Slice(report.Files, func(a, b int) bool {
+		if report.Files[a].Size != report.Files[b].Size {
+			return report.Files[a].Size > report.Files[b].Size
+		}
+		return report.Files[a].Path < report.Files[b].Path
+	})
+	if len(report.Files) > maxDeletedFiles {
+		report.Files = report.Files[:maxDeletedFiles]
+		report.Truncated = true
+	}
+	return report, nil
+}
+
+// statDeleted stats a descriptor's file within deletedStatTimeout. A stat
+// that times out keeps running; the descriptor is skipped until it
+// returns, so each hung file holds at most one goroutine.
+func (i *Inspector) statDeleted(ctx context.Context, fdPath string) (os.FileInfo, error) {
+	i.pendingMu.Lock()
+	if i.pending[fdPath] {
+		i.pendingMu.Unlock()
+		return nil, errUnresponsive
+	}
+	if i.pending == nil {
+		i.pending = make(map[string]bool)
+	}
+	i.pending[fdPath] = true
+	i.pendingMu.Unlock()
+
+	type result struct {
+		info os.FileInfo
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		info, err := statFile(fdPath)
+		i.pendingMu.Lock()
+		delete(i.pending, fdPath)
+		i.pendingMu.Unlock()
+		done <- result{info, err}
+	}()
+
+	timer := time.NewTimer(deletedStatTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.info, r.err
+	case <-timer.C:
+		return nil, errUnresponsive
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// mountOf returns the mount point holding path, by the longest matching
+// prefix, or "" when none matches
+func mountOf(path string, mounts map[uint64]string) string {
+	var best string
+	for _, m := range mounts {
+		if len(m) <= len(best) {
+			continue
+		}
+		if mountinfo.Contains(m, path) {
+			best = m
+		}
+	}
+	return best
+}
+
+// listPIDs returns the processes in a procfs tree, lowest PID first
+func listPIDs(root string) ([]int, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	var pids []int
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+// readProcessName returns the name of the process in a /proc/[pid]
+// directory, or "" when it has exited
+func readProcessName(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return ""
+	}
+	stat, err := parseStat(string(data))
+	if err != nil {
+		return ""
+	}
+	var argv []string
+	if data, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil {
+		argv = parseCmdline(data)
+	}
+	return processName(stat.comm, argv)
+}
+
+// readMountDevices maps device numbers to mount points from
+// /proc/self/mountinfo. The first mount of a filesystem's root wins, so
+// that bind mounts do not take over the filesystem they come from; a
+// filesystem only bind-mounted from a subdirectory, as container volumes
+// are, falls back to its first mount. The device numbers are read from the
+// file rather than by stat, which can hang on an unresponsive network mount.
+func readMountDevices(path string) (map[uint64]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	defer f.Close()
+
+	mounts := make(map[uint64]string)
+	subdirMounts := make(map[uint64]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// mount-ID parent-ID major:minor root mount-point options ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		majorStr, minorStr, ok := strings.Cut(fields[2], ":")
+		if !ok {
+			continue
+		}
+		major, err1 := strconv.ParseUint(majorStr, 10, 32)
+		minor, err2 := strconv.ParseUint(minorStr, 10, 32)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		dev := makeDev(major, minor)
+		byRoot := mounts
+		if fields[3] != "/" {
+			byRoot = subdirMounts
+		}
+		if _, ok := byRoot[dev]; !ok {
+			byRoot[dev] = mountinfo.Unescape(fields[4])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+
+	for dev, mountPoint := range subdirMounts {
+		if _, ok := mounts[dev]; !ok {
+			mounts[dev] = mountPoint
+		}
+	}
+	return mounts, nil
+}
+
+// makeDev encodes a device number the way Linux reports it in st_dev
+func makeDev(major, minor uint64) uint64 {
+	return (minor & 0xff) | (major&0xfff)<<8 | (minor&^0xff)<<12 | (major&^0xfff)<<32
+}
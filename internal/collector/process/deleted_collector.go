@@ -0,0 +1,107 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// DeletedFilesCollector scans for deleted files held open on an interval
+// and keeps the last report, so that API requests never wait on a scan
+type DeletedFilesCollector struct {
+	inspector *Inspector
+	interval  time.Duration
+	report    *models.DeletedFilesReport // last successful scan
+	err       error                      // error of the last scan
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+}
+
+// NewDeletedFilesCollector creates a collector scanning with inspector
+func NewDeletedFilesCollector(inspector *Inspector, interval time.Duration) *DeletedFilesCollector {
+	return &DeletedFilesCollector{
+		inspector: inspector,
+		interval:  interval,
+		err:       models.ErrDeletedFilesPending,
+	}
+}
+
+// Start begins periodic scanning
+func (c *DeletedFilesCollector) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("collector already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.collectLoop(ctx)
+	}()
+
+	return nil
+}
+
+// Stop stops scanning
+func (c *DeletedFilesCollector) Stop() {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+	c.mu.Unlock()
+
+	c.wg.Wait()
+}
+
+// CollectOnce performs a single scan and stores its report
+func (c *DeletedFilesCollector) CollectOnce(ctx context.Context) error {
+	report, err := c.inspector.DeletedFiles(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err() // stopped; keep the last result
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	if err == nil {
+		c.report = report
+	}
+	return err
+}
+
+// DeletedFiles returns the last scan's report, or its error
+func (c *DeletedFilesCollector) DeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.report, nil
+}
+
+// collectLoop runs the periodic scan
+func (c *DeletedFilesCollector) collectLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	// Scan immediately on start
+	_ = c.CollectOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.CollectOnce(ctx)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// writeMountinfo mounts dir's filesystem at mountPoint in a fake procfs
+func writeMountinfo(t *testing.T, root, dir, mountPoint string) {
+	t.Helper()
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := uint64(info.Sys().(*syscall.Stat_t).Dev)
+	major := (dev>>8)&0xfff | (dev>>32)&^0xfff
+	minor := dev&0xff | (dev>>12)&^0xff
+	mountinfo := "22 1 0:21 / /proc rw,nosuid - proc proc rw\n" +
+		// A bind mount of a subdirectory does not take over the filesystem
+		fmt.Sprintf("30 1 %d:%d /sub /bind rw - ext4 /dev/sdb1 rw\n", major, minor) +
+		fmt.Sprintf("31 1 %d:%d / %s rw - ext4 /dev/sdb1 rw\n", major, minor, strings.ReplaceAll(mountPoint, " ", `\040`))
+	if err := os.MkdirAll(filepath.Join(root, "self"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "self", "mountinfo"), []byte(mountinfo), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeDeletedFile creates a file named as the kernel shows a deleted one
+func writeDeletedFile(t *testing.T, dir, name string, size int) string {
+	t.Helper()
+	path := filepath.Join(dir, name+deletedSuffix)
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestInspector_DeletedFiles(t *testing.T) {
+	root := newFakeProcfs(t,
+		fakeProc{pid: 100, comm: "java", state: "S", ppid: 1, uid: "0"},
+		fakeProc{pid: 101, comm: "java", state: "S", ppid: 100, uid: "0"},
+		fakeProc{pid: 200, comm: "nginx", state: "S", ppid: 1, uid: "0"},
+	)
+	data := t.TempDir()
+	writeMountinfo(t, root, data, "/data disk")
+	bigLog := writeDeletedFile(t, data, "app.log", 4000)
+	smallLog := writeDeletedFile(t, data, "gc.log", 1000)
+	live := filepath.Join(data, "live.log")
+	if err := os.WriteFile(live, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A parent and its forked child share a deleted log; the parent has it
+	// open twice
+	writeDetails(t, root, 100, map[int]string{
+		1: bigLog, 2: bigLog, 3: smallLog, 4: live, 5: "/memfd:jit (deleted)",
+	}, tcpHeader, tcpHeader, testLimits)
+	writeDetails(t, root, 101, map[int]string{1: bigLog}, tcpHeader, tcpHeader, testLimits)
+	writeDetails(t, root, 200, map[int]string{1: live}, tcpHeader, tcpHeader, testLimits)
+
+	report, err := (&Inspector{root: root}).DeletedFiles(context.Background())
+	if err != nil {
+		t.Fatalf("DeletedFiles failed: %v", err)
+	}
+
+	if report.TotalBytes != 5000 || report.TotalFiles != 2 {
+		t.Errorf("expected 2 files of 5000 bytes, counting the shared file once, got %d of %d", report.TotalFiles, report.TotalBytes)
+	}
+	if len(report.Mounts) != 1 || report.Mounts[0].MountPoint != "/data disk" ||
+		report.Mounts[0].Bytes != 5000 || report.Mounts[0].Files != 2 {
+		t.Errorf("expected 2 files on /data disk, got %+v", report.Mounts)
+	}
+	if len(report.Processes) != 2 {
+		t.Fatalf("expected the two java processes, got %+v", report.Processes)
+	}
+	if p := report.Processes[0]; p.PID != 100 || p.Name != "java" || p.Bytes != 5000 || p.Files != 2 {
+		t.Errorf("unexpected parent: %+v", p)
+	}
+	if p := report.Processes[1]; p.PID != 101 || p.Bytes != 4000 || p.Files != 1 {
+		t.Errorf("unexpected child: %+v", p)
+	}
+	if len(report.Files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", report.Files)
+	}
+	if f := report.Files[0]; f.Path != filepath.Join(data, "app.log") || f.Size != 4000 ||
+		f.MountPoint != "/data disk" || len(f.PIDs) != 2 || f.PIDs[0] != 100 || f.PIDs[1] != 101 {
+		t.Errorf("unexpected largest file: %+v", f)
+	}
+}
+
+func TestInspector_DeletedFiles_TruncatesFiles(t *testing.T) {
+	root := newFakeProcfs(t, fakeProc{pid: 10, comm: "loader", state: "S", uid: "0"})
+	data := t.TempDir()
+	writeMountinfo(t, root, data, "/data")
+	fds := make(map[int]string, maxDeletedFiles+5)
+	for fd := 0; fd < maxDeletedFiles+5; fd++ {
+		fds[fd] = writeDeletedFile(t, data, "part-"+strconv.Itoa(fd), fd)
+	}
+	writeDetails(t, root, 10, fds, tcpHeader, tcpHeader, testLimits)
+
+	report, err := (&Inspector{root: root}).DeletedFiles(context.Background())
+	if err != nil {
+		t.Fatalf("DeletedFiles failed: %v", err)
+	}
+	if len(report.Files) != maxDeletedFiles || !report.Truncated || report.TotalFiles != maxDeletedFiles+5 {
+		t.Errorf("expected %d of %d files and truncated, got %d (truncated=%v)",
+			maxDeletedFiles, maxDeletedFiles+5, len(report.Files), report.Truncated)
+	}
+	if report.Files[0].Size != maxDeletedFiles+4 {
+		t.Errorf("expected the largest file first, got %+v", report.Files[0])
+	}
+}
+
+func TestInspector_DeletedFiles_SkipsUnresponsiveMount(t *testing.T) {
+	root := newFakeProcfs(t,
+		fakeProc{pid: 10, comm: "loader", state: "S", uid: "0"},
+		fakeProc{pid: 11, comm: "loader", state: "S", uid: "0"},
+	)
+	data := t.TempDir()
+	writeMountinfo(t, root, data, data)
+	a := writeDeletedFile(t, data, "a.csv", 100)
+	b := writeDeletedFile(t, data, "b.csv", 200)
+	writeDetails(t, root, 10, map[int]string{1: a, 2: b}, tcpHeader, tcpHeader, testLimits)
+	writeDetails(t, root, 11, map[int]string{1: a}, tcpHeader, tcpHeader, testLimits)
+
+	// The filesystem stops answering, as a dead NFS server would
+	release := make(chan struct{})
+	defer close(release)
+	var calls atomic.Int32
+	statFile = func(name string) (os.FileInfo, error) {
+		calls.Add(1)
+		<-release
+		return os.Stat(name)
+	}
+	deletedStatTimeout = 50 * time.Millisecond
+	t.Cleanup(func() {
+		statFile = os.Stat
+		deletedStatTimeout = 2 * time.Second
+	})
+
+	inspector := &Inspector{root: root}
+	start := time.Now()
+	report, err := inspector.DeletedFiles(context.Background())
+	if err != nil {
+		t.Fatalf("DeletedFiles failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DeletedFiles took %s, want about one stat timeout", elapsed)
+	}
+	if report.Unresponsive != 3 || report.TotalFiles != 0 {
+		t.Errorf("expected 3 unresponsive files and none counted, got %+v", report)
+	}
+	// After the first timeout the rest of the mount is skipped
+	if n := calls.Load(); n != 1 {
+		t.Errorf("stat called %d times, want 1", n)
+	}
+
+	// The hung stat is not repeated by the next scan
+	if _, err := inspector.DeletedFiles(context.Background()); err != nil {
+		t.Fatalf("DeletedFiles failed: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("stat called %d times after a second scan, want 1", n)
+	}
+}
+
+func TestDeletedFilesCollector_CachesReport(t *testing.T) {
+	root := newFakeProcfs(t, fakeProc{pid: 10, comm: "loader", state: "S", uid: "0"})
+	data := t.TempDir()
+	writeMountinfo(t, root, data, "/data")
+	writeDetails(t, root, 10, map[int]string{1: writeDeletedFile(t, data, "a.csv", 100)}, tcpHeader, tcpHeader, testLimits)
+
+	c := NewDeletedFilesCollector(&Inspector{root: root}, time.Minute)
+	if _, err := c.DeletedFiles(context.Background()); !errors.Is(err, models.ErrDeletedFilesPending) {
+		t.Errorf("expected ErrDeletedFilesPending before the first scan, got %v", err)
+	}
+
+	if err := c.CollectOnce(context.Background()); err != nil {
+		t.Fatalf("CollectOnce failed: %v", err)
+	}
+	report, err := c.DeletedFiles(context.Background())
+	if err != nil || report.TotalBytes != 100 {
+		t.Fatalf("expected the cached report, got %+v, %v", report, err)
+	}
+
+	// Reading the cache does not scan again
+	if err := os.RemoveAll(filepath.Join(root, "10")); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := c.DeletedFiles(context.Background()); again != report {
+		t.Error("expected the same cached report")
+	}
+
+	// A failed scan is reported instead of the stale report
+	c.inspector = &Inspector{}
+	if err := c.CollectOnce(context.Background()); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if _, err := c.DeletedFiles(context.Background()); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected the scan error, got %v", err)
+	}
+}
+
+func TestInspector_DeletedFiles_Unsupported(t *testing.T) {
+	_, err := (&Inspector{}).DeletedFiles(context.Background())
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestReadMountDevices_FallsBackToSubdirMount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mountinfo")
+	mountinfo := "30 1 8:17 /sub /bind rw - ext4 /dev/sdb1 rw\n" +
+		"31 1 8:17 / /data rw - ext4 /dev/sdb1 rw\n" +
+		// A volume only mounted from a subdirectory, as in a container
+		"40 1 8:33 /volumes/pvc-1 /var/lib/landing rw - ext4 /dev/sdc1 rw\n" +
+		"41 1 8:33 /volumes/pvc-1/tmp /tmp/landing rw - ext4 /dev/sdc1 rw\n"
+	if err := os.WriteFile(path, []byte(mountinfo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mounts, err := readMountDevices(path)
+	if err != nil {
+		t.Fatalf("readMountDevices failed: %v", err)
+	}
+	if got := mounts[makeDev(8, 17)]; got != "/data" {
+		t.Errorf("expected the root mount /data, got %q", got)
+	}
+	if got := mounts[makeDev(8, 33)]; got != "/var/lib/landing" {
+		t.Errorf("expected the first subdirectory mount /var/lib/landing, got %q", got)
+	}
+}
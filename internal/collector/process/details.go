@@ -0,0 +1,278 @@
+package process
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// maxDetailFiles caps the files listed for one process; a process can hold
+// hundreds of thousands of descriptors
+const maxDetailFiles = 1000
+
+// tcpStates names the socket states of /proc/net/tcp
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+// Inspector reads the open files, sockets and limits of a single process
+// on demand
+type Inspector struct {
+	root      string          // procfs mount, empty where there is none
+	pending   map[string]bool // descriptors whose stat has not returned yet
+	pendingMu sync.Mutex
+}
+
+// Details returns what the process holds open right now. Reading another
+// user's descriptors needs the same privileges as ls -l /proc/[pid]/fd.
+func (i *Inspector) Details(ctx context.Context, pid int) (*models.ProcessDetails, error) {
+	if i.root == "" {
+		return nil, fmt.Errorf("process details: %w", errors.ErrUnsupported)
+	}
+	dir := filepath.Join(i.root, strconv.Itoa(pid))
+
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("process %d: %w", pid, models.ErrProcessNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process %d: %w", pid, err)
+	}
+	stat, err := parseStat(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var argv []string
+	if data, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil {
+		argv = parseCmdline(data)
+	}
+
+	details := &models.ProcessDetails{
+		PID:         pid,
+		Name:        processName(stat.comm, argv),
+		Cmdline:     strings.Join(argv, " "),
+		Files:       []models.ProcessFile{},
+		Sockets:     []models.ProcessSocket{},
+		ListenPorts: []int{},
+		CollectedAt: time.Now(),
+	}
+
+	if details.MaxFDs, details.MaxFDsHard, err = readOpenFilesLimit(filepath.Join(dir, "limits")); err != nil {
+		return nil, err
+	}
+
+	fds, err := readFDs(filepath.Join(dir, "fd"))
+	if err != nil {
+		return nil, err
+	}
+	details.OpenFDs = len(fds)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The process's own network namespace, which is the host's unless it
+	// runs in a container
+	sockets := make(map[string]models.ProcessSocket)
+	for _, proto := range []string{"tcp", "tcp6"} {
+		if err := readTCPTable(filepath.Join(dir, "net", proto), proto, sockets); err != nil {
+			return nil, err
+		}
+	}
+
+	listening := make(map[int]bool)
+	for _, fd := range fds {
+		if inode, ok := socketInode(fd.Path); ok {
+			if sock, ok := sockets[inode]; ok {
+				sock.FD = fd.FD
+				details.Sockets = append(details.Sockets, sock)
+				if sock.State == "LISTEN" {
+					listening[portOf(sock.LocalAddr)] = true
+				}
+				continue
+			}
+		}
+		if len(details.Files) == maxDetailFiles {
+			details.Truncated = true
+			continue
+		}
+		details.Files = append(details.Files, fd)
+	}
+	for port := range listening {
+		details.ListenPorts = append(details.ListenPorts, port)
+	}
+	sort.Ints(details.ListenPorts)
+
+	return details, nil
+}
+
+// readFDs lists the descriptors in /proc/[pid]/fd with their link targets,
+// by FD. Descriptors closed while being read are skipped.
+func readFDs(dir string) ([]models.ProcessFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", dir, models.ErrProcessNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open files: %w", err)
+	}
+
+	fds := make([]models.ProcessFile, 0, len(entries))
+	for _, entry := range entries {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		target, err := os.Readlink(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		fds = append(fds, models.ProcessFile{FD: fd, Path: target})
+	}
+	sort.Slice(fds, func(i, j int) bool { return fds[i].FD < fds[j].FD })
+	return fds, nil
+}
+
+// socketInode returns the inode of a "socket:[12345]" descriptor target
+func socketInode(target string) (string, bool) {
+	inode, ok := strings.CutPrefix(target, "socket:[")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSuffix(inode, "]"), true
+}
+
+// readTCPTable adds the sockets of a /proc/net/tcp or tcp6 table to
+// sockets, by inode. A missing table means the protocol is disabled.
+func readTCPTable(path, proto string, sockets map[string]models.ProcessSocket) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[9] == "0" {
+			continue
+		}
+		local, err := parseHexAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		sock := models.ProcessSocket{
+			Protocol:  proto,
+			State:     tcpStates[fields[3]],
+			LocalAddr: local,
+		}
+		if sock.State == "" {
+			sock.State = "UNKNOWN"
+		}
+		if sock.State != "LISTEN" {
+			sock.RemoteAddr, _ = parseHexAddr(fields[2])
+		}
+		sockets[fields[9]] = sock
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseHexAddr converts an address of /proc/net/tcp such as "0100007F:1F90"
+// to "127.0.0.1:8080". The address is stored as 32-bit words in host byte
+// order, little-endian on all architectures the collector targets.
+func parseHexAddr(s string) (string, error) {
+	hexIP, hexPort, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", fmt.Errorf("malformed address %q", s)
+	}
+	raw, err := hex.DecodeString(hexIP)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", fmt.Errorf("malformed address %q", s)
+	}
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("malformed port in %q", s)
+	}
+
+	ip := make(net.IP, len(raw))
+	for word := 0; word < len(raw); word += 4 {
+		for b := 0; b < 4; b++ {
+			ip[word+b] = raw[word+3-b]
+		}
+	}
+	return net.JoinHostPort(ip.String(), strconv.FormatUint(port, 10)), nil
+}
+
+// portOf returns the port of a host:port address
+func portOf(addr string) int {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return 0
+	}
+	return int(parseInt(port))
+}
+
+// readOpenFilesLimit reads the soft and hard "Max open files" limits from
+// /proc/[pid]/limits; 0 means unlimited
+func readOpenFilesLimit(path string) (soft, hard int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read limits: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Max open files            1024                 524288               files
+		rest, ok := strings.CutPrefix(scanner.Text(), "Max open files")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			return 0, 0, fmt.Errorf("malformed open files limit: %q", scanner.Text())
+		}
+		return parseLimit(fields[0]), parseLimit(fields[1]), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to read limits: %w", err)
+	}
+	return 0, 0, nil
+}
+
+// parseLimit parses a limits value, where "unlimited" is 0
+func parseLimit(s string) int64 {
+	if s == "unlimited" {
+		return 0
+	}
+	return parseInt(s)
+}
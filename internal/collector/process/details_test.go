@@ -0,0 +1,173 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// writeDetails adds open descriptors, TCP tables and limits to a fake process
+func writeDetails(t *testing.T, root string, pid int, fds map[int]string, tcp, tcp6, limits string) {
+	t.Helper()
+	dir := filepath.Join(root, strconv.Itoa(pid))
+	for _, sub := range []string{"fd", "net"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for fd, target := range fds {
+		if err := os.Symlink(target, filepath.Join(dir, "fd", strconv.Itoa(fd))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{"net/tcp": tcp, "net/tcp6": tcp6, "limits": limits}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const tcpHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+
+const testLimits = `Limit                     Soft Limit           Hard Limit           Units
+Max cpu time              unlimited            unlimited            seconds
+Max open files            1024                 524288               files
+Max locked memory         8388608              8388608              bytes
+`
+
+func TestInspector_Details(t *testing.T) {
+	root := newFakeProcfs(t, fakeProc{
+		pid: 4242, comm: "java", state: "S", ppid: 1, threads: 30, uid: "0",
+		cmdline: []string{"java", "-jar", "/opt/etl/loader.jar"},
+	})
+	tcp := tcpHeader +
+		// 0.0.0.0:8080 LISTEN
+		"   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 111 1 0000000000000000 100 0 0 10 0\n" +
+		// 10.0.0.5:51234 -> 10.0.0.9:5432 ESTABLISHED
+		"   1: 0500000A:C822 0900000A:1538 01 00000000:00000000 00:00000000 00000000  1000        0 222 1 0000000000000000 20 4 30 10 -1\n" +
+		// Another process's socket
+		"   2: 0100007F:0CEA 0100007F:C000 01 00000000:00000000 00:00000000 00000000  1000        0 999 1 0000000000000000 20 4 30 10 -1\n"
+	tcp6 := tcpHeader +
+		// [::1]:9090 LISTEN
+		"   0: 00000000000000000000000001000000:2382 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 333 1 0000000000000000 100 0 0 10 0\n"
+	writeDetails(t, root, 4242, map[int]string{
+		0:  "/dev/null",
+		3:  "/data/in/orders.csv",
+		4:  "socket:[111]",
+		5:  "socket:[222]",
+		6:  "socket:[333]",
+		7:  "socket:[444]", // a unix socket
+		12: "pipe:[555]",
+	}, tcp, tcp6, testLimits)
+
+	inspector := &Inspector{root: root}
+	details, err := inspector.Details(context.Background(), 4242)
+	if err != nil {
+		t.Fatalf("Details failed: %v", err)
+	}
+
+	if details.Name != "java" || details.Cmdline != "java -jar /opt/etl/loader.jar" {
+		t.Errorf("unexpected process: %q %q", details.Name, details.Cmdline)
+	}
+	if details.OpenFDs != 7 || details.MaxFDs != 1024 || details.MaxFDsHard != 524288 {
+		t.Errorf("expected 7 of 1024/524288 FDs, got %d of %d/%d", details.OpenFDs, details.MaxFDs, details.MaxFDsHard)
+	}
+
+	wantFiles := []models.ProcessFile{
+		{FD: 0, Path: "/dev/null"},
+		{FD: 3, Path: "/data/in/orders.csv"},
+		{FD: 7, Path: "socket:[444]"},
+		{FD: 12, Path: "pipe:[555]"},
+	}
+	if len(details.Files) != len(wantFiles) {
+		t.Fatalf("expected %d files, got %+v", len(wantFiles), details.Files)
+	}
+	for i, want := range wantFiles {
+		if details.Files[i] != want {
+			t.Errorf("file %d: expected %+v, got %+v", i, want, details.Files[i])
+		}
+	}
+
+	wantSockets := []models.ProcessSocket{
+		{FD: 4, Protocol: "tcp", State: "LISTEN", LocalAddr: "0.0.0.0:8080"},
+		{FD: 5, Protocol: "tcp", State: "ESTABLISHED", LocalAddr: "10.0.0.5:51234", RemoteAddr: "10.0.0.9:5432"},
+		{FD: 6, Protocol: "tcp6", State: "LISTEN", LocalAddr: "[::1]:9090"},
+	}
+	if len(details.Sockets) != len(wantSockets) {
+		t.Fatalf("expected %d sockets, got %+v", len(wantSockets), details.Sockets)
+	}
+	for i, want := range wantSockets {
+		if details.Sockets[i] != want {
+			t.Errorf("socket %d: expected %+v, got %+v", i, want, details.Sockets[i])
+		}
+	}
+
+	if len(details.ListenPorts) != 2 || details.ListenPorts[0] != 8080 || details.ListenPorts[1] != 9090 {
+		t.Errorf("expected listen ports [8080 9090], got %v", details.ListenPorts)
+	}
+}
+
+func TestInspector_Details_NotFound(t *testing.T) {
+	inspector := &Inspector{root: newFakeProcfs(t)}
+
+	_, err := inspector.Details(context.Background(), 4242)
+	if !errors.Is(err, models.ErrProcessNotFound) {
+		t.Errorf("expected ErrProcessNotFound, got %v", err)
+	}
+}
+
+func TestInspector_Details_Unsupported(t *testing.T) {
+	inspector := &Inspector{}
+
+	_, err := inspector.Details(context.Background(), 1)
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestInspector_Details_TruncatesFiles(t *testing.T) {
+	root := newFakeProcfs(t, fakeProc{pid: 10, comm: "loader", state: "S", uid: "0"})
+	fds := make(map[int]string, maxDetailFiles+5)
+	for fd := 0; fd < maxDetailFiles+5; fd++ {
+		fds[fd] = "/data/in/part-" + strconv.Itoa(fd)
+	}
+	writeDetails(t, root, 10, fds, tcpHeader, tcpHeader, "Max open files            unlimited            unlimited            files\n")
+
+	details, err := (&Inspector{root: root}).Details(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Details failed: %v", err)
+	}
+	if details.OpenFDs != maxDetailFiles+5 || len(details.Files) != maxDetailFiles || !details.Truncated {
+		t.Errorf("expected %d of %d files and truncated, got %d of %d (truncated=%v)",
+			maxDetailFiles, maxDetailFiles+5, len(details.Files), details.OpenFDs, details.Truncated)
+	}
+	if details.MaxFDs != 0 || details.MaxFDsHard != 0 {
+		t.Errorf("expected unlimited FDs, got %d/%d", details.MaxFDs, details.MaxFDsHard)
+	}
+}
+
+func TestParseHexAddr(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0100007F:1F90", "127.0.0.1:8080"},
+		{"00000000000000000000000001000000:0016", "[::1]:22"},
+		{"0000000000000000FFFF00000500000A:1538", "10.0.0.5:5432"}, // IPv4-mapped
+	}
+	for _, tt := range tests {
+		got, err := parseHexAddr(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseHexAddr(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseHexAddr("zz:1"); err == nil {
+		t.Error("expected an error for a malformed address")
+	}
+}
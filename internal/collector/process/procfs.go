@@ -0,0 +1,335 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/[pid]/stat.
+// It is 100 on all mainstream Linux architectures and cannot be queried
+// without cgo.
+const clockTicks = 100
+
+// commLen is the length the kernel truncates process names to
+const commLen = 15
+
+// procStat holds the /proc/[pid]/stat fields used by the collector
+type procStat struct {
+	comm       string
+	state      string
+	ppid       int
+	cpuTicks   uint64 // utime + stime
+	threads    int
+	startTicks uint64 // clock ticks after boot
+}
+
+// procKey identifies a process across samples; the start time tells a
+// reused PID apart from the process that held it before
+type procKey struct {
+	pid        int
+	startTicks uint64
+}
+
+// procReader reads processes from a procfs tree and derives CPU usage from
+// the change in CPU ticks since its previous read
+type procReader struct {
+	root string
+
+	mu     sync.Mutex
+	prev   map[procKey]uint64 // CPU ticks per process at the previous read
+	prevAt time.Time
+	users  map[string]string // uid to user name
+}
+
+// newProcReader creates a reader for the procfs mounted at root
+func newProcReader(root string) *procReader {
+	return &procReader{
+		root:  root,
+		prev:  make(map[procKey]uint64),
+		users: make(map[string]string),
+	}
+}
+
+// read returns every process in the procfs tree as of now. CPU% is the
+// share of one CPU used since the previous read; a process seen for the
+// first time reports its average over its lifetime instead.
+func (r *procReader) read(now time.Time) ([]*models.ProcessInfo, error) {
+	bootTime, err := r.bootTime()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(r.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interval := now.Sub(r.prevAt).Seconds()
+	ticks := make(map[procKey]uint64, len(entries))
+	var procs []*models.ProcessInfo
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		// Processes that exit while being read are skipped
+		proc, key, cpuTicks, err := r.readProcess(pid, bootTime, now)
+		if err != nil {
+			continue
+		}
+		ticks[key] = cpuTicks
+
+		if prev, ok := r.prev[key]; ok && interval > 0 && cpuTicks >= prev {
+			proc.CPUPercent = float64(cpuTicks-prev) / clockTicks / interval * 100
+		} else if lifetime := now.Sub(*proc.StartTime).Seconds(); lifetime > 0 {
+			proc.CPUPercent = float64(cpuTicks) / clockTicks / lifetime * 100
+		}
+		procs = append(procs, proc)
+	}
+
+	r.prev = ticks
+	r.prevAt = now
+	return procs, nil
+}
+
+// readProcess reads one process from /proc/[pid]
+func (r *procReader) readProcess(pid int, bootTime, now time.Time) (*models.ProcessInfo, procKey, uint64, error) {
+	dir := filepath.Join(r.root, strconv.Itoa(pid))
+
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return nil, procKey{}, 0, err
+	}
+	stat, err := parseStat(string(data))
+	if err != nil {
+		return nil, procKey{}, 0, err
+	}
+	status, err := readKeyValues(filepath.Join(dir, "status"))
+	if err != nil {
+		return nil, procKey{}, 0, err
+	}
+
+	// Kernel threads have an empty command line, and the I/O counters of
+	// other users' processes are unreadable without privileges
+	var argv []string
+	if data, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil {
+		argv = parseCmdline(data)
+	}
+	io, _ := readKeyValues(filepath.Join(dir, "io"))
+	var cgroup string
+	if data, err := os.ReadFile(filepath.Join(dir, "cgroup")); err == nil {
+		cgroup = parseCgroup(string(data))
+	}
+
+	started := bootTime.Add(time.Duration(stat.startTicks) * time.Second / clockTicks)
+	proc := &models.ProcessInfo{
+		PID:         pid,
+		PPID:        stat.ppid,
+		Name:        processName(stat.comm, argv),
+		Cmdline:     strings.Join(argv, " "),
+		User:        r.userName(firstField(status["Uid"])),
+		MemRSS:      parseKB(status["VmRSS"]),
+		Threads:     stat.threads,
+		Status:      parseState(stat.state),
+		StartTime:   &started,
+		Elapsed:     formatElapsed(now.Sub(started)),
+		ReadBytes:   parseInt(io["read_bytes"]),
+		WriteBytes:  parseInt(io["write_bytes"]),
+		Cgroup:      cgroup,
+		CollectedAt: now,
+	}
+	switch kind, name, _ := classifyCgroup(cgroup); kind {
+	case models.ProcessGroupUnit:
+		proc.Unit = name
+	case models.ProcessGroupContainer:
+		proc.ContainerID = name
+	}
+	return proc, procKey{pid, stat.startTicks}, stat.cpuTicks, nil
+}
+
+// openFDs counts the open file descriptors of a process; it fails for
+// processes that exited or that belong to other users
+func (r *procReader) openFDs(pid int) (int, bool) {
+	entries, err := os.ReadDir(filepath.Join(r.root, strconv.Itoa(pid), "fd"))
+	if err != nil {
+		return 0, false
+	}
+	return len(entries), true
+}
+
+// bootTime reads the system boot time from /proc/stat
+func (r *procReader) bootTime() (time.Time, error) {
+	f, err := os.Open(filepath.Join(r.root, "stat"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read boot time: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			sec, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid btime %q: %w", v, err)
+			}
+			return time.Unix(sec, 0), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read boot time: %w", err)
+	}
+	return time.Time{}, fmt.Errorf("no btime in %s", filepath.Join(r.root, "stat"))
+}
+
+// userName resolves a uid to a user name, falling back to the uid itself
+func (r *procReader) userName(uid string) string {
+	if name, ok := r.users[uid]; ok {
+		return name
+	}
+	name := uid
+	if u, err := user.LookupId(uid); err == nil {
+		name = u.Username
+	}
+	r.users[uid] = name
+	return name
+}
+
+// parseStat parses the contents of /proc/[pid]/stat. The name is enclosed
+// in parentheses and may itself contain spaces and parentheses, so the
+// remaining fields are split after the last closing one.
+func parseStat(data string) (procStat, error) {
+	open := strings.IndexByte(data, '(')
+	end := strings.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return procStat{}, fmt.Errorf("malformed stat: %q", data)
+	}
+	// Fields from the state (field 3) onwards
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 20 {
+		return procStat{}, fmt.Errorf("malformed stat: %q", data)
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return procStat{}, fmt.Errorf("invalid ppid: %w", err)
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("invalid utime: %w", err)
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("invalid stime: %w", err)
+	}
+	threads, err := strconv.Atoi(fields[17])
+	if err != nil {
+		return procStat{}, fmt.Errorf("invalid num_threads: %w", err)
+	}
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("invalid starttime: %w", err)
+	}
+
+	return procStat{
+		comm:       data[open+1 : end],
+		state:      fields[0],
+		ppid:       ppid,
+		cpuTicks:   utime + stime,
+		threads:    threads,
+		startTicks: start,
+	}, nil
+}
+
+// parseCmdline splits the NUL-separated arguments of /proc/[pid]/cmdline
+func parseCmdline(data []byte) []string {
+	data = bytes.TrimRight(data, "\x00")
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(string(data), "\x00")
+}
+
+// processName returns the kernel's name for the process, unless it was
+// truncated and the executable in the command line spells it out in full
+func processName(comm string, argv []string) string {
+	if len(comm) < commLen || len(argv) == 0 {
+		return comm
+	}
+	if exe := filepath.Base(argv[0]); strings.HasPrefix(exe, comm) {
+		return exe
+	}
+	return comm
+}
+
+// readKeyValues reads a "key: value" file such as /proc/[pid]/status
+func readKeyValues(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	return values, scanner.Err()
+}
+
+// firstField returns the first whitespace-separated field of s
+func firstField(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// parseKB converts a "1234 kB" value to bytes
+func parseKB(s string) int64 {
+	return parseInt(firstField(s)) * 1024
+}
+
+// parseInt parses a decimal value, treating anything unparseable as 0
+func parseInt(s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// formatElapsed renders a duration like ps etime: [[dd-]hh:]mm:ss
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	secs := int64(d / time.Second)
+	days, secs := secs/86400, secs%86400
+	hours, secs := secs/3600, secs%3600
+	mins, secs := secs/60, secs%60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, hours, mins, secs)
+	case hours > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", hours, mins, secs)
+	default:
+		return fmt.Sprintf("%02d:%02d", mins, secs)
+	}
+}
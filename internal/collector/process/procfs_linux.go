@@ -0,0 +1,16 @@
+//go:build linux
+
+package process
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted
+const cgroupRoot = "/sys/fs/cgroup"
+
+// newProcessSource returns a reader for the system's /proc
+func newProcessSource() processSource {
+	return newProcReader("/proc")
+}
+
+// NewInspector returns an inspector for the system's /proc
+func NewInspector() *Inspector {
+	return &Inspector{root: "/proc"}
+}
@@ -0,0 +1,227 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// bootTime is the btime of the fake procfs trees
+var bootTime = time.Unix(1760860800, 0) // 2025-10-19T08:00:00Z
+
+// fakeProc is one process in a fake procfs tree
+type fakeProc struct {
+	pid        int
+	comm       string
+	state      string
+	ppid       int
+	utime      uint64
+	stime      uint64
+	threads    int
+	startTicks uint64
+	uid        string
+	rssKB      int64
+	cmdline    []string
+	io         string // contents of io; omitted when empty
+	cgroup     string // contents of cgroup; omitted when empty
+}
+
+// writeProc writes p under root, replacing any previous state
+func writeProc(t *testing.T, root string, p fakeProc) {
+	t.Helper()
+	dir := filepath.Join(root, strconv.Itoa(p.pid))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Fields 3 to 22 of /proc/[pid]/stat, then a few trailing ones
+	fields := []string{
+		p.state, strconv.Itoa(p.ppid), "1", "1", "0", "-1", "4194304",
+		"100", "0", "0", "0",
+		strconv.FormatUint(p.utime, 10), strconv.FormatUint(p.stime, 10),
+		"0", "0", "20", "0", strconv.Itoa(p.threads), "0",
+		strconv.FormatUint(p.startTicks, 10), "1000000", "250",
+	}
+	stat := strconv.Itoa(p.pid) + " (" + p.comm + ") " + strings.Join(fields, " ") + "\n"
+	status := "Name:\t" + p.comm + "\nUid:\t" + p.uid + "\t" + p.uid + "\t" + p.uid + "\t" + p.uid + "\n"
+	if p.rssKB > 0 {
+		status += "VmRSS:\t   " + strconv.FormatInt(p.rssKB, 10) + " kB\n"
+	}
+	cmdline := ""
+	for _, arg := range p.cmdline {
+		cmdline += arg + "\x00"
+	}
+
+	files := map[string]string{"stat": stat, "status": status, "cmdline": cmdline}
+	if p.io != "" {
+		files["io"] = p.io
+	}
+	if p.cgroup != "" {
+		files["cgroup"] = p.cgroup
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newFakeProcfs creates a procfs tree with a /proc/stat and the given processes
+func newFakeProcfs(t *testing.T, procs ...fakeProc) string {
+	t.Helper()
+	root := t.TempDir()
+	stat := "cpu  1 2 3 4\nbtime " + strconv.FormatInt(bootTime.Unix(), 10) + "\nprocesses 42\n"
+	if err := os.WriteFile(filepath.Join(root, "stat"), []byte(stat), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Non-process entries are ignored
+	if err := os.MkdirAll(filepath.Join(root, "sys"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range procs {
+		writeProc(t, root, p)
+	}
+	return root
+}
+
+func TestProcReader_Read_ParsesProcFiles(t *testing.T) {
+	java := fakeProc{
+		pid: 4242, comm: "java", state: "S", ppid: 1, utime: 300, stime: 100,
+		threads: 48, startTicks: 360000, uid: "0", rssKB: 2048,
+		cmdline: []string{"/usr/bin/java", "-Xmx4g", "-jar", "/opt/etl/loader.jar", "--job", "orders"},
+		io:      "rchar: 999\nwchar: 888\nread_bytes: 4096\nwrite_bytes: 8192\ncancelled_write_bytes: 0\n",
+		cgroup:  "0::/system.slice/etl-loader.service\n",
+	}
+	kthread := fakeProc{pid: 2, comm: "kthreadd", state: "S", threads: 1, uid: "0"}
+	root := newFakeProcfs(t, java, kthread)
+
+	now := bootTime.Add(2 * time.Hour)
+	procs, err := newProcReader(root).read(now)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(procs) != 2 {
+		t.Fatalf("Expected 2 processes, got %d", len(procs))
+	}
+	byPID := map[int]int{procs[0].PID: 0, procs[1].PID: 1}
+
+	p := procs[byPID[4242]]
+	if p.Name != "java" || p.PPID != 1 || p.Threads != 48 || p.Status != "sleeping" {
+		t.Errorf("Unexpected process: %+v", p)
+	}
+	if p.Cmdline != "/usr/bin/java -Xmx4g -jar /opt/etl/loader.jar --job orders" {
+		t.Errorf("Unexpected cmdline %q", p.Cmdline)
+	}
+	if p.User != "root" || p.MemRSS != 2048*1024 {
+		t.Errorf("Expected root with 2MiB RSS, got %q with %d", p.User, p.MemRSS)
+	}
+	if p.ReadBytes != 4096 || p.WriteBytes != 8192 {
+		t.Errorf("Expected storage I/O of 4096/8192, got %d/%d", p.ReadBytes, p.WriteBytes)
+	}
+	if p.Cgroup != "/system.slice/etl-loader.service" || p.Unit != "etl-loader.service" || p.ContainerID != "" {
+		t.Errorf("Expected the etl-loader.service unit, got cgroup %q unit %q container %q", p.Cgroup, p.Unit, p.ContainerID)
+	}
+	// Started 3600s after boot, an hour before now
+	if p.StartTime == nil || !p.StartTime.Equal(bootTime.Add(time.Hour)) || p.Elapsed != "01:00:00" {
+		t.Errorf("Expected start one hour ago, got %v (%s)", p.StartTime, p.Elapsed)
+	}
+	// First sight: 4s of CPU over its 3600s lifetime
+	if got := p.CPUPercent; got < 0.11 || got > 0.12 {
+		t.Errorf("Expected lifetime CPU of ~0.11%%, got %.3f", got)
+	}
+
+	k := procs[byPID[2]]
+	if k.Cmdline != "" || k.Name != "kthreadd" || k.MemRSS != 0 || k.ReadBytes != 0 || k.Unit != "" {
+		t.Errorf("Unexpected kernel thread: %+v", k)
+	}
+}
+
+func TestProcReader_Read_CPUFromTickDeltas(t *testing.T) {
+	worker := fakeProc{pid: 100, comm: "etl_worker", state: "R", threads: 1, startTicks: 1000, uid: "0", utime: 5000}
+	root := newFakeProcfs(t, worker)
+	r := newProcReader(root)
+
+	now := bootTime.Add(24 * time.Hour)
+	if _, err := r.read(now); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	// 150 ticks (1.5s of CPU) over the next 2s
+	worker.utime += 100
+	worker.stime += 50
+	writeProc(t, root, worker)
+	procs, err := r.read(now.Add(2 * time.Second))
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if got := procs[0].CPUPercent; got != 75 {
+		t.Errorf("Expected 75%% CPU over the interval, got %.2f", got)
+	}
+
+	// A reused PID is a new process and gets no delta from its predecessor
+	worker.startTicks = 2000
+	worker.utime = 100
+	worker.stime = 0
+	writeProc(t, root, worker)
+	procs, err = r.read(now.Add(4 * time.Second))
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if got := procs[0].CPUPercent; got > 0.01 {
+		t.Errorf("Expected the lifetime average for a reused PID, got %.2f", got)
+	}
+}
+
+func TestParseStat_NameWithSpacesAndParens(t *testing.T) {
+	data := "77 (my (odd) proc) S 1 77 77 0 -1 4194304 1 0 0 0 12 8 0 0 20 0 3 0 555 1000 10\n"
+
+	stat, err := parseStat(data)
+	if err != nil {
+		t.Fatalf("parseStat failed: %v", err)
+	}
+	if stat.comm != "my (odd) proc" || stat.ppid != 1 || stat.cpuTicks != 20 || stat.threads != 3 || stat.startTicks != 555 {
+		t.Errorf("Unexpected stat: %+v", stat)
+	}
+
+	if _, err := parseStat("77 (truncated) S 1"); err == nil {
+		t.Error("Expected an error for a truncated stat line")
+	}
+}
+
+func TestProcessName_ExpandsTruncatedComm(t *testing.T) {
+	tests := []struct {
+		comm string
+		argv []string
+		want string
+	}{
+		{"java", []string{"/usr/bin/java", "-jar", "x.jar"}, "java"},
+		{"partner_ingest_", []string{"/opt/bin/partner_ingest_worker", "--once"}, "partner_ingest_worker"},
+		{"partner_ingest_", []string{"python3", "partner_ingest_worker.py"}, "partner_ingest_"},
+		{"kworker/0:1-eve", nil, "kworker/0:1-eve"},
+	}
+
+	for _, tt := range tests {
+		if got := processName(tt.comm, tt.argv); got != tt.want {
+			t.Errorf("processName(%q, %v) = %q, want %q", tt.comm, tt.argv, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{42 * time.Second, "00:42"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "01:02:03"},
+		{26*time.Hour + 5*time.Second, "1-02:00:05"},
+	}
+
+	for _, tt := range tests {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+//go:build !linux
+
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// cgroupRoot is empty, as there are no cgroups outside Linux
+const cgroupRoot = ""
+
+// psSource lists processes with the ps command. ps reports CPU% as an
+// average over each process's lifetime and truncates names.
+type psSource struct{}
+
+// newProcessSource returns the ps-based process source
+func newProcessSource() processSource {
+	return psSource{}
+}
+
+// NewInspector returns an inspector that reports process details as
+// unsupported, since they are read from /proc
+func NewInspector() *Inspector {
+	return &Inspector{}
+}
+
+// read runs ps command and parses output
+func (psSource) read(now time.Time) ([]*models.ProcessInfo, error) {
+	// Using ps with custom format for consistent cross-platform parsing
+	out, err := exec.Command("ps", "-eo", "pid,ppid,user,pcpu,rss,state,etime,comm").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ps command failed: %w", err)
+	}
+
+	var procs []*models.ProcessInfo
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+
+	// Skip header line
+	if scanner.Scan() {
+		// header consumed
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		proc, err := parsePsLine(line, now)
+		if err != nil {
+			continue // skip unparseable lines
+		}
+		procs = append(procs, proc)
+	}
+
+	return procs, nil
+}
+
+// parsePsLine parses a single line from ps -eo output
+func parsePsLine(line string, now time.Time) (*models.ProcessInfo, error) {
+	// Fields: PID PPID USER %CPU RSS STATE ELAPSED COMMAND
+	// The command may contain spaces, so we split carefully
+	fields := strings.Fields(line)
+	if len(fields) < 8 {
+		return nil, fmt.Errorf("insufficient fields: %s", line)
+	}
+
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid PID: %s", fields[0])
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid PPID: %s", fields[1])
+	}
+
+	cpu, err := strconv.ParseFloat(fields[3], 64)
+	if err != nil {
+		cpu = 0
+	}
+
+	rss, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		rss = 0
+	}
+
+	// Convert state code to human-readable
+	status := parseState(fields[5])
+
+	// Extract basename from command path (fields[7] is the comm field)
+	// On macOS, comm can include path prefixes like ./ or full paths
+	commandName := filepath.Base(fields[7])
+
+	return &models.ProcessInfo{
+		PID:         pid,
+		PPID:        ppid,
+		User:        fields[2],
+		CPUPercent:  cpu,
+		MemRSS:      rss * 1024, // ps reports RSS in KB, convert to bytes
+		Status:      status,
+		Elapsed:     fields[6],
+		Name:        commandName,
+		CollectedAt: now,
+	}, nil
+}
@@ -0,0 +1,201 @@
+package process
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// WatchConfig is a named group of processes expected to be running
+type WatchConfig struct {
+	Name  string
+	Match *regexp.Regexp // on the command line, or the name without one; nil matches all
+	User  string         // empty matches any user
+	Min   int
+	Max   int // 0 = unbounded
+}
+
+// matches reports whether proc belongs to the watch
+func (w WatchConfig) matches(proc *models.ProcessInfo) bool {
+	if w.User != "" && proc.User != w.User {
+		return false
+	}
+	if w.Match == nil {
+		return true
+	}
+	target := proc.Cmdline
+	if target == "" {
+		target = proc.Name
+	}
+	return w.Match.MatchString(target)
+}
+
+// evaluate counts the processes matching the watch and derives its status
+func (w WatchConfig) evaluate(procs []*models.ProcessInfo, now time.Time) (*models.ProcessWatch, []*models.ProcessInfo) {
+	watch := &models.ProcessWatch{
+		Name:      w.Name,
+		User:      w.User,
+		Min:       w.Min,
+		Max:       w.Max,
+		PIDs:      []int{},
+		Status:    models.ProcessWatchUp,
+		CheckedAt: now,
+	}
+	if w.Match != nil {
+		watch.Match = w.Match.String()
+	}
+
+	var matched []*models.ProcessInfo
+	for _, proc := range procs {
+		if w.matches(proc) {
+			matched = append(matched, proc)
+			watch.PIDs = append(watch.PIDs, proc.PID)
+		}
+	}
+	watch.Count = len(matched)
+
+	switch {
+	case watch.Count == 0:
+		watch.Status = models.ProcessWatchDown
+		watch.Reason = "no matching process"
+	case watch.Count < w.Min:
+		watch.Status = models.ProcessWatchDegraded
+		watch.Reason = fmt.Sprintf("%d running, expected at least %d", watch.Count, w.Min)
+	case w.Max > 0 && watch.Count > w.Max:
+		watch.Status = models.ProcessWatchDegraded
+		watch.Reason = fmt.Sprintf("%d running, expected at most %d", watch.Count, w.Max)
+	}
+	return watch, matched
+}
+
+// sample sums the usage of the processes matched by a watch; open
+// descriptors are left for the collector, as only some sources count them
+func sample(watch string, matched []*models.ProcessInfo, now time.Time) *models.ProcessWatchSample {
+	s := &models.ProcessWatchSample{
+		Watch:       watch,
+		Count:       len(matched),
+		CollectedAt: now,
+	}
+	for _, proc := range matched {
+		s.CPUPercent += proc.CPUPercent
+		s.MemRSS += proc.MemRSS
+		s.Threads += proc.Threads
+	}
+	return s
+}
+
+// restartGrace is how long after an instance exits a new instance of the
+// same watch counts as its restart rather than an additional instance
+const restartGrace = 15 * time.Minute
+
+// instanceKey identifies a process instance; the start time tells a
+// restarted process apart from its predecessor when it reuses the PID
+type instanceKey struct {
+	pid   int
+	start int64 // Unix nanoseconds, 0 when the start time is unknown
+}
+
+func instanceOf(proc *models.ProcessInfo) instanceKey {
+	key := instanceKey{pid: proc.PID}
+	if proc.StartTime != nil {
+		key.start = proc.StartTime.UnixNano()
+	}
+	return key
+}
+
+// watchTracker follows the instances of each watch between collections
+// and reports the ones that started and exited
+type watchTracker struct {
+	mu        sync.Mutex
+	instances map[string]map[instanceKey]*models.ProcessInfo
+	exits     map[string][]time.Time // Recent exits not yet replaced, oldest first
+}
+
+func newWatchTracker() *watchTracker {
+	return &watchTracker{
+		instances: make(map[string]map[instanceKey]*models.ProcessInfo),
+		exits:     make(map[string][]time.Time),
+	}
+}
+
+// track compares the instances matched by a watch with the previous
+// collection. The first collection of a watch only records its instances,
+// as what happened before it is unknown.
+func (t *watchTracker) track(watch string, matched []*models.ProcessInfo, now time.Time) []*models.ProcessEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[instanceKey]*models.ProcessInfo, len(matched))
+	for _, proc := range matched {
+		current[instanceOf(proc)] = proc
+	}
+	prev, seen := t.instances[watch]
+	t.instances[watch] = current
+	if !seen {
+		return nil
+	}
+
+	// Forget exits that were never replaced
+	exits := t.exits[watch]
+	for len(exits) > 0 && now.Sub(exits[0]) > restartGrace {
+		exits = exits[1:]
+	}
+
+	// Exits first, so that an instance replaced within one collection
+	// interval counts as restarted
+	var events []*models.ProcessEvent
+	for _, proc := range sortedByPID(prev) {
+		if _, ok := current[instanceOf(proc)]; ok {
+			continue
+		}
+		event := &models.ProcessEvent{
+			Watch:      watch,
+			PID:        proc.PID,
+			EventType:  models.ProcessEventExit,
+			StartTime:  proc.StartTime,
+			Cmdline:    proc.Cmdline,
+			DetectedAt: now,
+		}
+		if proc.StartTime != nil {
+			event.RuntimeSec = int64(now.Sub(*proc.StartTime) / time.Second)
+		}
+		events = append(events, event)
+		exits = append(exits, now)
+	}
+	for _, proc := range sortedByPID(current) {
+		if _, ok := prev[instanceOf(proc)]; ok {
+			continue
+		}
+		restart := len(exits) > 0
+		if restart {
+			exits = exits[1:]
+		}
+		events = append(events, &models.ProcessEvent{
+			Watch:      watch,
+			PID:        proc.PID,
+			EventType:  models.ProcessEventStart,
+			Restart:    restart,
+			StartTime:  proc.StartTime,
+			Cmdline:    proc.Cmdline,
+			DetectedAt: now,
+		})
+	}
+	t.exits[watch] = exits
+	return events
+}
+
+// sortedByPID returns the processes of an instance set in PID order
+func sortedByPID(instances map[instanceKey]*models.ProcessInfo) []*models.ProcessInfo {
+	procs := make([]*models.ProcessInfo, 0, len(instances))
+	for _, proc := range instances {
+		procs = append(procs, proc)
+	}
+	sort.Slice(procs, func(i, j int) bool {
+		return procs[i].PID < procs[j].PID
+	})
+	return procs
+}
@@ -0,0 +1,86 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// instance returns a process that started at start
+func instance(pid int, start time.Time) *models.ProcessInfo {
+	return &models.ProcessInfo{PID: pid, Name: "etl_worker", Cmdline: "etl_worker --queue a", StartTime: &start}
+}
+
+func TestWatchTracker_Track_DetectsRestarts(t *testing.T) {
+	tracker := newWatchTracker()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	first := instance(20, now.Add(-time.Hour))
+
+	// The first collection is the baseline
+	if events := tracker.track("workers", []*models.ProcessInfo{first}, now); len(events) != 0 {
+		t.Fatalf("Expected no events for the baseline, got %+v", events)
+	}
+
+	// Crashed and restarted by the supervisor between two collections
+	second := instance(21, now.Add(20*time.Second))
+	events := tracker.track("workers", []*models.ProcessInfo{second}, now.Add(30*time.Second))
+	if len(events) != 2 {
+		t.Fatalf("Expected an exit and a start, got %+v", events)
+	}
+	exit, start := events[0], events[1]
+	if exit.EventType != models.ProcessEventExit || exit.PID != 20 || exit.RuntimeSec != 3630 || exit.Cmdline != "etl_worker --queue a" {
+		t.Errorf("Unexpected exit event: %+v", exit)
+	}
+	if start.EventType != models.ProcessEventStart || start.PID != 21 || !start.Restart {
+		t.Errorf("Expected a restart of PID 21, got %+v", start)
+	}
+
+	// Down for a collection, then back: still a restart
+	events = tracker.track("workers", nil, now.Add(time.Minute))
+	if len(events) != 1 || events[0].EventType != models.ProcessEventExit {
+		t.Fatalf("Expected an exit, got %+v", events)
+	}
+	events = tracker.track("workers", []*models.ProcessInfo{instance(22, now.Add(80*time.Second))}, now.Add(90*time.Second))
+	if len(events) != 1 || !events[0].Restart {
+		t.Errorf("Expected a restart after the outage, got %+v", events)
+	}
+}
+
+func TestWatchTracker_Track_TellsReusedPIDsApart(t *testing.T) {
+	tracker := newWatchTracker()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tracker.track("workers", []*models.ProcessInfo{instance(20, now.Add(-time.Hour))}, now)
+
+	// Same PID, new start time
+	events := tracker.track("workers", []*models.ProcessInfo{instance(20, now.Add(10*time.Second))}, now.Add(30*time.Second))
+	if len(events) != 2 || events[0].EventType != models.ProcessEventExit || !events[1].Restart {
+		t.Errorf("Expected the reused PID to be a restart, got %+v", events)
+	}
+
+	// Unchanged instances produce no events
+	if events := tracker.track("workers", []*models.ProcessInfo{instance(20, now.Add(10*time.Second))}, now.Add(time.Minute)); len(events) != 0 {
+		t.Errorf("Expected no events, got %+v", events)
+	}
+}
+
+func TestWatchTracker_Track_ScaleUpIsNotARestart(t *testing.T) {
+	tracker := newWatchTracker()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	a := instance(20, now.Add(-time.Hour))
+	b := instance(21, now.Add(-time.Hour))
+	tracker.track("workers", []*models.ProcessInfo{a, b}, now)
+
+	// One instance stops for good
+	tracker.track("workers", []*models.ProcessInfo{a}, now.Add(time.Minute))
+
+	// A new instance long after is an additional one
+	events := tracker.track("workers", []*models.ProcessInfo{a, instance(30, now.Add(time.Hour))}, now.Add(time.Hour))
+	if len(events) != 1 || events[0].Restart {
+		t.Errorf("Expected a plain start, got %+v", events)
+	}
+	events = tracker.track("workers", []*models.ProcessInfo{a, instance(30, now.Add(time.Hour)), instance(31, now.Add(time.Hour))}, now.Add(time.Hour+time.Minute))
+	if len(events) != 1 || events[0].Restart {
+		t.Errorf("Expected a plain start, got %+v", events)
+	}
+}
@@ -17,21 +17,93 @@ type RefreshSettings struct {
 	DefaultPathScan time.Duration `yaml:"default_path_scan" json:"default_path_scan"`
 	Process         time.Duration `yaml:"process" json:"process"`
 	Log             time.Duration `yaml:"log" json:"log"`
+	DeletedFiles    time.Duration `yaml:"deleted_files" json:"deleted_files"`
 }
 
-// PathConfig defines a monitored path with its scan settings
+// PathConfig defines a monitored path with its scan settings. A path with
+// glob characters is a template for every directory it matches.
 type PathConfig struct {
-	Path         string        `yaml:"path" json:"path"`
-	ScanInterval time.Duration `yaml:"scan_interval" json:"scan_interval"`
-	MaxDepth     int           `yaml:"max_depth" json:"max_depth"`
-	Exclude      []string      `yaml:"exclude" json:"exclude"`
-	Timeout      time.Duration `yaml:"timeout" json:"timeout"`
+	Path             string            `yaml:"path" json:"path"`
+	ScanInterval     time.Duration     `yaml:"scan_interval" json:"scan_interval"`
+	MaxDepth         int               `yaml:"max_depth" json:"max_depth"`
+	Include          []string          `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude          []string          `yaml:"exclude" json:"exclude"`
+	Timeout          time.Duration     `yaml:"timeout" json:"timeout"`
+	MaxFileAge       time.Duration     `yaml:"max_file_age,omitempty" json:"max_file_age,omitempty"`
+	MaxFileCount     int64             `yaml:"max_file_count,omitempty" json:"max_file_count,omitempty"`
+	Track            bool              `yaml:"track,omitempty" json:"track,omitempty"`
+	Workers          int               `yaml:"workers,omitempty" json:"workers,omitempty"`
+	MaxOpsPerSec     int               `yaml:"max_ops_per_sec,omitempty" json:"max_ops_per_sec,omitempty"`
+	Incremental      bool              `yaml:"incremental,omitempty" json:"incremental,omitempty"`
+	FullScanInterval time.Duration     `yaml:"full_scan_interval,omitempty" json:"full_scan_interval,omitempty"`
+	SkipHidden       bool              `yaml:"skip_hidden,omitempty" json:"skip_hidden,omitempty"`
+	Symlinks         string            `yaml:"symlinks,omitempty" json:"symlinks,omitempty"`
+	OneFilesystem    bool              `yaml:"one_filesystem,omitempty" json:"one_filesystem,omitempty"`
+	Buckets          map[string]string `yaml:"buckets,omitempty" json:"buckets,omitempty"`
+	BreakdownDepth   int               `yaml:"breakdown_depth,omitempty" json:"breakdown_depth,omitempty"`
+	ExpandInterval   time.Duration     `yaml:"expand_interval,omitempty" json:"expand_interval,omitempty"`
+	Watch            bool              `yaml:"watch,omitempty" json:"watch,omitempty"`
+	ExpectedCadence  time.Duration     `yaml:"expected_cadence,omitempty" json:"expected_cadence,omitempty"`
+}
+
+// PartitionConfig defines a completeness check for date partitions. Path
+// holds {YYYY}, {MM} and {DD} placeholders, plus {HH} for hourly
+// partitions; a partition is complete once its marker file exists.
+type PartitionConfig struct {
+	Name          string        `yaml:"name" json:"name"`
+	Path          string        `yaml:"path" json:"path"`
+	Marker        string        `yaml:"marker" json:"marker"`
+	Deadline      time.Duration `yaml:"deadline" json:"deadline"`
+	Lookback      int           `yaml:"lookback" json:"lookback"`
+	CheckInterval time.Duration `yaml:"check_interval" json:"check_interval"`
+	Timezone      string        `yaml:"timezone" json:"timezone"`
+}
+
+// PipelineConfig defines a named group of monitored paths that files move
+// through in order, such as inbox, processing, done and error
+type PipelineConfig struct {
+	Name           string        `yaml:"name" json:"name"`
+	Stages         []StageConfig `yaml:"stages" json:"stages"`
+	SampleInterval time.Duration `yaml:"sample_interval" json:"sample_interval"`
+}
+
+// StageConfig is one stage of a pipeline. Path must be one of the concrete
+// paths under paths; stage paths are tracked to measure throughput.
+type StageConfig struct {
+	Name string `yaml:"name" json:"name"`
+	Path string `yaml:"path" json:"path"`
+}
+
+// DiskConfig selects the mounts the disk collector reports. Include and
+// Exclude are globs on the mount point. Types limits collection to those
+// filesystem types; without it, device-backed, network (NFS, CIFS) and
+// fuse filesystems are reported. ExcludeTypes adds to the built-in list of
+// pseudo filesystems.
+type DiskConfig struct {
+	Include       []string      `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude       []string      `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	Types         []string      `yaml:"types,omitempty" json:"types,omitempty"`
+	ExcludeTypes  []string      `yaml:"exclude_types,omitempty" json:"exclude_types,omitempty"`
+	StatfsTimeout time.Duration `yaml:"statfs_timeout" json:"statfs_timeout"`
 }
 
 // ProcessConfig defines process monitoring settings
 type ProcessConfig struct {
-	Patterns []string `yaml:"patterns" json:"patterns"`
-	TopN     int      `yaml:"top_n" json:"top_n"`
+	Patterns         []string      `yaml:"patterns" json:"patterns"`
+	TopN             int           `yaml:"top_n" json:"top_n"`
+	HistoryRetention time.Duration `yaml:"history_retention" json:"history_retention"`
+}
+
+// ProcessWatchConfig defines a named group of processes expected to be
+// running. Match is a regular expression on the command line (the process
+// name for processes without one); User restricts the watch to processes
+// of that user. Max of 0 sets no upper bound.
+type ProcessWatchConfig struct {
+	Name  string `yaml:"name" json:"name"`
+	Match string `yaml:"match" json:"match"`
+	User  string `yaml:"user,omitempty" json:"user,omitempty"`
+	Min   int    `yaml:"min" json:"min"`
+	Max   int    `yaml:"max,omitempty" json:"max,omitempty"`
 }
 
 // LogMonitorConfig defines a single log file to monitor
@@ -10,11 +10,15 @@ import (
 
 // NodeConfig represents the complete node configuration
 type NodeConfig struct {
-	Node    NodeSettings       `yaml:"node" json:"node"`
-	Refresh RefreshSettings    `yaml:"refresh" json:"refresh"`
-	Paths   []PathConfig       `yaml:"paths" json:"paths"`
-	Process ProcessConfig      `yaml:"process" json:"process"`
-	Logs    []LogMonitorConfig `yaml:"logs" json:"logs"`
+	Node         NodeSettings         `yaml:"node" json:"node"`
+	Refresh      RefreshSettings      `yaml:"refresh" json:"refresh"`
+	Disk         DiskConfig           `yaml:"disk" json:"disk"`
+	Paths        []PathConfig         `yaml:"paths" json:"paths"`
+	Partitions   []PartitionConfig    `yaml:"partitions" json:"partitions"`
+	Pipelines    []PipelineConfig     `yaml:"pipelines" json:"pipelines"`
+	Process      ProcessConfig        `yaml:"process" json:"process"`
+	ProcessWatch []ProcessWatchConfig `yaml:"process_watch" json:"process_watch"`
+	Logs         []LogMonitorConfig   `yaml:"logs" json:"logs"`
 }
 
 // LoadNodeConfig loads and validates a node configuration from a YAML file
@@ -75,6 +79,14 @@ func applyNodeDefaults(cfg *NodeConfig) {
 	if cfg.Refresh.Log == 0 {
 		cfg.Refresh.Log = 2 * time.Second
 	}
+	if cfg.Refresh.DeletedFiles == 0 {
+		cfg.Refresh.DeletedFiles = time.Minute
+	}
+
+	// Disk defaults
+	if cfg.Disk.StatfsTimeout == 0 {
+		cfg.Disk.StatfsTimeout = 5 * time.Second
+	}
 
 	// Path defaults
 	for i := range cfg.Paths {
@@ -90,10 +102,42 @@ func applyNodeDefaults(cfg *NodeConfig) {
 		}
 	}
 
+	// Partition defaults
+	for i := range cfg.Partitions {
+		part := &cfg.Partitions[i]
+		if part.Marker == "" {
+			part.Marker = "_SUCCESS"
+		}
+		if part.Lookback == 0 {
+			part.Lookback = 7
+		}
+		if part.CheckInterval == 0 {
+			part.CheckInterval = cfg.Refresh.DefaultPathScan
+		}
+		if part.Timezone == "" {
+			part.Timezone = "UTC"
+		}
+	}
+
+	// Pipeline defaults
+	for i := range cfg.Pipelines {
+		if cfg.Pipelines[i].SampleInterval == 0 {
+			cfg.Pipelines[i].SampleInterval = cfg.Refresh.DefaultPathScan
+		}
+	}
+
 	// Process defaults
 	if cfg.Process.TopN == 0 {
 		cfg.Process.TopN = 50
 	}
+	if cfg.Process.HistoryRetention == 0 {
+		cfg.Process.HistoryRetention = 7 * 24 * time.Hour
+	}
+	for i := range cfg.ProcessWatch {
+		if cfg.ProcessWatch[i].Min == 0 {
+			cfg.ProcessWatch[i].Min = 1
+		}
+	}
 
 	// Log defaults
 	for i := range cfg.Logs {
@@ -135,6 +135,12 @@ paths:
 	if cfg.Refresh.Process != 10*time.Second {
 		t.Errorf("Expected default process refresh 10s, got %v", cfg.Refresh.Process)
 	}
+	if cfg.Refresh.DeletedFiles != time.Minute {
+		t.Errorf("Expected default deleted files refresh 1m, got %v", cfg.Refresh.DeletedFiles)
+	}
+	if cfg.Process.HistoryRetention != 7*24*time.Hour {
+		t.Errorf("Expected default process history retention 7 days, got %v", cfg.Process.HistoryRetention)
+	}
 
 	// Verify path defaults
 	if len(cfg.Paths) != 1 {
@@ -263,3 +269,544 @@ func TestValidateNodeConfig_ValidConfig_NoError(t *testing.T) {
 		t.Errorf("Expected no error for valid config, got: %v", err)
 	}
 }
+
+func TestLoadNodeConfig_PathSLA_LoadsExpectations(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/input"
+    max_file_age: 2h
+    max_file_count: 500
+    expected_cadence: 15m
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if cfg.Paths[0].MaxFileAge != 2*time.Hour {
+		t.Errorf("Expected max_file_age 2h, got %v", cfg.Paths[0].MaxFileAge)
+	}
+	if cfg.Paths[0].MaxFileCount != 500 {
+		t.Errorf("Expected max_file_count 500, got %d", cfg.Paths[0].MaxFileCount)
+	}
+	if cfg.Paths[0].ExpectedCadence != 15*time.Minute {
+		t.Errorf("Expected expected_cadence 15m, got %v", cfg.Paths[0].ExpectedCadence)
+	}
+}
+
+func TestLoadNodeConfig_PathScanOptions(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/input"
+    track: true
+    workers: 8
+    max_ops_per_sec: 200
+    buckets:
+      errors: "*.err"
+      data: "*.csv"
+    breakdown_depth: 2
+    watch: true
+  - path: "/data/archive"
+    incremental: true
+    full_scan_interval: 12h
+    include: ["*.csv", "re:^2026/"]
+    skip_hidden: true
+    symlinks: follow
+    one_filesystem: true
+  - path: "/data/partners/*/inbox"
+    expand_interval: 10m
+    max_file_age: 1h
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if !cfg.Paths[0].Track {
+		t.Error("Expected track to be enabled for /data/input")
+	}
+	if cfg.Paths[1].Track {
+		t.Error("Expected track to default to false")
+	}
+	if cfg.Paths[0].Workers != 8 || cfg.Paths[0].MaxOpsPerSec != 200 {
+		t.Errorf("Expected workers 8 and max_ops_per_sec 200, got %d and %d", cfg.Paths[0].Workers, cfg.Paths[0].MaxOpsPerSec)
+	}
+	if cfg.Paths[1].Workers != 0 || cfg.Paths[1].MaxOpsPerSec != 0 {
+		t.Error("Expected workers and max_ops_per_sec to default to 0 (scanner decides)")
+	}
+	if !cfg.Paths[1].Incremental || cfg.Paths[1].FullScanInterval != 12*time.Hour {
+		t.Errorf("Expected incremental with 12h full scans, got %v and %v", cfg.Paths[1].Incremental, cfg.Paths[1].FullScanInterval)
+	}
+	if cfg.Paths[0].Incremental {
+		t.Error("Expected incremental to default to false")
+	}
+	if len(cfg.Paths[1].Include) != 2 || cfg.Paths[1].Include[1] != "re:^2026/" {
+		t.Errorf("Expected two include patterns, got %v", cfg.Paths[1].Include)
+	}
+	if !cfg.Paths[1].SkipHidden || cfg.Paths[1].Symlinks != "follow" || !cfg.Paths[1].OneFilesystem {
+		t.Errorf("Expected skip_hidden, symlinks follow and one_filesystem, got %v, %q and %v",
+			cfg.Paths[1].SkipHidden, cfg.Paths[1].Symlinks, cfg.Paths[1].OneFilesystem)
+	}
+	if len(cfg.Paths[0].Buckets) != 2 || cfg.Paths[0].Buckets["errors"] != "*.err" {
+		t.Errorf("Expected errors and data buckets, got %v", cfg.Paths[0].Buckets)
+	}
+	if cfg.Paths[0].BreakdownDepth != 2 || cfg.Paths[1].BreakdownDepth != 0 {
+		t.Errorf("Expected breakdown_depth 2 and 0, got %d and %d", cfg.Paths[0].BreakdownDepth, cfg.Paths[1].BreakdownDepth)
+	}
+	if !cfg.Paths[0].Watch || cfg.Paths[1].Watch {
+		t.Error("Expected watch enabled on the first path only")
+	}
+	if cfg.Paths[2].Path != "/data/partners/*/inbox" || cfg.Paths[2].ExpandInterval != 10*time.Minute {
+		t.Errorf("Expected template path with 10m expand_interval, got %q and %v", cfg.Paths[2].Path, cfg.Paths[2].ExpandInterval)
+	}
+	if err := ValidateNodeConfig(cfg); err != nil {
+		t.Errorf("Expected scan options to validate, got %v", err)
+	}
+	if cfg.Paths[1].Buckets != nil {
+		t.Errorf("Expected no buckets for /data/archive, got %v", cfg.Paths[1].Buckets)
+	}
+	if cfg.Paths[0].SkipHidden || cfg.Paths[0].Symlinks != "" || cfg.Paths[0].OneFilesystem {
+		t.Error("Expected matching options to default to off")
+	}
+}
+
+func TestValidateNodeConfig_NegativePathLimits_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name string
+		path PathConfig
+	}{
+		{"negative max_file_age", PathConfig{Path: "/data", MaxFileAge: -time.Minute}},
+		{"negative max_file_count", PathConfig{Path: "/data", MaxFileCount: -1}},
+		{"negative workers", PathConfig{Path: "/data", Workers: -1}},
+		{"negative max_ops_per_sec", PathConfig{Path: "/data", MaxOpsPerSec: -5}},
+		{"negative full_scan_interval", PathConfig{Path: "/data", Incremental: true, FullScanInterval: -time.Hour}},
+		{"incremental with track", PathConfig{Path: "/data", Incremental: true, Track: true}},
+		{"incremental with max_file_age", PathConfig{Path: "/data", Incremental: true, MaxFileAge: time.Hour}},
+		{"unknown symlinks mode", PathConfig{Path: "/data", Symlinks: "resolve"}},
+		{"malformed include glob", PathConfig{Path: "/data", Include: []string{"[a-"}}},
+		{"reversed include glob range", PathConfig{Path: "/data", Include: []string{"*.[z-a]"}}},
+		{"malformed exclude regex", PathConfig{Path: "/data", Exclude: []string{"re:(tmp"}}},
+		{"malformed bucket pattern", PathConfig{Path: "/data", Buckets: map[string]string{"errors": "[.err"}}},
+		{"empty bucket name", PathConfig{Path: "/data", Buckets: map[string]string{"": "*.err"}}},
+		{"malformed path pattern", PathConfig{Path: "/data/partners/[a-/inbox"}},
+		{"negative expand_interval", PathConfig{Path: "/data/partners/*/inbox", ExpandInterval: -time.Minute}},
+		{"negative breakdown_depth", PathConfig{Path: "/data", BreakdownDepth: -1}},
+		{"incremental with breakdown_depth", PathConfig{Path: "/data", Incremental: true, BreakdownDepth: 1}},
+		{"incremental with buckets", PathConfig{Path: "/data", Incremental: true, Buckets: map[string]string{"errors": "*.err"}}},
+		{"watch with followed symlinks", PathConfig{Path: "/data", Watch: true, Symlinks: "follow"}},
+		{"negative expected_cadence", PathConfig{Path: "/data", ExpectedCadence: -time.Minute}},
+		{"incremental with expected_cadence", PathConfig{Path: "/data", Incremental: true, ExpectedCadence: 15 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NodeConfig{
+				Node:  NodeSettings{NodeName: "test-node"},
+				Paths: []PathConfig{tt.path},
+			}
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadNodeConfig_Partitions_AppliesDefaults(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/input"
+
+partitions:
+  - name: events
+    path: "/warehouse/events/dt={YYYY}-{MM}-{DD}"
+    deadline: 6h
+  - name: clicks
+    path: "/warehouse/clicks/dt={YYYY}-{MM}-{DD}/hr={HH}"
+    marker: "_DONE"
+    deadline: 30m
+    lookback: 48
+    check_interval: 5m
+    timezone: "Europe/Berlin"
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if len(cfg.Partitions) != 2 {
+		t.Fatalf("Expected 2 partition checks, got %d", len(cfg.Partitions))
+	}
+	events := cfg.Partitions[0]
+	if events.Marker != "_SUCCESS" || events.Lookback != 7 || events.Timezone != "UTC" {
+		t.Errorf("Expected _SUCCESS marker, lookback 7 and UTC by default, got %+v", events)
+	}
+	if events.CheckInterval != cfg.Refresh.DefaultPathScan || events.Deadline != 6*time.Hour {
+		t.Errorf("Expected default check interval and 6h deadline, got %v and %v", events.CheckInterval, events.Deadline)
+	}
+	clicks := cfg.Partitions[1]
+	if clicks.Marker != "_DONE" || clicks.Lookback != 48 || clicks.CheckInterval != 5*time.Minute || clicks.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected configured clicks settings, got %+v", clicks)
+	}
+}
+
+func TestValidateNodeConfig_InvalidPartitions_ReturnsError(t *testing.T) {
+	valid := PartitionConfig{
+		Name:          "events",
+		Path:          "/warehouse/events/dt={YYYY}-{MM}-{DD}",
+		Marker:        "_SUCCESS",
+		Deadline:      time.Hour,
+		Lookback:      7,
+		CheckInterval: time.Minute,
+		Timezone:      "UTC",
+	}
+	tests := []struct {
+		name   string
+		modify func(p *PartitionConfig)
+	}{
+		{"missing name", func(p *PartitionConfig) { p.Name = "" }},
+		{"path without day", func(p *PartitionConfig) { p.Path = "/warehouse/events/dt={YYYY}-{MM}" }},
+		{"absolute marker", func(p *PartitionConfig) { p.Marker = "/_SUCCESS" }},
+		{"negative deadline", func(p *PartitionConfig) { p.Deadline = -time.Hour }},
+		{"negative lookback", func(p *PartitionConfig) { p.Lookback = -1 }},
+		{"unknown timezone", func(p *PartitionConfig) { p.Timezone = "Mars/Olympus" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part := valid
+			tt.modify(&part)
+			cfg := &NodeConfig{
+				Node:       NodeSettings{NodeName: "test-node"},
+				Paths:      []PathConfig{{Path: "/data"}},
+				Partitions: []PartitionConfig{part},
+			}
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := &NodeConfig{
+			Node:       NodeSettings{NodeName: "test-node"},
+			Paths:      []PathConfig{{Path: "/data"}},
+			Partitions: []PartitionConfig{valid, valid},
+		}
+		if err := ValidateNodeConfig(cfg); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+}
+
+func TestLoadNodeConfig_Pipelines_AppliesDefaults(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/inbox"
+  - path: "/data/processing"
+  - path: "/data/done"
+
+pipelines:
+  - name: ingest
+    stages:
+      - name: inbox
+        path: "/data/inbox"
+      - name: processing
+        path: "/data/processing"
+      - name: done
+        path: "/data/done"
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if len(cfg.Pipelines) != 1 || len(cfg.Pipelines[0].Stages) != 3 {
+		t.Fatalf("Expected 1 pipeline with 3 stages, got %+v", cfg.Pipelines)
+	}
+	if cfg.Pipelines[0].Stages[1].Name != "processing" || cfg.Pipelines[0].Stages[1].Path != "/data/processing" {
+		t.Errorf("Expected processing stage at /data/processing, got %+v", cfg.Pipelines[0].Stages[1])
+	}
+	if cfg.Pipelines[0].SampleInterval != cfg.Refresh.DefaultPathScan {
+		t.Errorf("Expected default sample interval, got %v", cfg.Pipelines[0].SampleInterval)
+	}
+}
+
+func TestValidateNodeConfig_InvalidPipelines_ReturnsError(t *testing.T) {
+	paths := []PathConfig{
+		{Path: "/data/inbox"},
+		{Path: "/data/done"},
+		{Path: "/data/archive", Incremental: true},
+		{Path: "/data/partners/*/inbox"},
+	}
+	valid := PipelineConfig{
+		Name: "ingest",
+		Stages: []StageConfig{
+			{Name: "inbox", Path: "/data/inbox"},
+			{Name: "done", Path: "/data/done"},
+		},
+		SampleInterval: time.Minute,
+	}
+	tests := []struct {
+		name   string
+		modify func(p *PipelineConfig)
+	}{
+		{"missing name", func(p *PipelineConfig) { p.Name = "" }},
+		{"single stage", func(p *PipelineConfig) { p.Stages = p.Stages[:1] }},
+		{"zero sample interval", func(p *PipelineConfig) { p.SampleInterval = 0 }},
+		{"missing stage name", func(p *PipelineConfig) { p.Stages[1].Name = "" }},
+		{"duplicate stage name", func(p *PipelineConfig) { p.Stages[1].Name = "inbox" }},
+		{"unconfigured path", func(p *PipelineConfig) { p.Stages[1].Path = "/data/error" }},
+		{"template path", func(p *PipelineConfig) { p.Stages[1].Path = "/data/partners/*/inbox" }},
+		{"incremental path", func(p *PipelineConfig) { p.Stages[1].Path = "/data/archive" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := valid
+			pipeline.Stages = append([]StageConfig{}, valid.Stages...)
+			tt.modify(&pipeline)
+			cfg := &NodeConfig{
+				Node:      NodeSettings{NodeName: "test-node"},
+				Paths:     paths,
+				Pipelines: []PipelineConfig{pipeline},
+			}
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := &NodeConfig{
+			Node:      NodeSettings{NodeName: "test-node"},
+			Paths:     paths,
+			Pipelines: []PipelineConfig{valid, valid},
+		}
+		if err := ValidateNodeConfig(cfg); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+}
+
+func TestLoadNodeConfig_ProcessWatch_AppliesDefaults(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/input"
+
+process_watch:
+  - name: etl_worker
+    match: "etl_worker.*"
+  - name: loaders
+    match: "java .*loader\\.jar"
+    user: etl
+    min: 2
+    max: 4
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if len(cfg.ProcessWatch) != 2 {
+		t.Fatalf("Expected 2 process watches, got %d", len(cfg.ProcessWatch))
+	}
+	if w := cfg.ProcessWatch[0]; w.Min != 1 || w.Max != 0 {
+		t.Errorf("Expected at least one instance by default, got min %d max %d", w.Min, w.Max)
+	}
+	if w := cfg.ProcessWatch[1]; w.User != "etl" || w.Min != 2 || w.Max != 4 || w.Match != `java .*loader\.jar` {
+		t.Errorf("Unexpected loaders watch: %+v", w)
+	}
+}
+
+func TestValidateNodeConfig_InvalidProcessWatch_ReturnsError(t *testing.T) {
+	valid := ProcessWatchConfig{Name: "etl_worker", Match: "etl_worker.*", Min: 1}
+	tests := []struct {
+		name   string
+		modify func(w *ProcessWatchConfig)
+	}{
+		{"missing name", func(w *ProcessWatchConfig) { w.Name = "" }},
+		{"no match or user", func(w *ProcessWatchConfig) { w.Match = "" }},
+		{"invalid regex", func(w *ProcessWatchConfig) { w.Match = "etl_worker(" }},
+		{"zero min", func(w *ProcessWatchConfig) { w.Min = 0 }},
+		{"negative max", func(w *ProcessWatchConfig) { w.Max = -1 }},
+		{"max below min", func(w *ProcessWatchConfig) { w.Min = 3; w.Max = 2 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watch := valid
+			tt.modify(&watch)
+			cfg := &NodeConfig{
+				Node:         NodeSettings{NodeName: "test-node"},
+				Paths:        []PathConfig{{Path: "/data/input"}},
+				ProcessWatch: []ProcessWatchConfig{watch},
+			}
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := &NodeConfig{
+			Node:         NodeSettings{NodeName: "test-node"},
+			Paths:        []PathConfig{{Path: "/data/input"}},
+			ProcessWatch: []ProcessWatchConfig{valid, valid},
+		}
+		if err := ValidateNodeConfig(cfg); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+
+	t.Run("user only", func(t *testing.T) {
+		cfg := &NodeConfig{
+			Node:         NodeSettings{NodeName: "test-node"},
+			Paths:        []PathConfig{{Path: "/data/input"}},
+			ProcessWatch: []ProcessWatchConfig{{Name: "etl", User: "etl", Min: 1}},
+		}
+		if err := ValidateNodeConfig(cfg); err != nil {
+			t.Errorf("Expected a user-only watch to be valid, got %v", err)
+		}
+	})
+}
+
+func TestLoadNodeConfig_Disk_AppliesDefaults(t *testing.T) {
+	yamlContent := `
+node:
+  node_name: "test-node"
+
+paths:
+  - path: "/data/input"
+
+disk:
+  include: ["/", "/mnt/*"]
+  exclude: ["/mnt/scratch"]
+  types: [ext4, xfs, nfs4, cifs, fuse.s3fs]
+`
+
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "node.yaml")
+	if err := os.WriteFile(configFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadNodeConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadNodeConfig failed: %v", err)
+	}
+
+	if len(cfg.Disk.Include) != 2 || cfg.Disk.Include[1] != "/mnt/*" {
+		t.Errorf("Unexpected disk include: %v", cfg.Disk.Include)
+	}
+	if len(cfg.Disk.Exclude) != 1 || len(cfg.Disk.Types) != 5 || cfg.Disk.Types[4] != "fuse.s3fs" {
+		t.Errorf("Unexpected disk config: %+v", cfg.Disk)
+	}
+	if cfg.Disk.StatfsTimeout != 5*time.Second {
+		t.Errorf("Expected default statfs timeout of 5s, got %v", cfg.Disk.StatfsTimeout)
+	}
+}
+
+func TestValidateNodeConfig_NegativeDurations_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*NodeConfig)
+	}{
+		{"negative history_retention", func(c *NodeConfig) { c.Process.HistoryRetention = -time.Hour }},
+		{"negative deleted_files refresh", func(c *NodeConfig) { c.Refresh.DeletedFiles = -time.Minute }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NodeConfig{
+				Node:  NodeSettings{NodeName: "test-node"},
+				Paths: []PathConfig{{Path: "/data/input"}},
+			}
+			tt.modify(cfg)
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestValidateNodeConfig_InvalidDisk_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name string
+		disk DiskConfig
+	}{
+		{"invalid include", DiskConfig{Include: []string{"/mnt/["}}},
+		{"invalid exclude", DiskConfig{Exclude: []string{"/mnt/[a-"}}},
+		{"empty type", DiskConfig{Types: []string{""}}},
+		{"type with space", DiskConfig{ExcludeTypes: []string{"nfs cifs"}}},
+		{"negative timeout", DiskConfig{StatfsTimeout: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NodeConfig{
+				Node:  NodeSettings{NodeName: "test-node"},
+				Paths: []PathConfig{{Path: "/data/input"}},
+				Disk:  tt.disk,
+			}
+
+			if err := ValidateNodeConfig(cfg); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
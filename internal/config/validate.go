@@ -1,6 +1,14 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	pathcollector "github.com/etlmon/etlmon/internal/collector/path"
+)
 
 // ValidateNodeConfig validates a node configuration
 func ValidateNodeConfig(cfg *NodeConfig) error {
@@ -9,6 +17,21 @@ func ValidateNodeConfig(cfg *NodeConfig) error {
 		return fmt.Errorf("node_name is required")
 	}
 
+	// Validate disk mount selection
+	for _, pattern := range append(append([]string{}, cfg.Disk.Include...), cfg.Disk.Exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("disk: invalid mount pattern %q: %w", pattern, err)
+		}
+	}
+	for _, fsType := range append(append([]string{}, cfg.Disk.Types...), cfg.Disk.ExcludeTypes...) {
+		if fsType == "" || strings.ContainsAny(fsType, " \t") {
+			return fmt.Errorf("disk: invalid filesystem type %q", fsType)
+		}
+	}
+	if cfg.Disk.StatfsTimeout < 0 {
+		return fmt.Errorf("disk: statfs_timeout must not be negative")
+	}
+
 	// Validate paths
 	if len(cfg.Paths) == 0 {
 		return fmt.Errorf("at least one path must be configured")
@@ -18,11 +41,179 @@ func ValidateNodeConfig(cfg *NodeConfig) error {
 		if path.Path == "" {
 			return fmt.Errorf("path[%d]: path is required", i)
 		}
+		if _, err := filepath.Match(path.Path, ""); err != nil {
+			return fmt.Errorf("path[%d]: invalid path pattern %q: %w", i, path.Path, err)
+		}
+		if path.ExpandInterval < 0 {
+			return fmt.Errorf("path[%d]: expand_interval must not be negative", i)
+		}
+		if path.MaxFileAge < 0 {
+			return fmt.Errorf("path[%d]: max_file_age must not be negative", i)
+		}
+		if path.ExpectedCadence < 0 {
+			return fmt.Errorf("path[%d]: expected_cadence must not be negative", i)
+		}
+		if path.MaxFileCount < 0 {
+			return fmt.Errorf("path[%d]: max_file_count must not be negative", i)
+		}
+		if path.Workers < 0 {
+			return fmt.Errorf("path[%d]: workers must not be negative", i)
+		}
+		if path.MaxOpsPerSec < 0 {
+			return fmt.Errorf("path[%d]: max_ops_per_sec must not be negative", i)
+		}
+		if path.BreakdownDepth < 0 {
+			return fmt.Errorf("path[%d]: breakdown_depth must not be negative", i)
+		}
+		if path.FullScanInterval < 0 {
+			return fmt.Errorf("path[%d]: full_scan_interval must not be negative", i)
+		}
+		// Directory mtimes do not change when files age or are rewritten in place
+		if path.Incremental && (path.Track || path.MaxFileAge > 0 || path.ExpectedCadence > 0 || len(path.Buckets) > 0 || path.BreakdownDepth > 0) {
+			return fmt.Errorf("path[%d]: incremental cannot be combined with track, max_file_age, expected_cadence, buckets or breakdown_depth", i)
+		}
+		switch path.Symlinks {
+		case "", "count", "skip", "follow":
+		default:
+			return fmt.Errorf("path[%d]: symlinks must be count, skip or follow", i)
+		}
+		// Notifications only arrive for directories below the path itself
+		if path.Watch && path.Symlinks == "follow" {
+			return fmt.Errorf("path[%d]: watch cannot be combined with symlinks: follow", i)
+		}
+		for _, pattern := range append(append([]string{}, path.Include...), path.Exclude...) {
+			if err := validatePattern(pattern); err != nil {
+				return fmt.Errorf("path[%d]: invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+		for name, pattern := range path.Buckets {
+			if name == "" {
+				return fmt.Errorf("path[%d]: bucket name is required", i)
+			}
+			if err := validatePattern(pattern); err != nil {
+				return fmt.Errorf("path[%d]: bucket %s: invalid pattern %q: %w", i, name, pattern, err)
+			}
+		}
+	}
+
+	// Validate partition checks
+	names := make(map[string]bool)
+	for i, part := range cfg.Partitions {
+		if part.Name == "" {
+			return fmt.Errorf("partitions[%d]: name is required", i)
+		}
+		if names[part.Name] {
+			return fmt.Errorf("partitions[%d]: duplicate name %q", i, part.Name)
+		}
+		names[part.Name] = true
+		for _, token := range []string{"{YYYY}", "{MM}", "{DD}"} {
+			if !strings.Contains(part.Path, token) {
+				return fmt.Errorf("partitions[%d]: path must contain {YYYY}, {MM} and {DD}", i)
+			}
+		}
+		if part.Marker == "" || filepath.IsAbs(part.Marker) {
+			return fmt.Errorf("partitions[%d]: marker must be a path relative to the partition", i)
+		}
+		if part.Deadline < 0 {
+			return fmt.Errorf("partitions[%d]: deadline must not be negative", i)
+		}
+		if part.Lookback < 1 {
+			return fmt.Errorf("partitions[%d]: lookback must be at least 1", i)
+		}
+		if part.CheckInterval <= 0 {
+			return fmt.Errorf("partitions[%d]: check_interval must be positive", i)
+		}
+		if _, err := time.LoadLocation(part.Timezone); err != nil {
+			return fmt.Errorf("partitions[%d]: invalid timezone %q: %w", i, part.Timezone, err)
+		}
+	}
+
+	// Validate pipelines
+	paths := make(map[string]PathConfig)
+	for _, path := range cfg.Paths {
+		paths[path.Path] = path
+	}
+	names = make(map[string]bool)
+	for i, pipeline := range cfg.Pipelines {
+		if pipeline.Name == "" {
+			return fmt.Errorf("pipelines[%d]: name is required", i)
+		}
+		if names[pipeline.Name] {
+			return fmt.Errorf("pipelines[%d]: duplicate name %q", i, pipeline.Name)
+		}
+		names[pipeline.Name] = true
+		if len(pipeline.Stages) < 2 {
+			return fmt.Errorf("pipelines[%d]: at least two stages are required", i)
+		}
+		if pipeline.SampleInterval <= 0 {
+			return fmt.Errorf("pipelines[%d]: sample_interval must be positive", i)
+		}
+		stages := make(map[string]bool)
+		for j, stage := range pipeline.Stages {
+			if stage.Name == "" {
+				return fmt.Errorf("pipelines[%d].stages[%d]: name is required", i, j)
+			}
+			if stages[stage.Name] {
+				return fmt.Errorf("pipelines[%d].stages[%d]: duplicate name %q", i, j, stage.Name)
+			}
+			stages[stage.Name] = true
+			path, ok := paths[stage.Path]
+			if !ok || strings.ContainsAny(stage.Path, "*?[") {
+				return fmt.Errorf("pipelines[%d].stages[%d]: path %q is not a configured concrete path", i, j, stage.Path)
+			}
+			// Throughput comes from the tracked file events of each stage
+			if path.Incremental {
+				return fmt.Errorf("pipelines[%d].stages[%d]: path %q is incremental and cannot be tracked", i, j, stage.Path)
+			}
+		}
+	}
+
+	if cfg.Process.HistoryRetention < 0 {
+		return fmt.Errorf("process: history_retention must not be negative")
+	}
+	if cfg.Refresh.DeletedFiles < 0 {
+		return fmt.Errorf("refresh: deleted_files must not be negative")
+	}
+
+	// Validate process watches
+	names = make(map[string]bool)
+	for i, watch := range cfg.ProcessWatch {
+		if watch.Name == "" {
+			return fmt.Errorf("process_watch[%d]: name is required", i)
+		}
+		if names[watch.Name] {
+			return fmt.Errorf("process_watch[%d]: duplicate name %q", i, watch.Name)
+		}
+		names[watch.Name] = true
+		if watch.Match == "" && watch.User == "" {
+			return fmt.Errorf("process_watch[%d]: match or user is required", i)
+		}
+		if _, err := regexp.Compile(watch.Match); err != nil {
+			return fmt.Errorf("process_watch[%d]: invalid match %q: %w", i, watch.Match, err)
+		}
+		if watch.Min < 1 {
+			return fmt.Errorf("process_watch[%d]: min must be at least 1", i)
+		}
+		if watch.Max < 0 || (watch.Max > 0 && watch.Max < watch.Min) {
+			return fmt.Errorf("process_watch[%d]: max must be 0 (unbounded) or at least min", i)
+		}
 	}
 
 	return nil
 }
 
+// validatePattern checks an include/exclude pattern: a regular expression
+// when prefixed with "re:", otherwise a glob, compiled as the path walker
+// compiles it
+func validatePattern(pattern string) error {
+	if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+		_, err := regexp.Compile(expr)
+		return err
+	}
+	_, err := pathcollector.GlobToRegexp(pattern)
+	return err
+}
+
 // ValidateUIConfig validates a UI configuration
 func ValidateUIConfig(cfg *UIConfig) error {
 	// Validate nodes
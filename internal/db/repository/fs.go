@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 )
@@ -22,15 +24,19 @@ func NewFSRepository(db *sql.DB) *FSRepository {
 	var err error
 	r.stmtInsert, err = db.Prepare(`
 		INSERT OR REPLACE INTO filesystem_usage
-		(mount_point, total_bytes, used_bytes, avail_bytes, used_percent, collected_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		(mount_point, total_bytes, used_bytes, avail_bytes, used_percent,
+		 inodes_total, inodes_used, inodes_free, inodes_used_percent,
+		 status, status_reason, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		panic(fmt.Sprintf("failed to prepare insert statement: %v", err))
 	}
 
 	r.stmtGetAll, err = db.Prepare(`
-		SELECT mount_point, total_bytes, used_bytes, avail_bytes, used_percent, collected_at
+		SELECT mount_point, total_bytes, used_bytes, avail_bytes, used_percent,
+		       inodes_total, inodes_used, inodes_free, inodes_used_percent,
+		       status, status_reason, collected_at
 		FROM filesystem_usage
 		ORDER BY mount_point
 	`)
@@ -43,12 +49,22 @@ func NewFSRepository(db *sql.DB) *FSRepository {
 
 // Save inserts or updates filesystem usage record
 func (r *FSRepository) Save(ctx context.Context, usage *models.FilesystemUsage) error {
+	status := usage.Status
+	if status == "" {
+		status = models.FilesystemOK
+	}
 	_, err := r.stmtInsert.ExecContext(ctx,
 		usage.MountPoint,
 		usage.TotalBytes,
 		usage.UsedBytes,
 		usage.AvailBytes,
 		usage.UsedPercent,
+		usage.InodesTotal,
+		usage.InodesUsed,
+		usage.InodesFree,
+		usage.InodesUsedPercent,
+		status,
+		usage.StatusReason,
 		usage.CollectedAt,
 	)
 	if err != nil {
@@ -74,6 +90,12 @@ func (r *FSRepository) GetLatest(ctx context.Context) ([]*models.FilesystemUsage
 			&u.UsedBytes,
 			&u.AvailBytes,
 			&u.UsedPercent,
+			&u.InodesTotal,
+			&u.InodesUsed,
+			&u.InodesFree,
+			&u.InodesUsedPercent,
+			&u.Status,
+			&u.StatusReason,
 			&u.CollectedAt,
 		)
 		if err != nil {
@@ -89,6 +111,38 @@ func (r *FSRepository) GetLatest(ctx context.Context) ([]*models.FilesystemUsage
 	return result, nil
 }
 
+// SetFilesystemStatus records a failed collection of a mount, keeping its
+// last usage. A mount never collected successfully gets an empty row.
+func (r *FSRepository) SetFilesystemStatus(ctx context.Context, mountPoint, status, reason string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO filesystem_usage
+		(mount_point, total_bytes, used_bytes, avail_bytes, used_percent, status, status_reason, collected_at)
+		VALUES (?, 0, 0, 0, 0, ?, ?, ?)
+		ON CONFLICT(mount_point) DO UPDATE SET status = excluded.status, status_reason = excluded.status_reason
+	`, mountPoint, status, reason, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to set filesystem status: %w", err)
+	}
+	return nil
+}
+
+// RetainFilesystemMounts deletes the usage of mounts that are no longer
+// collected, e.g. after they were unmounted or excluded by config
+func (r *FSRepository) RetainFilesystemMounts(ctx context.Context, mountPoints []string) error {
+	query := "DELETE FROM filesystem_usage"
+	args := make([]any, len(mountPoints))
+	if len(mountPoints) > 0 {
+		query += " WHERE mount_point NOT IN (?" + strings.Repeat(", ?", len(mountPoints)-1) + ")"
+		for i, m := range mountPoints {
+			args[i] = m
+		}
+	}
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete stale filesystem usage: %w", err)
+	}
+	return nil
+}
+
 // ListAll returns all filesystem usage records (alias for GetLatest with empty context)
 func (r *FSRepository) ListAll() ([]models.FilesystemUsage, error) {
 	results, err := r.GetLatest(context.Background())
@@ -162,6 +162,47 @@ func TestFSRepository_GetLatest_ReturnsAllRecords(t *testing.T) {
 	}
 }
 
+func TestFSRepository_GetLatest_ReturnsInodes(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewFSRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	err := repo.Save(ctx, &models.FilesystemUsage{
+		MountPoint:        "/landing",
+		TotalBytes:        1000000,
+		UsedBytes:         200000,
+		AvailBytes:        800000,
+		UsedPercent:       20.0,
+		InodesTotal:       65536,
+		InodesUsed:        64880,
+		InodesFree:        656,
+		InodesUsedPercent: 99.0,
+		CollectedAt:       time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Execute
+	results, err := repo.GetLatest(ctx)
+
+	// Verify
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	u := results[0]
+	if u.InodesTotal != 65536 || u.InodesUsed != 64880 || u.InodesFree != 656 || u.InodesUsedPercent != 99.0 {
+		t.Errorf("Expected the inode usage to round-trip, got %+v", u)
+	}
+}
+
 func TestFSRepository_GetLatest_EmptyDatabase_ReturnsEmptySlice(t *testing.T) {
 	// Setup
 	database := setupTestDB(t)
@@ -184,6 +225,94 @@ func TestFSRepository_GetLatest_EmptyDatabase_ReturnsEmptySlice(t *testing.T) {
 	}
 }
 
+func TestFSRepository_RetainFilesystemMounts(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewFSRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	for _, mount := range []string{"/", "/data", "/mnt/nfs"} {
+		usage := &models.FilesystemUsage{MountPoint: mount, TotalBytes: 100, CollectedAt: time.Now()}
+		if err := repo.Save(ctx, usage); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	// Execute
+	if err := repo.RetainFilesystemMounts(ctx, []string{"/", "/mnt/nfs"}); err != nil {
+		t.Fatalf("RetainFilesystemMounts failed: %v", err)
+	}
+
+	// Verify
+	results, err := repo.GetLatest(ctx)
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if len(results) != 2 || results[0].MountPoint != "/" || results[1].MountPoint != "/mnt/nfs" {
+		t.Errorf("Expected / and /mnt/nfs to remain, got %+v", results)
+	}
+
+	if err := repo.RetainFilesystemMounts(ctx, nil); err != nil {
+		t.Fatalf("RetainFilesystemMounts failed: %v", err)
+	}
+	if results, _ := repo.GetLatest(ctx); len(results) != 0 {
+		t.Errorf("Expected no usage without mounts, got %d", len(results))
+	}
+}
+
+func TestFSRepository_SetFilesystemStatus_KeepsLastUsage(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewFSRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	collectedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	usage := &models.FilesystemUsage{MountPoint: "/mnt/etl", TotalBytes: 100, UsedBytes: 40, UsedPercent: 40, CollectedAt: collectedAt}
+	if err := repo.Save(ctx, usage); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Execute
+	if err := repo.SetFilesystemStatus(ctx, "/mnt/etl", models.FilesystemStale, "statfs timed out after 5s"); err != nil {
+		t.Fatalf("SetFilesystemStatus failed: %v", err)
+	}
+	if err := repo.SetFilesystemStatus(ctx, "/mnt/new", models.FilesystemStale, "statfs timed out after 5s"); err != nil {
+		t.Fatalf("SetFilesystemStatus failed: %v", err)
+	}
+
+	// Verify
+	results, err := repo.GetLatest(ctx)
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	etl := results[0]
+	if etl.Status != models.FilesystemStale || etl.StatusReason != "statfs timed out after 5s" ||
+		etl.UsedBytes != 40 || !etl.CollectedAt.Equal(collectedAt) {
+		t.Errorf("Expected the last usage marked stale, got %+v", etl)
+	}
+	if fresh := results[1]; fresh.Status != models.FilesystemStale || fresh.TotalBytes != 0 || !fresh.CollectedAt.IsZero() {
+		t.Errorf("Expected an empty stale row for a never collected mount, got %+v", fresh)
+	}
+
+	// A successful collection clears the status
+	usage.CollectedAt = collectedAt.Add(time.Minute)
+	if err := repo.Save(ctx, usage); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if results, _ := repo.GetLatest(ctx); results[0].Status != models.FilesystemOK || results[0].StatusReason != "" {
+		t.Errorf("Expected the status to be OK again, got %+v", results[0])
+	}
+}
+
 func TestFSRepository_Close_ClosesStatements(t *testing.T) {
 	// Setup
 	database := setupTestDB(t)
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// PartitionsRepository handles partition check data access
+type PartitionsRepository struct {
+	db *sql.DB
+}
+
+// NewPartitionsRepository creates a new PartitionsRepository
+func NewPartitionsRepository(db *sql.DB) *PartitionsRepository {
+	return &PartitionsRepository{db: db}
+}
+
+// SavePartitionStatus replaces the stored partitions of a check with the
+// result of its latest round, so that partitions leaving the lookback
+// window are dropped
+func (r *PartitionsRepository) SavePartitionStatus(ctx context.Context, check string, statuses []*models.PartitionStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM partition_status WHERE check_name = ?", check); err != nil {
+		return fmt.Errorf("failed to clear partitions of %s: %w", check, err)
+	}
+
+	for _, p := range statuses {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO partition_status (check_name, partition, period_start, due, status, arrived_at, checked_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, check, p.Partition, p.PeriodStart, p.Due, p.Status, p.ArrivedAt, p.CheckedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save partition status: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit partition status: %w", err)
+	}
+	return nil
+}
+
+// RetainPartitionChecks deletes the partitions of checks that are no longer configured
+func (r *PartitionsRepository) RetainPartitionChecks(ctx context.Context, checks []string) error {
+	query := "DELETE FROM partition_status"
+	args := make([]any, len(checks))
+	if len(checks) > 0 {
+		query += " WHERE check_name NOT IN (?" + strings.Repeat(", ?", len(checks)-1) + ")"
+		for i, c := range checks {
+			args[i] = c
+		}
+	}
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete unconfigured partition checks: %w", err)
+	}
+	return nil
+}
+
+// ListPartitionStatus returns the stored partitions of a check (all checks
+// if check is empty), by check and newest partition first
+func (r *PartitionsRepository) ListPartitionStatus(ctx context.Context, check string) ([]models.PartitionStatus, error) {
+	query := `
+		SELECT check_name, partition, period_start, due, status, arrived_at, checked_at
+		FROM partition_status
+	`
+	var args []any
+	if check != "" {
+		query += " WHERE check_name = ?"
+		args = append(args, check)
+	}
+	query += " ORDER BY check_name, period_start DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query partition status: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PartitionStatus
+	for rows.Next() {
+		var p models.PartitionStatus
+		var arrived sql.NullTime
+		if err := rows.Scan(&p.Check, &p.Partition, &p.PeriodStart, &p.Due, &p.Status, &arrived, &p.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan partition status row: %w", err)
+		}
+		p.ArrivedAt = nullTimePtr(arrived)
+		results = append(results, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating partition status rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// Close releases repository resources
+func (r *PartitionsRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestPartitionsRepository_SavePartitionStatus_ReplacesCheck(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPartitionsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	arrived := day.Add(26 * time.Hour)
+	partition := func(offset int, status string) *models.PartitionStatus {
+		start := day.AddDate(0, 0, offset)
+		return &models.PartitionStatus{
+			Partition:   "/data/events/dt=" + start.Format("2006-01-02"),
+			PeriodStart: start,
+			Due:         start.Add(30 * time.Hour),
+			Status:      status,
+			CheckedAt:   day.Add(48 * time.Hour),
+		}
+	}
+	old := partition(-1, models.PartitionMissing)
+	present := partition(0, models.PartitionPresent)
+	present.ArrivedAt = &arrived
+	pending := partition(1, models.PartitionPending)
+
+	// Execute
+	if err := repo.SavePartitionStatus(ctx, "events", []*models.PartitionStatus{old, present}); err != nil {
+		t.Fatalf("SavePartitionStatus failed: %v", err)
+	}
+	if err := repo.SavePartitionStatus(ctx, "clicks", []*models.PartitionStatus{partition(0, models.PartitionLate)}); err != nil {
+		t.Fatalf("SavePartitionStatus failed: %v", err)
+	}
+	// The next round no longer covers the oldest partition
+	if err := repo.SavePartitionStatus(ctx, "events", []*models.PartitionStatus{present, pending}); err != nil {
+		t.Fatalf("SavePartitionStatus failed: %v", err)
+	}
+	got, err := repo.ListPartitionStatus(ctx, "events")
+	if err != nil {
+		t.Fatalf("ListPartitionStatus failed: %v", err)
+	}
+
+	// Verify: newest first, old partition dropped
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 partitions, got %d", len(got))
+	}
+	if got[0].Status != models.PartitionPending || got[1].Status != models.PartitionPresent {
+		t.Errorf("Expected PENDING then PRESENT, got %s then %s", got[0].Status, got[1].Status)
+	}
+	if got[1].Check != "events" || got[1].ArrivedAt == nil || !got[1].ArrivedAt.Equal(arrived) {
+		t.Errorf("Expected events partition arrived at %v, got %+v", arrived, got[1])
+	}
+	if got[0].ArrivedAt != nil {
+		t.Errorf("Expected no arrival for a pending partition, got %v", got[0].ArrivedAt)
+	}
+
+	all, err := repo.ListPartitionStatus(ctx, "")
+	if err != nil {
+		t.Fatalf("ListPartitionStatus failed: %v", err)
+	}
+	if len(all) != 3 || all[0].Check != "clicks" {
+		t.Errorf("Expected 3 partitions with clicks first, got %d", len(all))
+	}
+}
+
+func TestPartitionsRepository_RetainPartitionChecks(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPartitionsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	for _, check := range []string{"events", "clicks", "orders"} {
+		p := &models.PartitionStatus{Partition: "/data/" + check, Status: models.PartitionPending, CheckedAt: time.Now()}
+		if err := repo.SavePartitionStatus(ctx, check, []*models.PartitionStatus{p}); err != nil {
+			t.Fatalf("SavePartitionStatus failed: %v", err)
+		}
+	}
+
+	// Execute
+	if err := repo.RetainPartitionChecks(ctx, []string{"events", "orders"}); err != nil {
+		t.Fatalf("RetainPartitionChecks failed: %v", err)
+	}
+
+	// Verify
+	all, err := repo.ListPartitionStatus(ctx, "")
+	if err != nil {
+		t.Fatalf("ListPartitionStatus failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Check != "events" || all[1].Check != "orders" {
+		t.Errorf("Expected events and orders to remain, got %+v", all)
+	}
+
+	if err := repo.RetainPartitionChecks(ctx, nil); err != nil {
+		t.Fatalf("RetainPartitionChecks failed: %v", err)
+	}
+	if all, _ := repo.ListPartitionStatus(ctx, ""); len(all) != 0 {
+		t.Errorf("Expected no partitions without checks, got %d", len(all))
+	}
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 )
@@ -22,15 +24,15 @@ func NewPathsRepository(db *sql.DB) *PathsRepository {
 	var err error
 	r.stmtInsert, err = db.Prepare(`
 		INSERT OR REPLACE INTO path_stats
-		(path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		(path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, fs_type, fs_ops, ops_limit, throttled_ms, scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		panic(fmt.Sprintf("failed to prepare insert statement: %v", err))
 	}
 
 	r.stmtGetAll, err = db.Prepare(`
-		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at
+		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, fs_type, fs_ops, ops_limit, throttled_ms, scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at, collected_at
 		FROM path_stats
 		ORDER BY path
 	`)
@@ -50,6 +52,23 @@ func (r *PathsRepository) Save(ctx context.Context, stats *models.PathStats) err
 		stats.ScanDurationMs,
 		stats.Status,
 		stats.ErrorMessage,
+		stats.StatusReason,
+		stats.FsType,
+		stats.FsOps,
+		stats.OpsLimit,
+		stats.ThrottledMs,
+		stats.ScanMode,
+		stats.DirsReused,
+		stats.LastFullScan,
+		encodeJSON(stats.PatternMatches),
+		encodeJSON(stats.Buckets),
+		stats.WatchState,
+		stats.WatchError,
+		stats.NewestFileAt,
+		stats.Freshness,
+		stats.DataAgeSec,
+		stats.SizeBytes,
+		stats.OldestFileAt,
 		stats.CollectedAt,
 	)
 	if err != nil {
@@ -69,6 +88,8 @@ func (r *PathsRepository) GetAll(ctx context.Context) ([]*models.PathStats, erro
 	var result []*models.PathStats
 	for rows.Next() {
 		s := &models.PathStats{}
+		var lastFull, newest, oldest sql.NullTime
+		var matches, buckets string
 		err := rows.Scan(
 			&s.Path,
 			&s.FileCount,
@@ -76,11 +97,33 @@ func (r *PathsRepository) GetAll(ctx context.Context) ([]*models.PathStats, erro
 			&s.ScanDurationMs,
 			&s.Status,
 			&s.ErrorMessage,
+			&s.StatusReason,
+			&s.FsType,
+			&s.FsOps,
+			&s.OpsLimit,
+			&s.ThrottledMs,
+			&s.ScanMode,
+			&s.DirsReused,
+			&lastFull,
+			&matches,
+			&buckets,
+			&s.WatchState,
+			&s.WatchError,
+			&newest,
+			&s.Freshness,
+			&s.DataAgeSec,
+			&s.SizeBytes,
+			&oldest,
 			&s.CollectedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan path stats row: %w", err)
 		}
+		s.LastFullScan = nullTimePtr(lastFull)
+		s.NewestFileAt = nullTimePtr(newest)
+		s.OldestFileAt = nullTimePtr(oldest)
+		decodeJSON(matches, &s.PatternMatches)
+		decodeJSON(buckets, &s.Buckets)
 		result = append(result, s)
 	}
 
@@ -94,7 +137,7 @@ func (r *PathsRepository) GetAll(ctx context.Context) ([]*models.PathStats, erro
 // ListAll returns all path statistics records (alias for GetAll with empty context)
 func (r *PathsRepository) ListAll() ([]models.PathStats, error) {
 	query := `
-		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at
+		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, fs_type, fs_ops, ops_limit, throttled_ms, scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at, collected_at
 		FROM path_stats
 		ORDER BY path
 	`
@@ -109,13 +152,23 @@ func (r *PathsRepository) ListAll() ([]models.PathStats, error) {
 	for rows.Next() {
 		var ps models.PathStats
 		var errMsg sql.NullString
+		var lastFull, newest, oldest sql.NullTime
+		var matches, buckets string
 		if err := rows.Scan(&ps.Path, &ps.FileCount, &ps.DirCount,
-			&ps.ScanDurationMs, &ps.Status, &errMsg, &ps.CollectedAt); err != nil {
+			&ps.ScanDurationMs, &ps.Status, &errMsg, &ps.StatusReason,
+			&ps.FsType, &ps.FsOps, &ps.OpsLimit, &ps.ThrottledMs,
+			&ps.ScanMode, &ps.DirsReused, &lastFull, &matches, &buckets, &ps.WatchState, &ps.WatchError,
+			&newest, &ps.Freshness, &ps.DataAgeSec, &ps.SizeBytes, &oldest, &ps.CollectedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan path stats row: %w", err)
 		}
 		if errMsg.Valid {
 			ps.ErrorMessage = errMsg.String
 		}
+		ps.LastFullScan = nullTimePtr(lastFull)
+		ps.NewestFileAt = nullTimePtr(newest)
+		ps.OldestFileAt = nullTimePtr(oldest)
+		decodeJSON(matches, &ps.PatternMatches)
+		decodeJSON(buckets, &ps.Buckets)
 		results = append(results, ps)
 	}
 
@@ -129,7 +182,7 @@ func (r *PathsRepository) ListAll() ([]models.PathStats, error) {
 // ListWithPagination returns path statistics with limit and offset
 func (r *PathsRepository) ListWithPagination(limit, offset int) ([]models.PathStats, error) {
 	query := `
-		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at
+		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, fs_type, fs_ops, ops_limit, throttled_ms, scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at, collected_at
 		FROM path_stats
 		ORDER BY path
 		LIMIT ? OFFSET ?
@@ -145,13 +198,23 @@ func (r *PathsRepository) ListWithPagination(limit, offset int) ([]models.PathSt
 	for rows.Next() {
 		var ps models.PathStats
 		var errMsg sql.NullString
+		var lastFull, newest, oldest sql.NullTime
+		var matches, buckets string
 		if err := rows.Scan(&ps.Path, &ps.FileCount, &ps.DirCount,
-			&ps.ScanDurationMs, &ps.Status, &errMsg, &ps.CollectedAt); err != nil {
+			&ps.ScanDurationMs, &ps.Status, &errMsg, &ps.StatusReason,
+			&ps.FsType, &ps.FsOps, &ps.OpsLimit, &ps.ThrottledMs,
+			&ps.ScanMode, &ps.DirsReused, &lastFull, &matches, &buckets, &ps.WatchState, &ps.WatchError,
+			&newest, &ps.Freshness, &ps.DataAgeSec, &ps.SizeBytes, &oldest, &ps.CollectedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan path stats row: %w", err)
 		}
 		if errMsg.Valid {
 			ps.ErrorMessage = errMsg.String
 		}
+		ps.LastFullScan = nullTimePtr(lastFull)
+		ps.NewestFileAt = nullTimePtr(newest)
+		ps.OldestFileAt = nullTimePtr(oldest)
+		decodeJSON(matches, &ps.PatternMatches)
+		decodeJSON(buckets, &ps.Buckets)
 		results = append(results, ps)
 	}
 
@@ -185,13 +248,15 @@ func (r *PathsRepository) GetLatestPathStats(ctx context.Context) ([]*models.Pat
 // GetPathStats retrieves statistics for a specific path
 func (r *PathsRepository) GetPathStats(ctx context.Context, path string) (*models.PathStats, error) {
 	query := `
-		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at
+		SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, fs_type, fs_ops, ops_limit, throttled_ms, scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at, collected_at
 		FROM path_stats
 		WHERE path = ?
 	`
 
 	var stats models.PathStats
 	var errMsg sql.NullString
+	var lastFull, newest, oldest sql.NullTime
+	var matches, buckets string
 
 	err := r.db.QueryRowContext(ctx, query, path).Scan(
 		&stats.Path,
@@ -200,6 +265,23 @@ func (r *PathsRepository) GetPathStats(ctx context.Context, path string) (*model
 		&stats.ScanDurationMs,
 		&stats.Status,
 		&errMsg,
+		&stats.StatusReason,
+		&stats.FsType,
+		&stats.FsOps,
+		&stats.OpsLimit,
+		&stats.ThrottledMs,
+		&stats.ScanMode,
+		&stats.DirsReused,
+		&lastFull,
+		&matches,
+		&buckets,
+		&stats.WatchState,
+		&stats.WatchError,
+		&newest,
+		&stats.Freshness,
+		&stats.DataAgeSec,
+		&stats.SizeBytes,
+		&oldest,
 		&stats.CollectedAt,
 	)
 
@@ -213,10 +295,351 @@ func (r *PathsRepository) GetPathStats(ctx context.Context, path string) (*model
 	if errMsg.Valid {
 		stats.ErrorMessage = errMsg.String
 	}
+	stats.LastFullScan = nullTimePtr(lastFull)
+	stats.NewestFileAt = nullTimePtr(newest)
+	stats.OldestFileAt = nullTimePtr(oldest)
+	decodeJSON(matches, &stats.PatternMatches)
+	decodeJSON(buckets, &stats.Buckets)
 
 	return &stats, nil
 }
 
+// SavePathViolations replaces the stored violations for a path with the given list
+func (r *PathsRepository) SavePathViolations(ctx context.Context, path string, violations []*models.PathViolation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM path_violations WHERE path = ?", path); err != nil {
+		return fmt.Errorf("failed to clear path violations for %s: %w", path, err)
+	}
+
+	for _, v := range violations {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO path_violations (path, file_path, size_bytes, mod_time, reason, detected_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, path, v.FilePath, v.SizeBytes, v.ModTime, v.Reason, v.DetectedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save path violation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit path violations: %w", err)
+	}
+	return nil
+}
+
+// ListViolations returns stored violations, oldest files first.
+// An empty path returns violations for all monitored paths.
+func (r *PathsRepository) ListViolations(ctx context.Context, path string) ([]models.PathViolation, error) {
+	query := `
+		SELECT path, file_path, size_bytes, mod_time, reason, detected_at
+		FROM path_violations
+		WHERE ? = '' OR path = ?
+		ORDER BY mod_time, file_path
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, path, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query path violations: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PathViolation
+	for rows.Next() {
+		var v models.PathViolation
+		if err := rows.Scan(&v.Path, &v.FilePath, &v.SizeBytes, &v.ModTime, &v.Reason, &v.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan path violation row: %w", err)
+		}
+		results = append(results, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating path violation rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// SavePathBreakdown replaces the stored subdirectory sizes of a path
+func (r *PathsRepository) SavePathBreakdown(ctx context.Context, path string, usage []*models.PathDirUsage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM path_breakdown WHERE path = ?", path); err != nil {
+		return fmt.Errorf("failed to clear path breakdown for %s: %w", path, err)
+	}
+
+	for _, u := range usage {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO path_breakdown (path, dir, depth, file_count, dir_count, size_bytes, collected_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, path, u.Dir, u.Depth, u.FileCount, u.DirCount, u.SizeBytes, u.CollectedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save path breakdown: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit path breakdown: %w", err)
+	}
+	return nil
+}
+
+// ListPathBreakdown returns the stored subdirectory sizes of a path,
+// shallowest first so that parents come before their children
+func (r *PathsRepository) ListPathBreakdown(ctx context.Context, path string) ([]models.PathDirUsage, error) {
+	query := `
+		SELECT path, dir, depth, file_count, dir_count, size_bytes, collected_at
+		FROM path_breakdown
+		WHERE path = ?
+		ORDER BY depth, dir
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query path breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PathDirUsage
+	for rows.Next() {
+		var u models.PathDirUsage
+		if err := rows.Scan(&u.Path, &u.Dir, &u.Depth, &u.FileCount, &u.DirCount, &u.SizeBytes, &u.CollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan path breakdown row: %w", err)
+		}
+		results = append(results, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating path breakdown rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// DeletePath removes everything stored for a path that is no longer monitored
+func (r *PathsRepository) DeletePath(ctx context.Context, path string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"path_stats", "path_violations", "path_events", "path_dir_cache", "path_breakdown"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE path = ?", path); err != nil {
+			return fmt.Errorf("failed to delete %s rows for %s: %w", table, path, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit path deletion: %w", err)
+	}
+	return nil
+}
+
+// maxPathEvents caps the number of stored events per path
+const maxPathEvents = 10000
+
+// SavePathEvents appends file events and trims each affected path to the
+// most recent maxPathEvents rows
+func (r *PathsRepository) SavePathEvents(ctx context.Context, events []*models.PathEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	paths := make(map[string]bool)
+	for _, e := range events {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO path_events (path, file_name, event_type, size_bytes, mod_time, detected_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, e.Path, e.FileName, e.EventType, e.SizeBytes, e.ModTime.UTC(), e.DetectedAt.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to save path event: %w", err)
+		}
+		paths[e.Path] = true
+	}
+
+	for path := range paths {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM path_events
+			WHERE path = ? AND id <= (
+				SELECT id FROM path_events WHERE path = ?
+				ORDER BY id DESC LIMIT 1 OFFSET ?
+			)
+		`, path, path, maxPathEvents)
+		if err != nil {
+			return fmt.Errorf("failed to trim path events for %s: %w", path, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit path events: %w", err)
+	}
+	return nil
+}
+
+// CountPathEvents returns how many files were created in and removed from a
+// tracked path by events detected after since and up to until
+func (r *PathsRepository) CountPathEvents(ctx context.Context, path string, since, until time.Time) (created, removed int64, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN event_type = ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN event_type = ? THEN 1 ELSE 0 END), 0)
+		FROM path_events
+		WHERE path = ? AND detected_at > ? AND detected_at <= ?
+	`, models.PathEventCreated, models.PathEventRemoved, path, since.UTC(), until.UTC()).Scan(&created, &removed)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count path events for %s: %w", path, err)
+	}
+	return created, removed, nil
+}
+
+// ListPathEvents returns up to limit events detected after since, oldest first.
+// When more events match, the most recent ones are returned.
+// An empty path returns events for all tracked paths.
+func (r *PathsRepository) ListPathEvents(ctx context.Context, path string, since time.Time, limit int) ([]models.PathEvent, error) {
+	query := `
+		SELECT id, path, file_name, event_type, size_bytes, mod_time, detected_at
+		FROM path_events
+		WHERE (? = '' OR path = ?) AND detected_at > ?
+		ORDER BY id DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, path, path, since.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query path events: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PathEvent
+	for rows.Next() {
+		var e models.PathEvent
+		if err := rows.Scan(&e.ID, &e.Path, &e.FileName, &e.EventType, &e.SizeBytes, &e.ModTime, &e.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan path event row: %w", err)
+		}
+		results = append(results, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating path event rows: %w", err)
+	}
+
+	// Return in chronological order
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+
+	return results, nil
+}
+
+// LoadDirCache returns the cached directory state of an incrementally scanned path
+func (r *PathsRepository) LoadDirCache(ctx context.Context, path string) ([]models.DirCacheEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT dir, mtime_ns, file_count, dir_count, rules, pattern_matches
+		FROM path_dir_cache
+		WHERE path = ?
+	`, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dir cache: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.DirCacheEntry
+	for rows.Next() {
+		var e models.DirCacheEntry
+		var mtime int64
+		var matches string
+		if err := rows.Scan(&e.Dir, &mtime, &e.FileCount, &e.DirCount, &e.Rules, &matches); err != nil {
+			return nil, fmt.Errorf("failed to scan dir cache row: %w", err)
+		}
+		e.ModTime = time.Unix(0, mtime)
+		decodeJSON(matches, &e.Matches)
+		results = append(results, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dir cache rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// UpdateDirCache upserts changed directory entries of a path and removes
+// directories that no longer exist
+func (r *PathsRepository) UpdateDirCache(ctx context.Context, path string, changed []models.DirCacheEntry, removed []string) error {
+	if len(changed) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, e := range changed {
+		_, err := tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO path_dir_cache (path, dir, mtime_ns, file_count, dir_count, rules, pattern_matches)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, path, e.Dir, e.ModTime.UnixNano(), e.FileCount, e.DirCount, e.Rules, encodeJSON(e.Matches))
+		if err != nil {
+			return fmt.Errorf("failed to save dir cache entry: %w", err)
+		}
+	}
+	for _, dir := range removed {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM path_dir_cache WHERE path = ? AND dir = ?", path, dir); err != nil {
+			return fmt.Errorf("failed to delete dir cache entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dir cache: %w", err)
+	}
+	return nil
+}
+
+// encodeJSON stores an optional list as JSON text, using "" for an empty list
+func encodeJSON[T any](v []T) string {
+	if len(v) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeJSON reads a list stored by encodeJSON, leaving v empty for "" or bad data
+func decodeJSON[T any](text string, v *[]T) {
+	if text == "" {
+		return
+	}
+	_ = json.Unmarshal([]byte(text), v)
+}
+
+// nullTimePtr converts a nullable column to an optional time
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
 // Close closes prepared statements
 func (r *PathsRepository) Close() error {
 	var errs []error
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -249,3 +250,603 @@ func TestPathsRepository_Close_ClosesStatements(t *testing.T) {
 		t.Error("Expected error when using closed statement, got nil")
 	}
 }
+
+func TestPathsRepository_Save_PersistsStatusReason(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	stats := &models.PathStats{
+		Path:         "/data/input",
+		FileCount:    120,
+		Status:       "WARNING",
+		StatusReason: "file count 120 exceeds max 100",
+		CollectedAt:  time.Now(),
+	}
+
+	// Execute
+	if err := repo.Save(ctx, stats); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	got, err := repo.GetPathStats(ctx, "/data/input")
+
+	// Verify
+	if err != nil {
+		t.Fatalf("GetPathStats failed: %v", err)
+	}
+	if got.Status != "WARNING" {
+		t.Errorf("Expected status = 'WARNING', got '%s'", got.Status)
+	}
+	if got.StatusReason != stats.StatusReason {
+		t.Errorf("Expected status_reason = '%s', got '%s'", stats.StatusReason, got.StatusReason)
+	}
+}
+
+func TestPathsRepository_Save_PersistsThrottleStats(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	stats := &models.PathStats{
+		Path:        "/mnt/filer/incoming",
+		Status:      "OK",
+		FsType:      "nfs4",
+		FsOps:       48210,
+		OpsLimit:    500,
+		ThrottledMs: 91500,
+		CollectedAt: time.Now(),
+	}
+
+	// Execute
+	if err := repo.Save(ctx, stats); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	all, err := repo.GetAll(ctx)
+
+	// Verify
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(all))
+	}
+	got := all[0]
+	if got.FsType != "nfs4" || got.FsOps != 48210 || got.OpsLimit != 500 || got.ThrottledMs != 91500 {
+		t.Errorf("Throttle stats not persisted: %+v", got)
+	}
+}
+
+func TestPathsRepository_SavePathViolations_ReplacesPreviousList(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	first := []*models.PathViolation{
+		{FilePath: "/data/input/a.csv", SizeBytes: 10, ModTime: now.Add(-3 * time.Hour), Reason: "older than 1h0m0s", DetectedAt: now},
+		{FilePath: "/data/input/b.csv", SizeBytes: 20, ModTime: now.Add(-2 * time.Hour), Reason: "older than 1h0m0s", DetectedAt: now},
+	}
+	if err := repo.SavePathViolations(ctx, "/data/input", first); err != nil {
+		t.Fatalf("SavePathViolations failed: %v", err)
+	}
+
+	// Execute: second scan finds only one stuck file
+	second := []*models.PathViolation{
+		{FilePath: "/data/input/b.csv", SizeBytes: 20, ModTime: now.Add(-2 * time.Hour), Reason: "older than 1h0m0s", DetectedAt: now},
+	}
+	if err := repo.SavePathViolations(ctx, "/data/input", second); err != nil {
+		t.Fatalf("SavePathViolations failed: %v", err)
+	}
+
+	// Verify
+	results, err := repo.ListViolations(ctx, "/data/input")
+	if err != nil {
+		t.Fatalf("ListViolations failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 violation, got %d", len(results))
+	}
+	if results[0].FilePath != "/data/input/b.csv" {
+		t.Errorf("Expected file_path = '/data/input/b.csv', got '%s'", results[0].FilePath)
+	}
+	if results[0].Path != "/data/input" {
+		t.Errorf("Expected path = '/data/input', got '%s'", results[0].Path)
+	}
+}
+
+func TestPathsRepository_ListViolations_FiltersByPath(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	repo.SavePathViolations(ctx, "/data/input", []*models.PathViolation{
+		{FilePath: "/data/input/a.csv", ModTime: now.Add(-time.Hour), Reason: "stuck", DetectedAt: now},
+	})
+	repo.SavePathViolations(ctx, "/data/outbox", []*models.PathViolation{
+		{FilePath: "/data/outbox/b.csv", ModTime: now.Add(-2 * time.Hour), Reason: "stuck", DetectedAt: now},
+	})
+
+	// Execute
+	filtered, err := repo.ListViolations(ctx, "/data/outbox")
+	if err != nil {
+		t.Fatalf("ListViolations failed: %v", err)
+	}
+	all, err := repo.ListViolations(ctx, "")
+	if err != nil {
+		t.Fatalf("ListViolations failed: %v", err)
+	}
+
+	// Verify
+	if len(filtered) != 1 || filtered[0].Path != "/data/outbox" {
+		t.Errorf("Expected only /data/outbox violations, got %+v", filtered)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 violations across paths, got %d", len(all))
+	}
+	if all[0].FilePath != "/data/outbox/b.csv" {
+		t.Errorf("Expected oldest file first, got '%s'", all[0].FilePath)
+	}
+}
+
+func TestPathsRepository_ListPathEvents_FiltersBySinceAndPath(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	err := repo.SavePathEvents(ctx, []*models.PathEvent{
+		{Path: "/data/input", FileName: "old.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now.Add(-2 * time.Hour)},
+		{Path: "/data/input", FileName: "a.csv", EventType: models.PathEventCreated, SizeBytes: 10, ModTime: now, DetectedAt: now},
+		{Path: "/data/input", FileName: "b.csv", EventType: models.PathEventRemoved, ModTime: now, DetectedAt: now},
+		{Path: "/data/outbox", FileName: "c.csv", EventType: models.PathEventModified, ModTime: now, DetectedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("SavePathEvents failed: %v", err)
+	}
+
+	// Execute
+	events, err := repo.ListPathEvents(ctx, "/data/input", now.Add(-time.Hour), 100)
+	if err != nil {
+		t.Fatalf("ListPathEvents failed: %v", err)
+	}
+	all, err := repo.ListPathEvents(ctx, "", time.Time{}, 100)
+	if err != nil {
+		t.Fatalf("ListPathEvents failed: %v", err)
+	}
+
+	// Verify
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 recent /data/input events, got %d: %+v", len(events), events)
+	}
+	if events[0].FileName != "a.csv" || events[1].FileName != "b.csv" {
+		t.Errorf("Expected chronological order a.csv, b.csv, got %s, %s", events[0].FileName, events[1].FileName)
+	}
+	if events[0].SizeBytes != 10 || events[0].EventType != models.PathEventCreated {
+		t.Errorf("Unexpected event fields: %+v", events[0])
+	}
+	if len(all) != 4 {
+		t.Errorf("Expected 4 events across paths, got %d", len(all))
+	}
+}
+
+func TestPathsRepository_CountPathEvents_CountsWindow(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	err := repo.SavePathEvents(ctx, []*models.PathEvent{
+		{Path: "/data/inbox", FileName: "old.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now.Add(-2 * time.Hour)},
+		{Path: "/data/inbox", FileName: "a.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now.Add(-time.Minute)},
+		{Path: "/data/inbox", FileName: "b.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now},
+		{Path: "/data/inbox", FileName: "c.csv", EventType: models.PathEventModified, ModTime: now, DetectedAt: now},
+		{Path: "/data/inbox", FileName: "d.csv", EventType: models.PathEventRemoved, ModTime: now, DetectedAt: now},
+		{Path: "/data/done", FileName: "d.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now},
+		{Path: "/data/inbox", FileName: "e.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now.Add(time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("SavePathEvents failed: %v", err)
+	}
+
+	// Execute
+	created, removed, err := repo.CountPathEvents(ctx, "/data/inbox", now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatalf("CountPathEvents failed: %v", err)
+	}
+
+	// Verify: the window excludes its start, includes its end and ignores modifications
+	if created != 2 || removed != 1 {
+		t.Errorf("Expected 2 created and 1 removed, got %d and %d", created, removed)
+	}
+	if created, removed, _ := repo.CountPathEvents(ctx, "/data/untracked", time.Time{}, now); created != 0 || removed != 0 {
+		t.Errorf("Expected no events for an untracked path, got %d and %d", created, removed)
+	}
+}
+
+func TestPathsRepository_ListPathEvents_LimitKeepsMostRecent(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	var batch []*models.PathEvent
+	for _, name := range []string{"1.csv", "2.csv", "3.csv"} {
+		batch = append(batch, &models.PathEvent{
+			Path: "/data/input", FileName: name, EventType: models.PathEventCreated, ModTime: now, DetectedAt: now,
+		})
+	}
+	if err := repo.SavePathEvents(ctx, batch); err != nil {
+		t.Fatalf("SavePathEvents failed: %v", err)
+	}
+
+	// Execute
+	events, err := repo.ListPathEvents(ctx, "/data/input", time.Time{}, 2)
+	if err != nil {
+		t.Fatalf("ListPathEvents failed: %v", err)
+	}
+
+	// Verify
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].FileName != "2.csv" || events[1].FileName != "3.csv" {
+		t.Errorf("Expected the two most recent events, got %s, %s", events[0].FileName, events[1].FileName)
+	}
+}
+
+func TestPathsRepository_Save_PersistsScanMode(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	lastFull := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	stats := []*models.PathStats{
+		{Path: "/archive", Status: "OK", ScanMode: models.ScanModeIncremental, DirsReused: 9800, LastFullScan: &lastFull, CollectedAt: time.Now(),
+			PatternMatches: []models.PatternMatch{{Pattern: "*.csv", Kind: models.PatternInclude, Count: 120}}},
+		{Path: "/data/input", Status: "OK", CollectedAt: time.Now()},
+	}
+
+	// Execute
+	for _, s := range stats {
+		if err := repo.Save(ctx, s); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	got, err := repo.GetPathStats(ctx, "/archive")
+	if err != nil {
+		t.Fatalf("GetPathStats failed: %v", err)
+	}
+	plain, err := repo.GetPathStats(ctx, "/data/input")
+	if err != nil {
+		t.Fatalf("GetPathStats failed: %v", err)
+	}
+
+	// Verify
+	if got.ScanMode != models.ScanModeIncremental || got.DirsReused != 9800 {
+		t.Errorf("Scan mode not persisted: %+v", got)
+	}
+	if got.LastFullScan == nil || !got.LastFullScan.Equal(lastFull) {
+		t.Errorf("LastFullScan = %v, want %v", got.LastFullScan, lastFull)
+	}
+	if len(got.PatternMatches) != 1 || got.PatternMatches[0].Count != 120 {
+		t.Errorf("PatternMatches not persisted: %+v", got.PatternMatches)
+	}
+	if plain.LastFullScan != nil || plain.ScanMode != "" {
+		t.Errorf("Expected no scan mode for a regular path, got %+v", plain)
+	}
+}
+
+func TestPathsRepository_Save_PersistsBuckets(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	buckets := []models.PathBucket{
+		{Name: "data", Pattern: "*.csv", FileCount: 40, SizeBytes: 1 << 20},
+		{Name: "errors", Pattern: "*.err", FileCount: 3, SizeBytes: 512},
+	}
+
+	// Execute
+	if err := repo.Save(ctx, &models.PathStats{Path: "/data/input", Status: "OK", Buckets: buckets, CollectedAt: time.Now()}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.Save(ctx, &models.PathStats{Path: "/data/logs", Status: "OK", CollectedAt: time.Now()}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	all, err := repo.ListAll()
+	if err != nil {
+		t.Fatalf("ListAll failed: %v", err)
+	}
+
+	// Verify
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 paths, got %d", len(all))
+	}
+	if fmt.Sprint(all[0].Buckets) != fmt.Sprint(buckets) {
+		t.Errorf("Buckets = %v, want %v", all[0].Buckets, buckets)
+	}
+	if all[1].Buckets != nil {
+		t.Errorf("Expected no buckets for /data/logs, got %v", all[1].Buckets)
+	}
+}
+
+func TestPathsRepository_Save_PersistsWatchState(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	stats := &models.PathStats{
+		Path:        "/data/hot",
+		Status:      "OK",
+		WatchState:  models.WatchFallback,
+		WatchError:  "inotify watch limit reached",
+		CollectedAt: time.Now(),
+	}
+
+	// Execute
+	if err := repo.Save(ctx, stats); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	got, err := repo.GetPathStats(ctx, "/data/hot")
+	if err != nil {
+		t.Fatalf("GetPathStats failed: %v", err)
+	}
+
+	// Verify
+	if got.WatchState != models.WatchFallback || got.WatchError != stats.WatchError {
+		t.Errorf("Expected fallback watch state with error, got %q and %q", got.WatchState, got.WatchError)
+	}
+}
+
+func TestPathsRepository_Save_PersistsFreshness(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	newest := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	fresh := &models.PathStats{
+		Path:         "/data/feed",
+		Status:       "WARNING",
+		NewestFileAt: &newest,
+		Freshness:    models.FreshnessLate,
+		DataAgeSec:   1200,
+		CollectedAt:  newest.Add(20 * time.Minute),
+	}
+	empty := &models.PathStats{
+		Path:        "/data/dead",
+		Status:      "CRITICAL",
+		Freshness:   models.FreshnessStale,
+		CollectedAt: time.Now(),
+	}
+
+	// Execute
+	for _, stats := range []*models.PathStats{fresh, empty} {
+		if err := repo.Save(ctx, stats); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+
+	// Verify
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 paths, got %d", len(all))
+	}
+	if all[0].Freshness != models.FreshnessStale || all[0].NewestFileAt != nil {
+		t.Errorf("Expected a stale path without newest file, got %q and %v", all[0].Freshness, all[0].NewestFileAt)
+	}
+	got := all[1]
+	if got.Freshness != models.FreshnessLate || got.DataAgeSec != 1200 {
+		t.Errorf("Expected LATE with age 1200s, got %q and %d", got.Freshness, got.DataAgeSec)
+	}
+	if got.NewestFileAt == nil || !got.NewestFileAt.Equal(newest) {
+		t.Errorf("Expected newest file at %v, got %v", newest, got.NewestFileAt)
+	}
+}
+
+func TestPathsRepository_SavePathBreakdown_ReplacesPreviousScan(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	first := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	second := time.Now().UTC().Truncate(time.Second)
+
+	// Execute
+	err := repo.SavePathBreakdown(ctx, "/data/input", []*models.PathDirUsage{
+		{Dir: ".", FileCount: 3, CollectedAt: first},
+		{Dir: "old", Depth: 1, FileCount: 3, CollectedAt: first},
+	})
+	if err != nil {
+		t.Fatalf("SavePathBreakdown failed: %v", err)
+	}
+	err = repo.SavePathBreakdown(ctx, "/data/input", []*models.PathDirUsage{
+		{Dir: "new/sub", Depth: 2, FileCount: 1, SizeBytes: 10, CollectedAt: second},
+		{Dir: "new", Depth: 1, FileCount: 2, DirCount: 1, SizeBytes: 30, CollectedAt: second},
+		{Dir: ".", FileCount: 2, DirCount: 2, SizeBytes: 30, CollectedAt: second},
+	})
+	if err != nil {
+		t.Fatalf("SavePathBreakdown failed: %v", err)
+	}
+	usage, err := repo.ListPathBreakdown(ctx, "/data/input")
+	if err != nil {
+		t.Fatalf("ListPathBreakdown failed: %v", err)
+	}
+	other, err := repo.ListPathBreakdown(ctx, "/data/outbox")
+	if err != nil {
+		t.Fatalf("ListPathBreakdown failed: %v", err)
+	}
+
+	// Verify
+	if len(usage) != 3 {
+		t.Fatalf("Expected 3 dirs from the latest scan, got %d", len(usage))
+	}
+	for i, dir := range []string{".", "new", "new/sub"} {
+		if usage[i].Dir != dir {
+			t.Errorf("usage[%d] = %s, want %s", i, usage[i].Dir, dir)
+		}
+	}
+	if usage[1].SizeBytes != 30 || usage[1].DirCount != 1 || !usage[1].CollectedAt.Equal(second) {
+		t.Errorf("Unexpected entry: %+v", usage[1])
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no breakdown for /data/outbox, got %d", len(other))
+	}
+}
+
+func TestPathsRepository_DeletePath_RemovesAllRows(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, path := range []string{"/data/partners/a/inbox", "/data/partners/b/inbox"} {
+		if err := repo.Save(ctx, &models.PathStats{Path: path, Status: "OK", CollectedAt: now}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := repo.SavePathViolations(ctx, path, []*models.PathViolation{{FilePath: path + "/x.csv", ModTime: now, Reason: "old", DetectedAt: now}}); err != nil {
+			t.Fatalf("SavePathViolations failed: %v", err)
+		}
+		if err := repo.SavePathEvents(ctx, []*models.PathEvent{{Path: path, FileName: "x.csv", EventType: models.PathEventCreated, ModTime: now, DetectedAt: now}}); err != nil {
+			t.Fatalf("SavePathEvents failed: %v", err)
+		}
+		if err := repo.SavePathBreakdown(ctx, path, []*models.PathDirUsage{{Dir: ".", CollectedAt: now}}); err != nil {
+			t.Fatalf("SavePathBreakdown failed: %v", err)
+		}
+		if err := repo.UpdateDirCache(ctx, path, []models.DirCacheEntry{{Dir: ".", ModTime: now}}, nil); err != nil {
+			t.Fatalf("UpdateDirCache failed: %v", err)
+		}
+	}
+
+	// Execute
+	if err := repo.DeletePath(ctx, "/data/partners/b/inbox"); err != nil {
+		t.Fatalf("DeletePath failed: %v", err)
+	}
+
+	// Verify
+	for _, table := range []string{"path_stats", "path_violations", "path_events", "path_dir_cache", "path_breakdown"} {
+		var retired, kept int
+		database.GetDB().QueryRow("SELECT COUNT(*) FROM "+table+" WHERE path = ?", "/data/partners/b/inbox").Scan(&retired)
+		database.GetDB().QueryRow("SELECT COUNT(*) FROM "+table+" WHERE path = ?", "/data/partners/a/inbox").Scan(&kept)
+		if retired != 0 || kept != 1 {
+			t.Errorf("%s: %d rows left for the retired path, %d for the kept path", table, retired, kept)
+		}
+	}
+}
+
+func TestPathsRepository_UpdateDirCache_UpsertsAndRemoves(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPathsRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	mtime := time.Unix(1760000000, 123456789)
+	initial := []models.DirCacheEntry{
+		{Dir: ".", ModTime: mtime, FileCount: 2, DirCount: 2},
+		{Dir: "a", ModTime: mtime, FileCount: 100, Rules: "r1", Matches: []int64{7, 0}},
+		{Dir: "b", ModTime: mtime, FileCount: 5},
+	}
+	if err := repo.UpdateDirCache(ctx, "/archive", initial, nil); err != nil {
+		t.Fatalf("UpdateDirCache failed: %v", err)
+	}
+	if err := repo.UpdateDirCache(ctx, "/other", initial[:1], nil); err != nil {
+		t.Fatalf("UpdateDirCache failed: %v", err)
+	}
+
+	// Execute
+	changed := []models.DirCacheEntry{{Dir: "a", ModTime: mtime.Add(time.Second), FileCount: 101}}
+	if err := repo.UpdateDirCache(ctx, "/archive", changed, []string{"b"}); err != nil {
+		t.Fatalf("UpdateDirCache failed: %v", err)
+	}
+	entries, err := repo.LoadDirCache(ctx, "/archive")
+	if err != nil {
+		t.Fatalf("LoadDirCache failed: %v", err)
+	}
+
+	// Verify
+	got := make(map[string]models.DirCacheEntry)
+	for _, e := range entries {
+		got[e.Dir] = e
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+	if !got["."].ModTime.Equal(mtime) || got["."].DirCount != 2 {
+		t.Errorf("Root entry changed: %+v", got["."])
+	}
+	if got["a"].FileCount != 101 || !got["a"].ModTime.Equal(mtime.Add(time.Second)) {
+		t.Errorf("Entry a not updated: %+v", got["a"])
+	}
+
+	if err := repo.UpdateDirCache(ctx, "/archive", initial[1:2], nil); err != nil {
+		t.Fatalf("UpdateDirCache failed: %v", err)
+	}
+	entries, err = repo.LoadDirCache(ctx, "/archive")
+	if err != nil {
+		t.Fatalf("LoadDirCache failed: %v", err)
+	}
+	for _, e := range entries {
+		if e.Dir == "a" && (e.Rules != "r1" || len(e.Matches) != 2 || e.Matches[0] != 7) {
+			t.Errorf("Rules and matches not persisted: %+v", e)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// pipelineHistory is how long pipeline samples are kept
+const pipelineHistory = 7 * 24 * time.Hour
+
+// PipelinesRepository handles pipeline stage sample data access
+type PipelinesRepository struct {
+	db *sql.DB
+}
+
+// NewPipelinesRepository creates a new PipelinesRepository
+func NewPipelinesRepository(db *sql.DB) *PipelinesRepository {
+	return &PipelinesRepository{db: db}
+}
+
+// SavePipelineSamples appends one sample per stage of a pipeline and drops
+// the pipeline's samples older than pipelineHistory
+func (r *PipelinesRepository) SavePipelineSamples(ctx context.Context, pipeline string, samples []*models.PipelineStage) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, s := range samples {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO pipeline_samples (pipeline, stage, position, path, file_count, size_bytes, oldest_file_at, arrived, departed, status, collected_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, pipeline, s.Stage, s.Position, s.Path, s.FileCount, s.SizeBytes, s.OldestFileAt, s.Arrived, s.Departed, s.Status, s.CollectedAt.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to save pipeline sample: %w", err)
+		}
+	}
+
+	cutoff := samples[0].CollectedAt.Add(-pipelineHistory).UTC()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pipeline_samples WHERE pipeline = ? AND collected_at < ?", pipeline, cutoff); err != nil {
+		return fmt.Errorf("failed to trim pipeline samples for %s: %w", pipeline, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pipeline samples: %w", err)
+	}
+	return nil
+}
+
+// RetainPipelines deletes the samples of pipelines that are no longer configured
+func (r *PipelinesRepository) RetainPipelines(ctx context.Context, pipelines []string) error {
+	query := "DELETE FROM pipeline_samples"
+	args := make([]any, len(pipelines))
+	if len(pipelines) > 0 {
+		query += " WHERE pipeline NOT IN (?" + strings.Repeat(", ?", len(pipelines)-1) + ")"
+		for i, p := range pipelines {
+			args[i] = p
+		}
+	}
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete unconfigured pipelines: %w", err)
+	}
+	return nil
+}
+
+// LatestPipelines returns the most recent sample of every stage of a
+// pipeline (all pipelines if name is empty), ordered by pipeline name
+func (r *PipelinesRepository) LatestPipelines(ctx context.Context, name string) ([]models.Pipeline, error) {
+	samples, err := r.querySamples(ctx, `
+		WHERE (? = '' OR pipeline = ?)
+		AND collected_at = (SELECT MAX(collected_at) FROM pipeline_samples p WHERE p.pipeline = pipeline_samples.pipeline)
+		ORDER BY pipeline, position
+	`, name, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.Pipeline
+	for _, s := range samples {
+		if len(result) == 0 || result[len(result)-1].Name != s.Pipeline {
+			result = append(result, models.Pipeline{Name: s.Pipeline})
+		}
+		last := &result[len(result)-1]
+		last.Stages = append(last.Stages, s)
+	}
+	return result, nil
+}
+
+// ListPipelineSamples returns the samples of a pipeline (all pipelines if
+// name is empty) taken after since, oldest first and in stage order
+func (r *PipelinesRepository) ListPipelineSamples(ctx context.Context, name string, since time.Time) ([]models.PipelineStage, error) {
+	return r.querySamples(ctx, `
+		WHERE (? = '' OR pipeline = ?) AND collected_at > ?
+		ORDER BY collected_at, pipeline, position
+	`, name, name, since.UTC())
+}
+
+// querySamples selects pipeline samples with the given filter and ordering
+func (r *PipelinesRepository) querySamples(ctx context.Context, where string, args ...any) ([]models.PipelineStage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pipeline, stage, position, path, file_count, size_bytes, oldest_file_at, arrived, departed, status, collected_at
+		FROM pipeline_samples
+	`+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pipeline samples: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PipelineStage
+	for rows.Next() {
+		var s models.PipelineStage
+		var oldest sql.NullTime
+		if err := rows.Scan(&s.Pipeline, &s.Stage, &s.Position, &s.Path, &s.FileCount, &s.SizeBytes,
+			&oldest, &s.Arrived, &s.Departed, &s.Status, &s.CollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pipeline sample row: %w", err)
+		}
+		s.OldestFileAt = nullTimePtr(oldest)
+		if s.OldestFileAt != nil {
+			s.OldestAgeSec = int64(max(s.CollectedAt.Sub(*s.OldestFileAt), 0) / time.Second)
+		}
+		results = append(results, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pipeline sample rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// Close releases repository resources
+func (r *PipelinesRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// pipelineRound builds one sample per stage of the ingest pipeline
+func pipelineRound(at time.Time, inbox, done int64) []*models.PipelineStage {
+	oldest := at.Add(-10 * time.Minute)
+	return []*models.PipelineStage{
+		{Stage: "inbox", Position: 0, Path: "/data/inbox", FileCount: inbox, SizeBytes: inbox * 1024, OldestFileAt: &oldest, Arrived: 5, Departed: 3, Status: "OK", CollectedAt: at},
+		{Stage: "done", Position: 1, Path: "/data/done", FileCount: done, Arrived: 3, Status: "OK", CollectedAt: at},
+	}
+}
+
+func TestPipelinesRepository_LatestPipelines_ReturnsLastRound(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPipelinesRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	// Execute
+	for i, round := range [][]*models.PipelineStage{pipelineRound(now.Add(-time.Minute), 10, 1), pipelineRound(now, 12, 4)} {
+		if err := repo.SavePipelineSamples(ctx, "ingest", round); err != nil {
+			t.Fatalf("SavePipelineSamples %d failed: %v", i, err)
+		}
+	}
+	if err := repo.SavePipelineSamples(ctx, "export", pipelineRound(now, 1, 1)); err != nil {
+		t.Fatalf("SavePipelineSamples failed: %v", err)
+	}
+	pipelines, err := repo.LatestPipelines(ctx, "")
+	if err != nil {
+		t.Fatalf("LatestPipelines failed: %v", err)
+	}
+
+	// Verify
+	if len(pipelines) != 2 || pipelines[0].Name != "export" || pipelines[1].Name != "ingest" {
+		t.Fatalf("Expected export and ingest, got %+v", pipelines)
+	}
+	ingest := pipelines[1]
+	if len(ingest.Stages) != 2 || ingest.Stages[0].Stage != "inbox" || ingest.Stages[1].Stage != "done" {
+		t.Fatalf("Expected inbox then done, got %+v", ingest.Stages)
+	}
+	inbox := ingest.Stages[0]
+	if inbox.FileCount != 12 || inbox.SizeBytes != 12*1024 || inbox.Arrived != 5 || inbox.Departed != 3 {
+		t.Errorf("Expected the latest inbox sample, got %+v", inbox)
+	}
+	if inbox.OldestAgeSec != 600 {
+		t.Errorf("Expected oldest file age 600s, got %d", inbox.OldestAgeSec)
+	}
+	if ingest.Stages[1].OldestFileAt != nil || ingest.Stages[1].OldestAgeSec != 0 {
+		t.Errorf("Expected no oldest file for done, got %+v", ingest.Stages[1])
+	}
+
+	only, err := repo.LatestPipelines(ctx, "ingest")
+	if err != nil {
+		t.Fatalf("LatestPipelines failed: %v", err)
+	}
+	if len(only) != 1 || only[0].Name != "ingest" {
+		t.Errorf("Expected only ingest, got %+v", only)
+	}
+}
+
+func TestPipelinesRepository_ListPipelineSamples_FiltersAndTrims(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPipelinesRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now.Add(-8 * 24 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Hour), now} {
+		if err := repo.SavePipelineSamples(ctx, "ingest", pipelineRound(at, 1, 1)); err != nil {
+			t.Fatalf("SavePipelineSamples failed: %v", err)
+		}
+	}
+
+	// Execute
+	recent, err := repo.ListPipelineSamples(ctx, "ingest", now.Add(-90*time.Minute))
+	if err != nil {
+		t.Fatalf("ListPipelineSamples failed: %v", err)
+	}
+	all, err := repo.ListPipelineSamples(ctx, "", time.Time{})
+	if err != nil {
+		t.Fatalf("ListPipelineSamples failed: %v", err)
+	}
+
+	// Verify: two rounds of two stages, oldest first; the 8 day old round is trimmed
+	if len(recent) != 4 {
+		t.Fatalf("Expected 4 recent samples, got %d", len(recent))
+	}
+	if !recent[0].CollectedAt.Equal(now.Add(-time.Hour)) || recent[0].Stage != "inbox" || recent[1].Stage != "done" {
+		t.Errorf("Expected the -1h round first in stage order, got %+v", recent[:2])
+	}
+	if len(all) != 6 {
+		t.Errorf("Expected 6 samples after trimming, got %d", len(all))
+	}
+}
+
+func TestPipelinesRepository_RetainPipelines(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewPipelinesRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, name := range []string{"ingest", "export"} {
+		if err := repo.SavePipelineSamples(ctx, name, pipelineRound(now, 1, 1)); err != nil {
+			t.Fatalf("SavePipelineSamples failed: %v", err)
+		}
+	}
+
+	// Execute
+	if err := repo.RetainPipelines(ctx, []string{"ingest"}); err != nil {
+		t.Fatalf("RetainPipelines failed: %v", err)
+	}
+
+	// Verify
+	pipelines, err := repo.LatestPipelines(ctx, "")
+	if err != nil {
+		t.Fatalf("LatestPipelines failed: %v", err)
+	}
+	if len(pipelines) != 1 || pipelines[0].Name != "ingest" {
+		t.Errorf("Expected only ingest to remain, got %+v", pipelines)
+	}
+}
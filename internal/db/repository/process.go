@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 )
@@ -23,15 +24,19 @@ func NewProcessRepository(db *sql.DB) *ProcessRepository {
 	var err error
 	r.stmtInsert, err = db.Prepare(`
 		INSERT OR REPLACE INTO process_stats
-		(pid, name, user, cpu_percent, mem_rss, status, elapsed, collected_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		(pid, name, user, cpu_percent, mem_rss, status, elapsed,
+		 cmdline, ppid, threads, start_time, read_bytes, write_bytes,
+		 cgroup, unit, container_id, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		panic(fmt.Sprintf("failed to prepare process insert statement: %v", err))
 	}
 
 	r.stmtGetAll, err = db.Prepare(`
-		SELECT pid, name, user, cpu_percent, mem_rss, status, elapsed, collected_at
+		SELECT pid, name, user, cpu_percent, mem_rss, status, elapsed,
+		       cmdline, ppid, threads, start_time, read_bytes, write_bytes,
+		       cgroup, unit, container_id, collected_at
 		FROM process_stats
 		ORDER BY cpu_percent DESC
 	`)
@@ -57,6 +62,15 @@ func (r *ProcessRepository) SaveProcessInfo(ctx context.Context, info *models.Pr
 		info.MemRSS,
 		info.Status,
 		info.Elapsed,
+		info.Cmdline,
+		info.PPID,
+		info.Threads,
+		info.StartTime,
+		info.ReadBytes,
+		info.WriteBytes,
+		info.Cgroup,
+		info.Unit,
+		info.ContainerID,
 		info.CollectedAt,
 	)
 	if err != nil {
@@ -76,6 +90,7 @@ func (r *ProcessRepository) GetLatestProcessInfo(ctx context.Context) ([]*models
 	var result []*models.ProcessInfo
 	for rows.Next() {
 		p := &models.ProcessInfo{}
+		var startTime sql.NullTime
 		err := rows.Scan(
 			&p.PID,
 			&p.Name,
@@ -84,11 +99,21 @@ func (r *ProcessRepository) GetLatestProcessInfo(ctx context.Context) ([]*models
 			&p.MemRSS,
 			&p.Status,
 			&p.Elapsed,
+			&p.Cmdline,
+			&p.PPID,
+			&p.Threads,
+			&startTime,
+			&p.ReadBytes,
+			&p.WriteBytes,
+			&p.Cgroup,
+			&p.Unit,
+			&p.ContainerID,
 			&p.CollectedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan process info row: %w", err)
 		}
+		p.StartTime = nullTimePtr(startTime)
 		result = append(result, p)
 	}
 	if err = rows.Err(); err != nil {
@@ -116,6 +141,383 @@ func (r *ProcessRepository) ClearAll(ctx context.Context) error {
 	return err
 }
 
+// SaveProcessWatches replaces the stored watches with the latest
+// evaluation, so that watches removed from the config are dropped along
+// with their events and samples
+func (r *ProcessRepository) SaveProcessWatches(ctx context.Context, watches []*models.ProcessWatch) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_watches"); err != nil {
+		return fmt.Errorf("failed to clear process watches: %w", err)
+	}
+
+	for _, w := range watches {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO process_watches
+			(name, pattern, user, min_count, max_count, count, pids, status, reason, checked_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, w.Name, w.Match, w.User, w.Min, w.Max, w.Count, encodeJSON(w.PIDs), w.Status, w.Reason, w.CheckedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save process watch %s: %w", w.Name, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_events WHERE watch NOT IN (SELECT name FROM process_watches)"); err != nil {
+		return fmt.Errorf("failed to clear events of removed process watches: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_watch_samples WHERE watch NOT IN (SELECT name FROM process_watches)"); err != nil {
+		return fmt.Errorf("failed to clear samples of removed process watches: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit process watches: %w", err)
+	}
+	return nil
+}
+
+// ListProcessWatches returns the latest evaluation of every process watch
+// by name, with the restarts detected after restartsSince
+func (r *ProcessRepository) ListProcessWatches(ctx context.Context, restartsSince time.Time) ([]*models.ProcessWatch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT name, pattern, user, min_count, max_count, count, pids, status, reason, checked_at
+		FROM process_watches
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process watches: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*models.ProcessWatch
+	byName := make(map[string]*models.ProcessWatch)
+	for rows.Next() {
+		w := &models.ProcessWatch{}
+		var pids string
+		if err := rows.Scan(&w.Name, &w.Match, &w.User, &w.Min, &w.Max, &w.Count, &pids, &w.Status, &w.Reason, &w.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan process watch row: %w", err)
+		}
+		decodeJSON(pids, &w.PIDs)
+		if w.PIDs == nil {
+			w.PIDs = []int{}
+		}
+		result = append(result, w)
+		byName[w.Name] = w
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process watch rows: %w", err)
+	}
+	rows.Close()
+
+	// Count the restarts of each watch
+	restarts, err := r.db.QueryContext(ctx, `
+		SELECT watch, detected_at
+		FROM process_events
+		WHERE restart = 1 AND detected_at > ?
+		ORDER BY id
+	`, restartsSince.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process restarts: %w", err)
+	}
+	defer restarts.Close()
+
+	for restarts.Next() {
+		var name string
+		var at time.Time
+		if err := restarts.Scan(&name, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan process restart row: %w", err)
+		}
+		if w, ok := byName[name]; ok {
+			w.Restarts++
+			w.LastRestartAt = &at
+		}
+	}
+	if err := restarts.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process restart rows: %w", err)
+	}
+	return result, nil
+}
+
+// SaveProcessTree replaces the stored process tree with every process of
+// the latest collection
+func (r *ProcessRepository) SaveProcessTree(ctx context.Context, procs []*models.ProcessInfo) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_tree"); err != nil {
+		return fmt.Errorf("failed to clear process tree: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR REPLACE INTO process_tree (pid, ppid, name, user, cmdline, cpu_percent, mem_rss, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare process tree insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range procs {
+		if _, err := stmt.ExecContext(ctx, p.PID, p.PPID, p.Name, p.User, p.Cmdline, p.CPUPercent, p.MemRSS, p.CollectedAt); err != nil {
+			return fmt.Errorf("failed to save process %d to tree: %w", p.PID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit process tree: %w", err)
+	}
+	return nil
+}
+
+// ListProcessTree returns every process of the latest collection by PID
+func (r *ProcessRepository) ListProcessTree(ctx context.Context) ([]*models.ProcessInfo, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pid, ppid, name, user, cmdline, cpu_percent, mem_rss, collected_at
+		FROM process_tree
+		ORDER BY pid
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process tree: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*models.ProcessInfo
+	for rows.Next() {
+		p := &models.ProcessInfo{}
+		if err := rows.Scan(&p.PID, &p.PPID, &p.Name, &p.User, &p.Cmdline, &p.CPUPercent, &p.MemRSS, &p.CollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan process tree row: %w", err)
+		}
+		result = append(result, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process tree rows: %w", err)
+	}
+	return result, nil
+}
+
+// SaveProcessGroups replaces the stored units and containers with those of
+// the latest collection
+func (r *ProcessRepository) SaveProcessGroups(ctx context.Context, groups []*models.ProcessGroup) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_groups"); err != nil {
+		return fmt.Errorf("failed to clear process groups: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR REPLACE INTO process_groups
+		(kind, name, cgroup, processes, cpu_percent, mem_rss, memory_current, cgroup_cpu_percent, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare process group insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, g := range groups {
+		if _, err := stmt.ExecContext(ctx, g.Kind, g.Name, g.Cgroup, g.Processes, g.CPUPercent, g.MemRSS,
+			g.MemoryCurrent, g.CgroupCPUPercent, g.CollectedAt); err != nil {
+			return fmt.Errorf("failed to save process group %s: %w", g.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit process groups: %w", err)
+	}
+	return nil
+}
+
+// ListProcessGroups returns the units and containers of the latest
+// collection, busiest first (only those of kind if it is not empty)
+func (r *ProcessRepository) ListProcessGroups(ctx context.Context, kind string) ([]*models.ProcessGroup, error) {
+	query := `
+		SELECT kind, name, cgroup, processes, cpu_percent, mem_rss, memory_current, cgroup_cpu_percent, collected_at
+		FROM process_groups`
+	var args []any
+	if kind != "" {
+		query += " WHERE kind = ?"
+		args = append(args, kind)
+	}
+	query += " ORDER BY cpu_percent DESC, name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process groups: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*models.ProcessGroup
+	for rows.Next() {
+		g := &models.ProcessGroup{}
+		if err := rows.Scan(&g.Kind, &g.Name, &g.Cgroup, &g.Processes, &g.CPUPercent, &g.MemRSS,
+			&g.MemoryCurrent, &g.CgroupCPUPercent, &g.CollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan process group row: %w", err)
+		}
+		result = append(result, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process group rows: %w", err)
+	}
+	return result, nil
+}
+
+// SaveProcessWatchSamples appends one sample per watch and drops samples
+// older than retention
+func (r *ProcessRepository) SaveProcessWatchSamples(ctx context.Context, samples []*models.ProcessWatchSample, retention time.Duration) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, s := range samples {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO process_watch_samples (watch, count, cpu_percent, mem_rss, threads, open_fds, collected_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, s.Watch, s.Count, s.CPUPercent, s.MemRSS, s.Threads, s.OpenFDs, s.CollectedAt.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to save process watch sample: %w", err)
+		}
+	}
+
+	cutoff := samples[0].CollectedAt.Add(-retention).UTC()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM process_watch_samples WHERE collected_at < ?", cutoff); err != nil {
+		return fmt.Errorf("failed to trim process watch samples: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit process watch samples: %w", err)
+	}
+	return nil
+}
+
+// ListProcessWatchSamples returns the samples of a watch (all watches if
+// watch is empty) taken after since, oldest first
+func (r *ProcessRepository) ListProcessWatchSamples(ctx context.Context, watch string, since time.Time) ([]models.ProcessWatchSample, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT watch, count, cpu_percent, mem_rss, threads, open_fds, collected_at
+		FROM process_watch_samples
+		WHERE (? = '' OR watch = ?) AND collected_at > ?
+		ORDER BY collected_at, watch
+	`, watch, watch, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process watch samples: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.ProcessWatchSample
+	for rows.Next() {
+		var s models.ProcessWatchSample
+		if err := rows.Scan(&s.Watch, &s.Count, &s.CPUPercent, &s.MemRSS, &s.Threads, &s.OpenFDs, &s.CollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan process watch sample row: %w", err)
+		}
+		results = append(results, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process watch sample rows: %w", err)
+	}
+	return results, nil
+}
+
+// maxProcessEvents caps the number of stored events per watch
+const maxProcessEvents = 1000
+
+// SaveProcessEvents records start and exit events, keeping the newest
+// maxProcessEvents of each watch
+func (r *ProcessRepository) SaveProcessEvents(ctx context.Context, events []*models.ProcessEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	watches := make(map[string]bool)
+	for _, e := range events {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO process_events (watch, pid, event_type, restart, start_time, runtime_sec, cmdline, detected_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, e.Watch, e.PID, e.EventType, e.Restart, e.StartTime, e.RuntimeSec, e.Cmdline, e.DetectedAt.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to save process event: %w", err)
+		}
+		watches[e.Watch] = true
+	}
+
+	for watch := range watches {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM process_events
+			WHERE watch = ? AND id <= (
+				SELECT id FROM process_events WHERE watch = ?
+				ORDER BY id DESC LIMIT 1 OFFSET ?
+			)
+		`, watch, watch, maxProcessEvents)
+		if err != nil {
+			return fmt.Errorf("failed to trim process events for %s: %w", watch, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit process events: %w", err)
+	}
+	return nil
+}
+
+// ListProcessEvents returns up to limit of the most recent events detected
+// after since, for one watch or all watches if watch is empty, in
+// chronological order
+func (r *ProcessRepository) ListProcessEvents(ctx context.Context, watch string, since time.Time, limit int) ([]models.ProcessEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, watch, pid, event_type, restart, start_time, runtime_sec, cmdline, detected_at
+		FROM process_events
+		WHERE (? = '' OR watch = ?) AND detected_at > ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, watch, watch, since.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query process events: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.ProcessEvent
+	for rows.Next() {
+		var e models.ProcessEvent
+		var start sql.NullTime
+		if err := rows.Scan(&e.ID, &e.Watch, &e.PID, &e.EventType, &e.Restart, &start, &e.RuntimeSec, &e.Cmdline, &e.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan process event row: %w", err)
+		}
+		e.StartTime = nullTimePtr(start)
+		results = append(results, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating process event rows: %w", err)
+	}
+
+	// Return in chronological order
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+
+	return results, nil
+}
+
 // Close closes prepared statements
 func (r *ProcessRepository) Close() error {
 	var errs []error
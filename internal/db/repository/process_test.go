@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+func TestProcessRepository_SaveProcessInfo_PersistsProcDetails(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-26 * time.Hour)
+	java := &models.ProcessInfo{
+		PID:         4242,
+		PPID:        1,
+		Name:        "java",
+		Cmdline:     "java -Xmx4g -jar /opt/etl/loader.jar --job orders",
+		User:        "etl",
+		CPUPercent:  37.5,
+		MemRSS:      2 << 30,
+		Threads:     48,
+		Status:      "sleeping",
+		StartTime:   &started,
+		Elapsed:     "1-02:00:00",
+		ReadBytes:   1 << 20,
+		WriteBytes:  3 << 20,
+		Cgroup:      "/system.slice/etl-loader.service",
+		Unit:        "etl-loader.service",
+		CollectedAt: now,
+	}
+	kthread := &models.ProcessInfo{PID: 2, Name: "kthreadd", User: "root", Status: "sleeping", CollectedAt: now}
+
+	// Execute
+	for _, p := range []*models.ProcessInfo{java, kthread} {
+		if err := repo.SaveProcessInfo(ctx, p); err != nil {
+			t.Fatalf("SaveProcessInfo failed: %v", err)
+		}
+	}
+	procs, err := repo.GetLatestProcessInfo(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetLatestProcessInfo failed: %v", err)
+	}
+	if len(procs) != 2 {
+		t.Fatalf("Expected 2 processes, got %d", len(procs))
+	}
+	got := procs[0]
+	if got.Cmdline != java.Cmdline || got.PPID != 1 || got.Threads != 48 {
+		t.Errorf("Unexpected process details: %+v", got)
+	}
+	if got.ReadBytes != 1<<20 || got.WriteBytes != 3<<20 {
+		t.Errorf("Expected 1MiB read and 3MiB written, got %d and %d", got.ReadBytes, got.WriteBytes)
+	}
+	if got.StartTime == nil || !got.StartTime.Equal(started) {
+		t.Errorf("Expected start time %v, got %v", started, got.StartTime)
+	}
+	if got.Cgroup != java.Cgroup || got.Unit != "etl-loader.service" || got.ContainerID != "" {
+		t.Errorf("Unexpected cgroup membership: %q %q %q", got.Cgroup, got.Unit, got.ContainerID)
+	}
+	if procs[1].StartTime != nil {
+		t.Errorf("Expected no start time, got %v", procs[1].StartTime)
+	}
+}
+
+func TestProcessRepository_SaveProcessWatches_ReplacesWatches(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	loaders := &models.ProcessWatch{
+		Name: "loaders", Match: `loader\.jar`, User: "etl", Min: 2, Max: 4,
+		Count: 1, PIDs: []int{4242}, Status: models.ProcessWatchDegraded,
+		Reason: "1 running, expected at least 2", CheckedAt: now,
+	}
+	scheduler := &models.ProcessWatch{
+		Name: "scheduler", Match: "scheduler.py", Min: 1,
+		Status: models.ProcessWatchDown, Reason: "no matching process", CheckedAt: now,
+	}
+
+	// Execute
+	if err := repo.SaveProcessWatches(ctx, []*models.ProcessWatch{scheduler, loaders}); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+	if err := repo.SaveProcessWatches(ctx, []*models.ProcessWatch{scheduler, loaders}); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+	watches, err := repo.ListProcessWatches(ctx, now.Add(-24*time.Hour))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListProcessWatches failed: %v", err)
+	}
+	if len(watches) != 2 || watches[0].Name != "loaders" {
+		t.Fatalf("Expected 2 watches ordered by name, got %+v", watches)
+	}
+	got := watches[0]
+	if got.User != "etl" || got.Min != 2 || got.Max != 4 || got.Count != 1 || got.Status != models.ProcessWatchDegraded {
+		t.Errorf("Unexpected watch: %+v", got)
+	}
+	if len(got.PIDs) != 1 || got.PIDs[0] != 4242 {
+		t.Errorf("Expected PID 4242, got %v", got.PIDs)
+	}
+	if watches[1].PIDs == nil || len(watches[1].PIDs) != 0 {
+		t.Errorf("Expected an empty PID list for a DOWN watch, got %v", watches[1].PIDs)
+	}
+
+	// Watches removed from the config are dropped
+	if err := repo.SaveProcessWatches(ctx, nil); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+	if watches, _ := repo.ListProcessWatches(ctx, now.Add(-24*time.Hour)); len(watches) != 0 {
+		t.Errorf("Expected no watches, got %d", len(watches))
+	}
+}
+
+func TestProcessRepository_ProcessEvents_CountsRestarts(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-90 * time.Second)
+	restarted := now.Add(-10 * time.Second)
+	watches := []*models.ProcessWatch{
+		{Name: "etl_worker", Min: 1, Count: 1, PIDs: []int{21}, Status: models.ProcessWatchUp, CheckedAt: now},
+		{Name: "scheduler", Min: 1, Count: 1, PIDs: []int{30}, Status: models.ProcessWatchUp, CheckedAt: now},
+	}
+	if err := repo.SaveProcessWatches(ctx, watches); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+
+	// Execute
+	events := []*models.ProcessEvent{
+		{Watch: "etl_worker", PID: 20, EventType: models.ProcessEventExit, StartTime: &started, RuntimeSec: 80, Cmdline: "etl_worker", DetectedAt: now.Add(-5 * time.Hour)},
+		{Watch: "etl_worker", PID: 21, EventType: models.ProcessEventStart, Restart: true, StartTime: &restarted, DetectedAt: now.Add(-5 * time.Hour)},
+		{Watch: "etl_worker", PID: 21, EventType: models.ProcessEventExit, StartTime: &restarted, RuntimeSec: 5, DetectedAt: now.Add(-time.Hour)},
+		{Watch: "etl_worker", PID: 22, EventType: models.ProcessEventStart, Restart: true, DetectedAt: now.Add(-time.Hour)},
+		{Watch: "etl_worker", PID: 23, EventType: models.ProcessEventStart, Restart: true, DetectedAt: now.Add(-30 * time.Hour)},
+		{Watch: "scheduler", PID: 30, EventType: models.ProcessEventStart, DetectedAt: now.Add(-time.Minute)},
+	}
+	if err := repo.SaveProcessEvents(ctx, events); err != nil {
+		t.Fatalf("SaveProcessEvents failed: %v", err)
+	}
+	listed, err := repo.ListProcessWatches(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("ListProcessWatches failed: %v", err)
+	}
+
+	// Assert
+	worker := listed[0]
+	if worker.Restarts != 2 {
+		t.Errorf("Expected 2 restarts in the last 24h, got %d", worker.Restarts)
+	}
+	if worker.LastRestartAt == nil || !worker.LastRestartAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected the last restart an hour ago, got %v", worker.LastRestartAt)
+	}
+	if listed[1].Restarts != 0 || listed[1].LastRestartAt != nil {
+		t.Errorf("Expected a plain start not to count as a restart, got %+v", listed[1])
+	}
+
+	history, err := repo.ListProcessEvents(ctx, "etl_worker", now.Add(-24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("ListProcessEvents failed: %v", err)
+	}
+	if len(history) != 4 || history[0].PID != 20 || history[3].PID != 22 {
+		t.Fatalf("Expected the 4 recent worker events in order, got %+v", history)
+	}
+	if history[0].RuntimeSec != 80 || history[0].StartTime == nil || !history[0].StartTime.Equal(started) {
+		t.Errorf("Unexpected exit event: %+v", history[0])
+	}
+	if !history[1].Restart || history[0].Restart {
+		t.Errorf("Expected only the start to be a restart, got %+v and %+v", history[0], history[1])
+	}
+
+	// Events of removed watches are dropped with them
+	if err := repo.SaveProcessWatches(ctx, watches[1:]); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+	if history, _ := repo.ListProcessEvents(ctx, "", time.Time{}, 10); len(history) != 1 || history[0].Watch != "scheduler" {
+		t.Errorf("Expected only the scheduler event to remain, got %+v", history)
+	}
+}
+
+func TestProcessRepository_SaveProcessTree_ReplacesSnapshot(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	snapshot := []*models.ProcessInfo{
+		{PID: 300, PPID: 1, Name: "spark-submit", User: "etl", Cmdline: "spark-submit --class Job job.jar", CPUPercent: 2, MemRSS: 1 << 20, CollectedAt: now},
+		{PID: 301, PPID: 300, Name: "java", User: "etl", CPUPercent: 80, MemRSS: 4 << 30, CollectedAt: now},
+	}
+
+	// Execute
+	if err := repo.SaveProcessTree(ctx, []*models.ProcessInfo{{PID: 99, Name: "gone", CollectedAt: now}}); err != nil {
+		t.Fatalf("SaveProcessTree failed: %v", err)
+	}
+	if err := repo.SaveProcessTree(ctx, snapshot); err != nil {
+		t.Fatalf("SaveProcessTree failed: %v", err)
+	}
+	procs, err := repo.ListProcessTree(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListProcessTree failed: %v", err)
+	}
+	if len(procs) != 2 {
+		t.Fatalf("Expected the latest snapshot only, got %d processes", len(procs))
+	}
+	if procs[1].PID != 301 || procs[1].PPID != 300 || procs[1].MemRSS != 4<<30 || procs[1].CPUPercent != 80 {
+		t.Errorf("Unexpected child process: %+v", procs[1])
+	}
+	if procs[0].Cmdline != "spark-submit --class Job job.jar" || procs[0].User != "etl" {
+		t.Errorf("Unexpected parent process: %+v", procs[0])
+	}
+}
+
+func TestProcessRepository_SaveProcessGroups_FiltersByKind(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	groups := []*models.ProcessGroup{
+		{Kind: models.ProcessGroupUnit, Name: "nifi.service", Cgroup: "/system.slice/nifi.service", Processes: 2, CPUPercent: 35, MemRSS: 1 << 30, MemoryCurrent: 3 << 30, CgroupCPUPercent: 36.5, CollectedAt: now},
+		{Kind: models.ProcessGroupContainer, Name: "4f1c2d3e4b5a", Cgroup: "/system.slice/docker-4f1c2d3e4b5a.scope", Processes: 1, CPUPercent: 40, CollectedAt: now},
+	}
+
+	// Execute
+	if err := repo.SaveProcessGroups(ctx, groups); err != nil {
+		t.Fatalf("SaveProcessGroups failed: %v", err)
+	}
+	all, err := repo.ListProcessGroups(ctx, "")
+	if err != nil {
+		t.Fatalf("ListProcessGroups failed: %v", err)
+	}
+	units, err := repo.ListProcessGroups(ctx, models.ProcessGroupUnit)
+	if err != nil {
+		t.Fatalf("ListProcessGroups failed: %v", err)
+	}
+
+	// Assert
+	if len(all) != 2 || all[0].Kind != models.ProcessGroupContainer {
+		t.Fatalf("Expected both groups, busiest first, got %+v", all)
+	}
+	if len(units) != 1 {
+		t.Fatalf("Expected 1 unit, got %d", len(units))
+	}
+	if u := units[0]; u.Name != "nifi.service" || u.Processes != 2 || u.MemoryCurrent != 3<<30 || u.CgroupCPUPercent != 36.5 || !u.CollectedAt.Equal(now) {
+		t.Errorf("Unexpected unit: %+v", u)
+	}
+}
+
+func TestProcessRepository_ProcessWatchSamples_KeepsHistory(t *testing.T) {
+	// Setup
+	database := setupTestDB(t)
+	defer database.Close()
+
+	repo := NewProcessRepository(database.GetDB())
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	watches := []*models.ProcessWatch{
+		{Name: "etl_worker", Min: 1, PIDs: []int{}, Status: models.ProcessWatchUp, CheckedAt: now},
+		{Name: "scheduler", Min: 1, PIDs: []int{}, Status: models.ProcessWatchUp, CheckedAt: now},
+	}
+	if err := repo.SaveProcessWatches(ctx, watches); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+
+	// Execute
+	for _, at := range []time.Time{now.Add(-8 * 24 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Minute)} {
+		samples := []*models.ProcessWatchSample{
+			{Watch: "etl_worker", Count: 2, CPUPercent: 12.5, MemRSS: 1 << 30, Threads: 40, OpenFDs: 120, CollectedAt: at},
+			{Watch: "scheduler", Count: 1, CPUPercent: 0.5, MemRSS: 64 << 20, Threads: 3, OpenFDs: 9, CollectedAt: at},
+		}
+		if err := repo.SaveProcessWatchSamples(ctx, samples, 7*24*time.Hour); err != nil {
+			t.Fatalf("SaveProcessWatchSamples failed: %v", err)
+		}
+	}
+
+	// Assert
+	all, err := repo.ListProcessWatchSamples(ctx, "", time.Time{})
+	if err != nil {
+		t.Fatalf("ListProcessWatchSamples failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("Expected samples older than the retention to be dropped, got %d", len(all))
+	}
+
+	recent, err := repo.ListProcessWatchSamples(ctx, "etl_worker", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListProcessWatchSamples failed: %v", err)
+	}
+	if len(recent) != 1 {
+		t.Fatalf("Expected 1 recent worker sample, got %+v", recent)
+	}
+	s := recent[0]
+	if s.Count != 2 || s.CPUPercent != 12.5 || s.MemRSS != 1<<30 || s.Threads != 40 || s.OpenFDs != 120 || !s.CollectedAt.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Unexpected sample: %+v", s)
+	}
+
+	// Samples of removed watches are dropped with them
+	if err := repo.SaveProcessWatches(ctx, watches[1:]); err != nil {
+		t.Fatalf("SaveProcessWatches failed: %v", err)
+	}
+	if left, _ := repo.ListProcessWatchSamples(ctx, "", time.Time{}); len(left) != 2 || left[0].Watch != "scheduler" {
+		t.Errorf("Expected only the scheduler samples to remain, got %+v", left)
+	}
+}
@@ -4,19 +4,23 @@ import "database/sql"
 
 // Repository aggregates all sub-repositories
 type Repository struct {
-	FS      *FSRepository
-	Paths   *PathsRepository
-	Process *ProcessRepository
-	Log     *LogRepository
+	FS         *FSRepository
+	Paths      *PathsRepository
+	Partitions *PartitionsRepository
+	Pipelines  *PipelinesRepository
+	Process    *ProcessRepository
+	Log        *LogRepository
 }
 
 // NewRepository creates a new Repository with all sub-repositories initialized
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{
-		FS:      NewFSRepository(db),
-		Paths:   NewPathsRepository(db),
-		Process: NewProcessRepository(db),
-		Log:     NewLogRepository(db),
+		FS:         NewFSRepository(db),
+		Paths:      NewPathsRepository(db),
+		Partitions: NewPartitionsRepository(db),
+		Pipelines:  NewPipelinesRepository(db),
+		Process:    NewProcessRepository(db),
+		Log:        NewLogRepository(db),
 	}
 }
 
@@ -30,6 +34,12 @@ func (r *Repository) Close() error {
 	if err := r.Paths.Close(); err != nil {
 		errs = append(errs, err)
 	}
+	if err := r.Partitions.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.Pipelines.Close(); err != nil {
+		errs = append(errs, err)
+	}
 	if err := r.Process.Close(); err != nil {
 		errs = append(errs, err)
 	}
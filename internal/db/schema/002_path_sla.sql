@@ -0,0 +1,14 @@
+-- Stuck-file SLA detection
+ALTER TABLE path_stats ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+
+-- Files that break a path's max_file_age expectation (replaced on every scan)
+CREATE TABLE IF NOT EXISTS path_violations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    path TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    mod_time DATETIME NOT NULL,
+    reason TEXT NOT NULL,
+    detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_path_violations_path ON path_violations(path);
@@ -0,0 +1,11 @@
+-- File arrival and departure events for tracked paths
+CREATE TABLE IF NOT EXISTS path_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    path TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    mod_time DATETIME NOT NULL,
+    detected_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_path_events_path_detected ON path_events(path, detected_at);
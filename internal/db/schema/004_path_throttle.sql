@@ -0,0 +1,5 @@
+-- Parallel walking and NFS-aware throttling stats
+ALTER TABLE path_stats ADD COLUMN fs_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_stats ADD COLUMN fs_ops INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE path_stats ADD COLUMN ops_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE path_stats ADD COLUMN throttled_ms INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- Incremental scanning: scan mode per result and the directory mtime cache
+ALTER TABLE path_stats ADD COLUMN scan_mode TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_stats ADD COLUMN dirs_reused INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE path_stats ADD COLUMN last_full_scan DATETIME;
+
+-- Per-directory mtimes and entry counts of incrementally scanned paths
+CREATE TABLE IF NOT EXISTS path_dir_cache (
+    path TEXT NOT NULL,
+    dir TEXT NOT NULL,
+    mtime_ns INTEGER NOT NULL,
+    file_count INTEGER NOT NULL DEFAULT 0,
+    dir_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (path, dir)
+);
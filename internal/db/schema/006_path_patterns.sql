@@ -0,0 +1,5 @@
+-- Include/exclude pattern match counts (JSON) and the matching rules the
+-- directory cache was built under
+ALTER TABLE path_stats ADD COLUMN pattern_matches TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_dir_cache ADD COLUMN rules TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_dir_cache ADD COLUMN pattern_matches TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Named file buckets with per-bucket counts and bytes (JSON)
+ALTER TABLE path_stats ADD COLUMN buckets TEXT NOT NULL DEFAULT '';
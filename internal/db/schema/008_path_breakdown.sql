@@ -0,0 +1,11 @@
+-- Per-directory subtree sizes from the last complete scan of each path
+CREATE TABLE IF NOT EXISTS path_breakdown (
+    path TEXT NOT NULL,
+    dir TEXT NOT NULL,
+    depth INTEGER NOT NULL,
+    file_count INTEGER NOT NULL,
+    dir_count INTEGER NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    collected_at DATETIME NOT NULL,
+    PRIMARY KEY (path, dir)
+);
@@ -0,0 +1,3 @@
+-- Real-time watch state of paths with watch mode enabled
+ALTER TABLE path_stats ADD COLUMN watch_state TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_stats ADD COLUMN watch_error TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+-- Completeness of date partitions checked for marker files
+CREATE TABLE IF NOT EXISTS partition_status (
+    check_name TEXT NOT NULL,
+    partition TEXT NOT NULL,
+    period_start DATETIME NOT NULL,
+    due DATETIME NOT NULL,
+    status TEXT NOT NULL,
+    arrived_at DATETIME,
+    checked_at DATETIME NOT NULL,
+    PRIMARY KEY (check_name, partition)
+);
@@ -0,0 +1,4 @@
+-- Data freshness of paths with an expected cadence
+ALTER TABLE path_stats ADD COLUMN newest_file_at DATETIME;
+ALTER TABLE path_stats ADD COLUMN freshness TEXT NOT NULL DEFAULT '';
+ALTER TABLE path_stats ADD COLUMN data_age_sec INTEGER NOT NULL DEFAULT 0;
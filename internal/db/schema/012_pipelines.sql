@@ -0,0 +1,21 @@
+-- Total size and oldest file of tracked paths
+ALTER TABLE path_stats ADD COLUMN size_bytes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE path_stats ADD COLUMN oldest_file_at DATETIME;
+
+-- Periodic samples of pipeline stages
+CREATE TABLE IF NOT EXISTS pipeline_samples (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pipeline TEXT NOT NULL,
+    stage TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    path TEXT NOT NULL,
+    file_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    oldest_file_at DATETIME,
+    arrived INTEGER NOT NULL DEFAULT 0,
+    departed INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT '',
+    collected_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_samples_pipeline ON pipeline_samples(pipeline, collected_at);
@@ -0,0 +1,7 @@
+-- Process details read from /proc
+ALTER TABLE process_stats ADD COLUMN cmdline TEXT NOT NULL DEFAULT '';
+ALTER TABLE process_stats ADD COLUMN ppid INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE process_stats ADD COLUMN threads INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE process_stats ADD COLUMN start_time DATETIME;
+ALTER TABLE process_stats ADD COLUMN read_bytes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE process_stats ADD COLUMN write_bytes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Latest evaluation of each configured process watch
+CREATE TABLE IF NOT EXISTS process_watches (
+    name TEXT PRIMARY KEY,
+    pattern TEXT NOT NULL DEFAULT '',
+    user TEXT NOT NULL DEFAULT '',
+    min_count INTEGER NOT NULL DEFAULT 1,
+    max_count INTEGER NOT NULL DEFAULT 0,
+    count INTEGER NOT NULL DEFAULT 0,
+    pids TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    checked_at DATETIME NOT NULL
+);
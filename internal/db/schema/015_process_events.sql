@@ -0,0 +1,14 @@
+-- Start and exit events of process watch instances
+CREATE TABLE IF NOT EXISTS process_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    watch TEXT NOT NULL,
+    pid INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    restart INTEGER NOT NULL DEFAULT 0,
+    start_time DATETIME,
+    runtime_sec INTEGER NOT NULL DEFAULT 0,
+    cmdline TEXT NOT NULL DEFAULT '',
+    detected_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_process_events_watch ON process_events(watch, id);
@@ -0,0 +1,11 @@
+-- Every process of the latest collection, for the process tree
+CREATE TABLE IF NOT EXISTS process_tree (
+    pid INTEGER PRIMARY KEY,
+    ppid INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    user TEXT NOT NULL DEFAULT '',
+    cmdline TEXT NOT NULL DEFAULT '',
+    cpu_percent REAL NOT NULL DEFAULT 0,
+    mem_rss INTEGER NOT NULL DEFAULT 0,
+    collected_at DATETIME NOT NULL
+);
@@ -0,0 +1,17 @@
+-- cgroup membership of processes, and totals per systemd unit and container
+ALTER TABLE process_stats ADD COLUMN cgroup TEXT NOT NULL DEFAULT '';
+ALTER TABLE process_stats ADD COLUMN unit TEXT NOT NULL DEFAULT '';
+ALTER TABLE process_stats ADD COLUMN container_id TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS process_groups (
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    cgroup TEXT NOT NULL,
+    processes INTEGER NOT NULL DEFAULT 0,
+    cpu_percent REAL NOT NULL DEFAULT 0,
+    mem_rss INTEGER NOT NULL DEFAULT 0,
+    memory_current INTEGER NOT NULL DEFAULT 0,
+    cgroup_cpu_percent REAL NOT NULL DEFAULT 0,
+    collected_at DATETIME NOT NULL,
+    PRIMARY KEY (kind, name)
+);
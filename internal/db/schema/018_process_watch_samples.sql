@@ -0,0 +1,12 @@
+-- Usage of each process watch's processes over time
+CREATE TABLE IF NOT EXISTS process_watch_samples (
+    watch TEXT NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    cpu_percent REAL NOT NULL DEFAULT 0,
+    mem_rss INTEGER NOT NULL DEFAULT 0,
+    threads INTEGER NOT NULL DEFAULT 0,
+    open_fds INTEGER NOT NULL DEFAULT 0,
+    collected_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_process_watch_samples_watch ON process_watch_samples(watch, collected_at);
@@ -0,0 +1,5 @@
+-- Inode usage of each filesystem
+ALTER TABLE filesystem_usage ADD COLUMN inodes_total INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE filesystem_usage ADD COLUMN inodes_used INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE filesystem_usage ADD COLUMN inodes_free INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE filesystem_usage ADD COLUMN inodes_used_percent REAL NOT NULL DEFAULT 0;
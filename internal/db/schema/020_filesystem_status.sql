@@ -0,0 +1,4 @@
+-- Outcome of each filesystem's last collection; a failed mount keeps its
+-- last usage
+ALTER TABLE filesystem_usage ADD COLUMN status TEXT NOT NULL DEFAULT 'OK';
+ALTER TABLE filesystem_usage ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
@@ -3,15 +3,140 @@ package schema
 import (
 	"database/sql"
 	_ "embed"
+	"fmt"
+	"strconv"
 )
 
 //go:embed 001_initial.sql
 var migration001 string
 
+//go:embed 002_path_sla.sql
+var migration002 string
+
+//go:embed 003_path_events.sql
+var migration003 string
+
+//go:embed 004_path_throttle.sql
+var migration004 string
+
+//go:embed 005_path_incremental.sql
+var migration005 string
+
+//go:embed 006_path_patterns.sql
+var migration006 string
+
+//go:embed 007_path_buckets.sql
+var migration007 string
+
+//go:embed 008_path_breakdown.sql
+var migration008 string
+
+//go:embed 009_path_watch.sql
+var migration009 string
+
+//go:embed 010_partitions.sql
+var migration010 string
+
+//go:embed 011_path_freshness.sql
+var migration011 string
+
+//go:embed 012_pipelines.sql
+var migration012 string
+
+//go:embed 013_process_procfs.sql
+var migration013 string
+
+//go:embed 014_process_watches.sql
+var migration014 string
+
+//go:embed 015_process_events.sql
+var migration015 string
+
+//go:embed 016_process_tree.sql
+var migration016 string
+
+//go:embed 017_process_groups.sql
+var migration017 string
+
+//go:embed 018_process_watch_samples.sql
+var migration018 string
+
+//go:embed 019_filesystem_inodes.sql
+var migration019 string
+
+//go:embed 020_filesystem_status.sql
+var migration020 string
+
+// migrations lists all schema migrations in order.
+// Migration i brings the schema to version i+1.
+var migrations = []string{
+	migration001,
+	migration002,
+	migration003,
+	migration004,
+	migration005,
+	migration006,
+	migration007,
+	migration008,
+	migration009,
+	migration010,
+	migration011,
+	migration012,
+	migration013,
+	migration014,
+	migration015,
+	migration016,
+	migration017,
+	migration018,
+	migration019,
+	migration020,
+}
+
 // RunMigrations executes all database migrations in order.
-// Uses CREATE TABLE IF NOT EXISTS for idempotency.
+// The initial migration uses CREATE TABLE IF NOT EXISTS for idempotency;
+// later migrations are applied once and tracked via meta.schema_version.
 func RunMigrations(db *sql.DB) error {
 	// Execute initial migration
-	_, err := db.Exec(migration001)
-	return err
+	if _, err := db.Exec(migrations[0]); err != nil {
+		return err
+	}
+
+	var value string
+	if err := db.QueryRow("SELECT value FROM meta WHERE key = 'schema_version'").Scan(&value); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid schema version %q: %w", value, err)
+	}
+
+	for v := version; v < len(migrations); v++ {
+		if err := applyMigration(db, v+1, migrations[v]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration and records the new schema version atomically
+func applyMigration(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("migration %d: failed to begin transaction: %w", version, err)
+	}
+
+	if _, err := tx.Exec(migration); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migration %d: %w", version, err)
+	}
+	if _, err := tx.Exec("UPDATE meta SET value = ? WHERE key = 'schema_version'", strconv.Itoa(version)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migration %d: failed to update schema version: %w", version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migration %d: failed to commit: %w", version, err)
+	}
+	return nil
 }
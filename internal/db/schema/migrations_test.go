@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -31,8 +32,8 @@ func TestRunMigrations_FreshDB_CreatesAllTables(t *testing.T) {
 	if err != nil {
 		t.Errorf("Failed to query schema_version: %v", err)
 	}
-	if schemaVersion != "1" {
-		t.Errorf("Expected schema_version = '1', got '%s'", schemaVersion)
+	if want := strconv.Itoa(len(migrations)); schemaVersion != want {
+		t.Errorf("Expected schema_version = '%s', got '%s'", want, schemaVersion)
 	}
 
 	// Verify: Check filesystem_usage table exists and has correct columns
@@ -43,11 +44,104 @@ func TestRunMigrations_FreshDB_CreatesAllTables(t *testing.T) {
 	rows.Close()
 
 	// Verify: Check path_stats table exists and has correct columns
-	rows, err = db.Query("SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, collected_at FROM path_stats LIMIT 0")
+	rows, err = db.Query("SELECT path, file_count, dir_count, scan_duration_ms, status, error_message, status_reason, collected_at FROM path_stats LIMIT 0")
 	if err != nil {
 		t.Errorf("path_stats table missing or invalid: %v", err)
 	}
 	rows.Close()
+
+	// Verify: Check path_violations table exists and has correct columns
+	rows, err = db.Query("SELECT path, file_path, size_bytes, mod_time, reason, detected_at FROM path_violations LIMIT 0")
+	if err != nil {
+		t.Errorf("path_violations table missing or invalid: %v", err)
+	}
+	rows.Close()
+
+	// Verify: Check path_stats throttle columns exist
+	rows, err = db.Query("SELECT fs_type, fs_ops, ops_limit, throttled_ms FROM path_stats LIMIT 0")
+	if err != nil {
+		t.Fatalf("path_stats throttle columns missing: %v", err)
+	}
+	rows.Close()
+
+	// Verify: Check path_events table exists and has correct columns
+	rows, err = db.Query("SELECT id, path, file_name, event_type, size_bytes, mod_time, detected_at FROM path_events LIMIT 0")
+	if err != nil {
+		t.Fatalf("path_events table missing or invalid: %v", err)
+	}
+	rows.Close()
+
+	// Verify: Check incremental scan columns and path_dir_cache table exist
+	rows, err = db.Query("SELECT scan_mode, dirs_reused, last_full_scan, pattern_matches, buckets, watch_state, watch_error, newest_file_at, freshness, data_age_sec, size_bytes, oldest_file_at FROM path_stats LIMIT 0")
+	if err != nil {
+		t.Fatalf("path_stats incremental columns missing: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT path, dir, mtime_ns, file_count, dir_count, rules, pattern_matches FROM path_dir_cache LIMIT 0")
+	if err != nil {
+		t.Fatalf("path_dir_cache table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT path, dir, depth, file_count, dir_count, size_bytes, collected_at FROM path_breakdown LIMIT 0")
+	if err != nil {
+		t.Fatalf("path_breakdown table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT check_name, partition, period_start, due, status, arrived_at, checked_at FROM partition_status LIMIT 0")
+	if err != nil {
+		t.Fatalf("partition_status table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT id, pipeline, stage, position, path, file_count, size_bytes, oldest_file_at, arrived, departed, status, collected_at FROM pipeline_samples LIMIT 0")
+	if err != nil {
+		t.Fatalf("pipeline_samples table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT pid, cmdline, ppid, threads, start_time, read_bytes, write_bytes FROM process_stats LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_stats columns missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT name, pattern, user, min_count, max_count, count, pids, status, reason, checked_at FROM process_watches LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_watches table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT id, watch, pid, event_type, restart, start_time, runtime_sec, cmdline, detected_at FROM process_events LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_events table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT pid, ppid, name, user, cmdline, cpu_percent, mem_rss, collected_at FROM process_tree LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_tree table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT cgroup, unit, container_id FROM process_stats LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_stats cgroup columns missing: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT kind, name, cgroup, processes, cpu_percent, mem_rss, memory_current, cgroup_cpu_percent, collected_at FROM process_groups LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_groups table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT watch, count, cpu_percent, mem_rss, threads, open_fds, collected_at FROM process_watch_samples LIMIT 0")
+	if err != nil {
+		t.Fatalf("process_watch_samples table missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT inodes_total, inodes_used, inodes_free, inodes_used_percent FROM filesystem_usage LIMIT 0")
+	if err != nil {
+		t.Fatalf("filesystem_usage inode columns missing or invalid: %v", err)
+	}
+	rows.Close()
+	rows, err = db.Query("SELECT status, status_reason FROM filesystem_usage LIMIT 0")
+	if err != nil {
+		t.Fatalf("filesystem_usage status columns missing or invalid: %v", err)
+	}
+	rows.Close()
 }
 
 func TestRunMigrations_AlreadyMigrated_SkipsCompleted(t *testing.T) {
@@ -87,14 +181,14 @@ func TestRunMigrations_AlreadyMigrated_SkipsCompleted(t *testing.T) {
 		t.Errorf("Expected mount_point = '/test', got '%s'", mountPoint)
 	}
 
-	// Verify: Schema version is still 1
+	// Verify: Schema version is unchanged
 	var schemaVersion string
 	err = db.QueryRow("SELECT value FROM meta WHERE key = 'schema_version'").Scan(&schemaVersion)
 	if err != nil {
 		t.Errorf("Failed to query schema_version: %v", err)
 	}
-	if schemaVersion != "1" {
-		t.Errorf("Expected schema_version = '1', got '%s'", schemaVersion)
+	if want := strconv.Itoa(len(migrations)); schemaVersion != want {
+		t.Errorf("Expected schema_version = '%s', got '%s'", want, schemaVersion)
 	}
 }
 
@@ -120,3 +214,45 @@ func TestRunMigrations_InvalidDB_ReturnsError(t *testing.T) {
 		t.Error("Expected error when running migrations on closed database, got nil")
 	}
 }
+
+func TestRunMigrations_VersionOneDB_AppliesPendingMigrations(t *testing.T) {
+	// Setup: Create database with only the initial migration applied
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(migration001); err != nil {
+		t.Fatalf("Initial migration failed: %v", err)
+	}
+	_, err = db.Exec(`INSERT INTO path_stats (path, file_count) VALUES ('/data', 10)`)
+	if err != nil {
+		t.Fatalf("Failed to insert test data: %v", err)
+	}
+
+	// Execute: Upgrade to latest schema
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+
+	// Verify: Existing row survives and new column has its default
+	var reason string
+	if err := db.QueryRow("SELECT status_reason FROM path_stats WHERE path = '/data'").Scan(&reason); err != nil {
+		t.Fatalf("Failed to query upgraded row: %v", err)
+	}
+	if reason != "" {
+		t.Errorf("Expected empty status_reason, got '%s'", reason)
+	}
+
+	var schemaVersion string
+	if err := db.QueryRow("SELECT value FROM meta WHERE key = 'schema_version'").Scan(&schemaVersion); err != nil {
+		t.Fatalf("Failed to query schema_version: %v", err)
+	}
+	if want := strconv.Itoa(len(migrations)); schemaVersion != want {
+		t.Errorf("Expected schema_version = '%s', got '%s'", want, schemaVersion)
+	}
+}
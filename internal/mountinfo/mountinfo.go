@@ -0,0 +1,55 @@
+package mountinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// networkTypes are filesystems served over the network; their source is a
+// server export rather than a device
+var networkTypes = map[string]bool{
+	"nfs":        true,
+	"nfs4":       true,
+	"cifs":       true,
+	"smb3":       true,
+	"smbfs":      true,
+	"afs":        true,
+	"ceph":       true,
+	"glusterfs":  true,
+	"fuse.sshfs": true,
+	"afpfs":      true,
+	"webdav":     true,
+}
+
+// IsNetwork reports whether fsType is a network filesystem
+func IsNetwork(fsType string) bool {
+	return networkTypes[fsType]
+}
+
+// Contains reports whether path lies on or below mountPoint
+func Contains(mountPoint, path string) bool {
+	if mountPoint == "/" || path == mountPoint {
+		return true
+	}
+	return strings.HasPrefix(path, mountPoint+"/")
+}
+
+// Unescape decodes the octal escapes (\040 for a space) the kernel uses in
+// /proc/mounts and /proc/self/mountinfo
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
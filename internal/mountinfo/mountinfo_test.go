@@ -0,0 +1,48 @@
+package mountinfo
+
+import "testing"
+
+func TestIsNetwork(t *testing.T) {
+	for _, fsType := range []string{"nfs", "nfs4", "cifs", "ceph", "afs", "glusterfs", "fuse.sshfs"} {
+		if !IsNetwork(fsType) {
+			t.Errorf("IsNetwork(%q) = false, want true", fsType)
+		}
+	}
+	for _, fsType := range []string{"ext4", "xfs", "tmpfs", "fuse.s3fs", ""} {
+		if IsNetwork(fsType) {
+			t.Errorf("IsNetwork(%q) = true, want false", fsType)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		mountPoint, path string
+		want             bool
+	}{
+		{"/", "/data/in", true},
+		{"/data", "/data", true},
+		{"/data", "/data/in/a.csv", true},
+		{"/data", "/database", false},
+		{"/data/in", "/data", false},
+	}
+	for _, tt := range tests {
+		if got := Contains(tt.mountPoint, tt.path); got != tt.want {
+			t.Errorf("Contains(%q, %q) = %v, want %v", tt.mountPoint, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	tests := map[string]string{
+		"/data":                 "/data",
+		`/mnt/my\040disk`:       "/mnt/my disk",
+		`/mnt/tab\011and\134bs`: "/mnt/tab\tand\\bs",
+		`/mnt/trailing\`:        `/mnt/trailing\`,
+	}
+	for in, want := range tests {
+		if got := Unescape(in); got != want {
+			t.Errorf("Unescape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,13 +1,76 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // FilesystemUsage represents disk usage statistics for a mount point
 type FilesystemUsage struct {
-	MountPoint  string    `json:"mount_point"`  // Mount point path (e.g., "/data")
-	TotalBytes  uint64    `json:"total_bytes"`  // Total filesystem size in bytes
-	UsedBytes   uint64    `json:"used_bytes"`   // Used space in bytes
-	AvailBytes  uint64    `json:"avail_bytes"`  // Available space in bytes
-	UsedPercent float64   `json:"used_percent"` // Usage percentage (0-100)
-	CollectedAt time.Time `json:"collected_at"` // When this metric was collected
+	MountPoint  string  `json:"mount_point"`  // Mount point path (e.g., "/data")
+	TotalBytes  uint64  `json:"total_bytes"`  // Total filesystem size in bytes
+	UsedBytes   uint64  `json:"used_bytes"`   // Used space in bytes
+	AvailBytes  uint64  `json:"avail_bytes"`  // Available space in bytes
+	UsedPercent float64 `json:"used_percent"` // Usage percentage (0-100)
+
+	// Inodes, all 0 for filesystems without a fixed inode count (e.g. btrfs)
+	InodesTotal       uint64  `json:"inodes_total"`
+	InodesUsed        uint64  `json:"inodes_used"`
+	InodesFree        uint64  `json:"inodes_free"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"` // Inode usage percentage (0-100)
+
+	// Whether the last collection succeeded; a mount that is not OK keeps
+	// the usage of its last successful collection
+	Status       string `json:"status"`                  // OK, STALE or ERROR
+	StatusReason string `json:"status_reason,omitempty"` // Why the last collection failed
+
+	CollectedAt time.Time `json:"collected_at"` // When this metric was last collected successfully
+}
+
+// Filesystem statuses
+const (
+	FilesystemOK    = "OK"
+	FilesystemStale = "STALE" // statfs timed out: the mount does not answer
+	FilesystemError = "ERROR" // statfs failed
+)
+
+// DeletedFilesReport is the latest periodic scan for files that were
+// deleted while processes still hold them open, so their space is not freed
+type DeletedFilesReport struct {
+	TotalBytes   int64                 `json:"total_bytes"` // Space held, counting each file once
+	TotalFiles   int                   `json:"total_files"`
+	Mounts       []DeletedFilesMount   `json:"mounts"`    // Largest first
+	Processes    []DeletedFilesProcess `json:"processes"` // Largest first
+	Files        []DeletedFile         `json:"files"`     // Largest first, capped
+	Truncated    bool                  `json:"truncated,omitempty"`
+	Unreadable   int                   `json:"unreadable,omitempty"`   // Processes whose descriptors could not be read
+	Unresponsive int                   `json:"unresponsive,omitempty"` // Files skipped because their filesystem did not answer
+	CollectedAt  time.Time             `json:"collected_at"`
+}
+
+// ErrDeletedFilesPending is returned before the first deleted files scan
+// has finished
+var ErrDeletedFilesPending = errors.New("deleted files have not been scanned yet")
+
+// DeletedFilesMount is the space held by deleted files on one filesystem
+type DeletedFilesMount struct {
+	MountPoint string `json:"mount_point"`
+	Bytes      int64  `json:"bytes"`
+	Files      int    `json:"files"`
+}
+
+// DeletedFilesProcess is the space a process holds in deleted files
+type DeletedFilesProcess struct {
+	PID   int    `json:"pid"`
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+	Files int    `json:"files"`
+}
+
+// DeletedFile is a deleted file still held open, with the processes holding it
+type DeletedFile struct {
+	Path       string `json:"path"` // Path it had before it was deleted
+	Size       int64  `json:"size"`
+	MountPoint string `json:"mount_point,omitempty"`
+	PIDs       []int  `json:"pids"`
 }
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Partition statuses reported by partition checks
+const (
+	PartitionPresent = "PRESENT" // Marker file found
+	PartitionPending = "PENDING" // No marker yet, deadline not reached
+	PartitionLate    = "LATE"    // Deadline passed; the partition exists but has no marker
+	PartitionMissing = "MISSING" // Deadline passed and the partition does not exist
+)
+
+// PartitionStatus is the completeness of one date partition of a partition check
+type PartitionStatus struct {
+	Check       string     `json:"check"`                // Name of the partition check
+	Partition   string     `json:"partition"`            // Partition directory (e.g., "/data/events/dt=2026-10-18")
+	PeriodStart time.Time  `json:"period_start"`         // Start of the day or hour the partition holds
+	Due         time.Time  `json:"due"`                  // When the marker is expected by
+	Status      string     `json:"status"`               // PRESENT, PENDING, LATE or MISSING
+	ArrivedAt   *time.Time `json:"arrived_at,omitempty"` // Modification time of the marker (PRESENT only)
+	CheckedAt   time.Time  `json:"checked_at"`           // When the partition was last checked
+}
+
+// ArrivedLate reports whether a present partition's marker appeared after its deadline
+func (p *PartitionStatus) ArrivedLate() bool {
+	return p.ArrivedAt != nil && p.ArrivedAt.After(p.Due)
+}
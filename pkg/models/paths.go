@@ -1,14 +1,198 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // PathStats represents file/directory count statistics for a monitored path
 type PathStats struct {
-	Path           string    `json:"path"`                      // Path being monitored (e.g., "/data/logs")
-	FileCount      int64     `json:"file_count"`                // Number of files found
-	DirCount       int64     `json:"dir_count"`                 // Number of directories found
-	ScanDurationMs int64     `json:"scan_duration_ms"`          // How long the scan took in milliseconds
-	Status         string    `json:"status"`                    // Current status: OK, SCANNING, ERROR
-	ErrorMessage   string    `json:"error_message,omitempty"`   // Error details if status is ERROR
-	CollectedAt    time.Time `json:"collected_at"`              // When this scan completed
+	Path           string           `json:"path"`                      // Path being monitored (e.g., "/data/logs")
+	FileCount      int64            `json:"file_count"`                // Number of files found
+	DirCount       int64            `json:"dir_count"`                 // Number of directories found
+	ScanDurationMs int64            `json:"scan_duration_ms"`          // How long the scan took in milliseconds
+	Status         string           `json:"status"`                    // Current status: OK, SCANNING, WARNING, CRITICAL, TIMEOUT, STALE, ERROR
+	ErrorMessage   string           `json:"error_message,omitempty"`   // Error details if status is ERROR
+	StatusReason   string           `json:"status_reason,omitempty"`   // Why the path is WARNING or CRITICAL
+	FsType         string           `json:"fs_type,omitempty"`         // Filesystem type holding the path (e.g., "ext4", "nfs4")
+	FsOps          int64            `json:"fs_ops"`                    // Readdir and stat calls issued by the scan
+	OpsLimit       int              `json:"ops_limit,omitempty"`       // Ops/sec limit applied to the scan (0 = unthrottled)
+	ThrottledMs    int64            `json:"throttled_ms"`              // Time the scan spent waiting on the ops/sec limit
+	ScanMode       string           `json:"scan_mode,omitempty"`       // full or incremental (empty unless incremental mode is enabled)
+	DirsReused     int64            `json:"dirs_reused,omitempty"`     // Directories counted from the mtime cache without being read
+	LastFullScan   *time.Time       `json:"last_full_scan,omitempty"`  // When the last full verification walk completed
+	PatternMatches []PatternMatch   `json:"pattern_matches,omitempty"` // Entries matched by each include and exclude pattern
+	Buckets        []PathBucket     `json:"buckets,omitempty"`         // File counts and bytes per configured bucket, by name
+	WatchState     string           `json:"watch_state,omitempty"`     // active or fallback (empty unless watch mode is enabled)
+	WatchError     string           `json:"watch_error,omitempty"`     // Why the path fell back to periodic scans
+	NewestFileAt   *time.Time       `json:"newest_file_at,omitempty"`  // Modification time of the newest file (only with an expected cadence)
+	Freshness      string           `json:"freshness,omitempty"`       // FRESH, LATE or STALE (empty unless an expected cadence is configured)
+	DataAgeSec     int64            `json:"data_age_sec,omitempty"`    // Age in seconds of the newest file when the scan started
+	SizeBytes      int64            `json:"size_bytes,omitempty"`      // Total size of the files (tracked paths only)
+	OldestFileAt   *time.Time       `json:"oldest_file_at,omitempty"`  // Modification time of the oldest file (tracked paths only)
+	CollectedAt    time.Time        `json:"collected_at"`              // When this scan completed
+	Violations     []*PathViolation `json:"-"`                         // Offending files from this scan (stored separately)
+	Events         []*PathEvent     `json:"-"`                         // File changes since the previous scan (stored separately)
+	Breakdown      []*PathDirUsage  `json:"-"`                         // Subdirectory sizes from a complete scan (stored separately)
+}
+
+// Pattern kinds reported in PatternMatch
+const (
+	PatternInclude = "include"
+	PatternExclude = "exclude"
+)
+
+// PatternMatch counts the entries one include or exclude pattern matched
+// during a scan. Excluded directories count once; their contents are not walked.
+type PatternMatch struct {
+	Pattern string `json:"pattern"`
+	Kind    string `json:"kind"` // include or exclude
+	Count   int64  `json:"count"`
+}
+
+// PathBucket counts the files that matched one named bucket pattern during
+// a scan. A file matching several buckets is counted in each of them.
+type PathBucket struct {
+	Name      string `json:"name"`       // Bucket name from the path config (e.g., "errors")
+	Pattern   string `json:"pattern"`    // Pattern files are matched against
+	FileCount int64  `json:"file_count"` // Files in the bucket
+	SizeBytes int64  `json:"size_bytes"` // Total size of the bucket's files
+}
+
+// Scan modes of paths with incremental scanning enabled
+const (
+	ScanModeFull        = "full"
+	ScanModeIncremental = "incremental"
+)
+
+// Watch states of paths with watch mode enabled
+const (
+	WatchActive   = "active"   // Counts are kept current from filesystem notifications
+	WatchFallback = "fallback" // Watching is unavailable; counts only change on periodic scans
+)
+
+// Freshness states of paths with an expected cadence. A path is LATE once its
+// newest file is older than the cadence and STALE once it is older than twice
+// the cadence, or when the path holds no files at all.
+const (
+	FreshnessFresh = "FRESH"
+	FreshnessLate  = "LATE"
+	FreshnessStale = "STALE"
+)
+
+// DirCacheEntry is the cached state of one directory under an incrementally
+// scanned path. Counts cover the directory's own entries, not its subtree.
+type DirCacheEntry struct {
+	Dir       string    // Directory relative to the monitored path ("." for the root)
+	ModTime   time.Time // Directory mtime when its entries were counted
+	FileCount int64     // Files directly in the directory
+	DirCount  int64     // Subdirectories directly in the directory
+	Rules     string    // Fingerprint of the matching rules the counts were taken under
+	Matches   []int64   // Entries matched per include/exclude pattern, in config order
+}
+
+// PathViolation represents a file that breaks a path's SLA expectations
+type PathViolation struct {
+	Path       string    `json:"path"`        // Monitored path the file was found under
+	FilePath   string    `json:"file_path"`   // Full path of the offending file
+	SizeBytes  int64     `json:"size_bytes"`  // File size in bytes
+	ModTime    time.Time `json:"mod_time"`    // Last modification time of the file
+	Reason     string    `json:"reason"`      // Which expectation the file violates
+	DetectedAt time.Time `json:"detected_at"` // When the scan found the violation
+}
+
+// PathDirUsage is the du-style size of one directory subtree under a
+// monitored path, recorded down to the path's breakdown depth
+type PathDirUsage struct {
+	Path        string    `json:"path"`         // Monitored path the directory belongs to
+	Dir         string    `json:"dir"`          // Directory relative to the monitored path ("." for the path itself)
+	Depth       int       `json:"depth"`        // 0 for the path itself, 1 for its immediate children
+	FileCount   int64     `json:"file_count"`   // Files anywhere below the directory
+	DirCount    int64     `json:"dir_count"`    // Subdirectories anywhere below the directory
+	SizeBytes   int64     `json:"size_bytes"`   // Total size of the files below the directory
+	CollectedAt time.Time `json:"collected_at"` // When the scan that measured it completed
+}
+
+// Path event types
+const (
+	PathEventCreated  = "created"
+	PathEventModified = "modified"
+	PathEventRemoved  = "removed"
+)
+
+// PathEvent represents a file change detected between two consecutive scans
+type PathEvent struct {
+	ID         int64     `json:"id"`
+	Path       string    `json:"path"`        // Monitored path the file belongs to
+	FileName   string    `json:"file_name"`   // File name relative to the monitored path
+	EventType  string    `json:"event_type"`  // created, modified or removed
+	SizeBytes  int64     `json:"size_bytes"`  // File size (last known size for removed files)
+	ModTime    time.Time `json:"mod_time"`    // File modification time (last known for removed files)
+	DetectedAt time.Time `json:"detected_at"` // When the scan that detected the change completed
+}
+
+// ErrPathNotConfigured is returned when an operation targets a path that is not monitored
+var ErrPathNotConfigured = errors.New("path is not configured for monitoring")
+
+// Path file listing sort keys
+const (
+	PathFileSortSize  = "size"  // Largest first by default
+	PathFileSortMtime = "mtime" // Oldest first by default
+	PathFileSortName  = "name"  // Alphabetical by default
+)
+
+// PathFilesQuery selects and orders the files returned by a path listing
+type PathFilesQuery struct {
+	Sort    string // size, mtime or name
+	Order   string // asc or desc; empty uses the sort key's default
+	Limit   int    // Maximum number of files to return
+	Pattern string // Optional glob matched against the file's base name
+}
+
+// PathFile is a single file in a path listing
+type PathFile struct {
+	Name      string    `json:"name"`       // File name relative to the monitored path
+	SizeBytes int64     `json:"size_bytes"` // File size in bytes
+	ModTime   time.Time `json:"mod_time"`   // Last modification time
+	Owner     string    `json:"owner"`      // Owning user name (numeric uid if unknown)
+}
+
+// PathFileList is the result of a bounded file listing under a monitored path
+type PathFileList struct {
+	Path      string     `json:"path"`      // Monitored path that was listed
+	Files     []PathFile `json:"files"`     // Top files according to the query
+	Matched   int64      `json:"matched"`   // Files that matched the query before the limit was applied
+	Truncated bool       `json:"truncated"` // Walk stopped at the path's timeout; results are partial
+}
+
+// Scan job states
+const (
+	ScanJobQueued    = "queued"
+	ScanJobRunning   = "running"
+	ScanJobCompleted = "completed"
+	ScanJobFailed    = "failed"
+	ScanJobCancelled = "cancelled"
+)
+
+// ErrScanJobNotFound is returned when a scan job ID is unknown or has expired
+var ErrScanJobNotFound = errors.New("scan job not found")
+
+// ScanJob is a manually requested scan of one or more paths, run in the background
+type ScanJob struct {
+	ID           string       `json:"id"`
+	Paths        []string     `json:"paths"`                  // Paths to scan, in order
+	Status       string       `json:"status"`                 // queued, running, completed, failed or cancelled
+	CurrentPath  string       `json:"current_path,omitempty"` // Path being walked while running
+	FilesScanned int64        `json:"files_scanned"`          // Files counted so far across all paths
+	DirsScanned  int64        `json:"dirs_scanned"`           // Directories counted so far across all paths
+	Results      []*PathStats `json:"results,omitempty"`      // Stats of the paths finished so far
+	Error        string       `json:"error,omitempty"`        // Failure or cancellation reason
+	CreatedAt    time.Time    `json:"created_at"`
+	StartedAt    *time.Time   `json:"started_at,omitempty"`
+	FinishedAt   *time.Time   `json:"finished_at,omitempty"`
+}
+
+// Done reports whether the job has reached a final state
+func (j *ScanJob) Done() bool {
+	return j.Status == ScanJobCompleted || j.Status == ScanJobFailed || j.Status == ScanJobCancelled
 }
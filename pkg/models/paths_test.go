@@ -130,7 +130,7 @@ func TestPathStats_JSONTags(t *testing.T) {
 }
 
 func TestPathStats_StatusValues(t *testing.T) {
-	validStatuses := []string{"OK", "SCANNING", "ERROR"}
+	validStatuses := []string{"OK", "SCANNING", "WARNING", "CRITICAL", "TIMEOUT", "STALE", "ERROR"}
 
 	for _, status := range validStatuses {
 		stats := PathStats{
@@ -154,3 +154,67 @@ func TestPathStats_StatusValues(t *testing.T) {
 		}
 	}
 }
+
+func TestPathStats_JSONMarshaling_OmitsViolations(t *testing.T) {
+	stats := PathStats{
+		Path:         "/data/input",
+		Status:       "WARNING",
+		StatusReason: "3 files older than 1h0m0s",
+		Violations: []*PathViolation{
+			{Path: "/data/input", FilePath: "/data/input/a.csv", Reason: "older than 1h0m0s"},
+		},
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to unmarshal to map: %v", err)
+	}
+
+	if raw["status_reason"] != stats.StatusReason {
+		t.Errorf("status_reason: got %v, want %s", raw["status_reason"], stats.StatusReason)
+	}
+	if _, exists := raw["violations"]; exists {
+		t.Error("violations should not be part of the path stats JSON")
+	}
+}
+
+func TestPathViolation_JSONMarshaling(t *testing.T) {
+	now := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+
+	v := PathViolation{
+		Path:       "/data/input",
+		FilePath:   "/data/input/partner_a.csv",
+		SizeBytes:  2048,
+		ModTime:    now.Add(-3 * time.Hour),
+		Reason:     "older than 1h0m0s",
+		DetectedAt: now,
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var decoded PathViolation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if decoded.FilePath != v.FilePath {
+		t.Errorf("FilePath: got %s, want %s", decoded.FilePath, v.FilePath)
+	}
+	if decoded.SizeBytes != v.SizeBytes {
+		t.Errorf("SizeBytes: got %d, want %d", decoded.SizeBytes, v.SizeBytes)
+	}
+	if !decoded.ModTime.Equal(v.ModTime) {
+		t.Errorf("ModTime: got %v, want %v", decoded.ModTime, v.ModTime)
+	}
+	if decoded.Reason != v.Reason {
+		t.Errorf("Reason: got %s, want %s", decoded.Reason, v.Reason)
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PipelineStage is a sample of one stage of a pipeline: the files waiting in
+// the stage's path and how many entered and left it since the previous sample
+type PipelineStage struct {
+	Pipeline     string     `json:"pipeline"`                 // Name of the pipeline
+	Stage        string     `json:"stage"`                    // Name of the stage (e.g., "inbox")
+	Position     int        `json:"position"`                 // Order of the stage in the pipeline, from 0
+	Path         string     `json:"path"`                     // Monitored path holding the stage's files
+	FileCount    int64      `json:"file_count"`               // Files in the stage at its last scan
+	SizeBytes    int64      `json:"size_bytes"`               // Total size of those files
+	OldestFileAt *time.Time `json:"oldest_file_at,omitempty"` // Modification time of the oldest file
+	OldestAgeSec int64      `json:"oldest_age_sec"`           // Age of the oldest file when the sample was taken
+	Arrived      int64      `json:"arrived"`                  // Files that appeared in the stage since the previous sample
+	Departed     int64      `json:"departed"`                 // Files that left the stage since the previous sample
+	Status       string     `json:"status,omitempty"`         // Status of the stage path's last scan
+	CollectedAt  time.Time  `json:"collected_at"`             // When the sample was taken
+}
+
+// Pipeline is the latest sample of every stage of a pipeline, in stage order
+type Pipeline struct {
+	Name   string          `json:"name"`
+	Stages []PipelineStage `json:"stages"`
+}
@@ -1,15 +1,151 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // ProcessInfo represents a monitored process's statistics
 type ProcessInfo struct {
-	PID         int       `json:"pid"`
-	Name        string    `json:"name"`
-	User        string    `json:"user"`
-	CPUPercent  float64   `json:"cpu_percent"`
-	MemRSS      int64     `json:"mem_rss"`       // bytes
-	Status      string    `json:"status"`         // running, sleeping, zombie, stopped
-	Elapsed     string    `json:"elapsed"`        // human-readable elapsed time
+	PID         int        `json:"pid"`
+	PPID        int        `json:"ppid"`
+	Name        string     `json:"name"`
+	Cmdline     string     `json:"cmdline,omitempty"` // full command line (Linux only)
+	User        string     `json:"user"`
+	CPUPercent  float64    `json:"cpu_percent"`
+	MemRSS      int64      `json:"mem_rss"`       // bytes
+	Threads     int        `json:"threads,omitempty"`
+	Status      string     `json:"status"`         // running, sleeping, zombie, stopped
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	Elapsed     string     `json:"elapsed"`        // human-readable elapsed time
+	ReadBytes   int64      `json:"read_bytes"`     // bytes read from storage since start
+	WriteBytes  int64      `json:"write_bytes"`    // bytes written to storage since start
+	Cgroup      string     `json:"cgroup,omitempty"`       // cgroup path (Linux only)
+	Unit        string     `json:"unit,omitempty"`         // systemd unit the process runs in
+	ContainerID string     `json:"container_id,omitempty"` // container the process runs in
+	CollectedAt time.Time  `json:"collected_at"`
+}
+
+// Process watch statuses
+const (
+	ProcessWatchUp       = "UP"       // Instance count within the expected range
+	ProcessWatchDown     = "DOWN"     // No matching process running
+	ProcessWatchDegraded = "DEGRADED" // Running, but fewer or more instances than expected
+)
+
+// ProcessWatch is the latest evaluation of a configured process watch
+type ProcessWatch struct {
+	Name      string    `json:"name"`
+	Match     string    `json:"match,omitempty"` // Regular expression on the command line
+	User      string    `json:"user,omitempty"`  // Required process owner
+	Min       int       `json:"min"`             // Minimum expected instances
+	Max       int       `json:"max,omitempty"`   // Maximum expected instances (0 = unbounded)
+	Count     int       `json:"count"`           // Matching processes running
+	PIDs      []int     `json:"pids"`            // PIDs of the matching processes
+	Status    string    `json:"status"`          // UP, DOWN or DEGRADED
+	Reason    string    `json:"reason,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+
+	Restarts      int        `json:"restarts"`                  // Restarts in the last 24 hours
+	LastRestartAt *time.Time `json:"last_restart_at,omitempty"` // When the latest restart was detected
+}
+
+// ProcessWatchSample is the combined usage of a watch's processes at one
+// collection
+type ProcessWatchSample struct {
+	Watch       string    `json:"watch"`
+	Count       int       `json:"count"`       // Matching processes running
+	CPUPercent  float64   `json:"cpu_percent"` // Sum over the processes
+	MemRSS      int64     `json:"mem_rss"`     // Sum over the processes, bytes
+	Threads     int       `json:"threads"`     // Sum over the processes
+	OpenFDs     int       `json:"open_fds"`    // Sum over the processes (Linux only)
 	CollectedAt time.Time `json:"collected_at"`
 }
+
+// Process event types
+const (
+	ProcessEventStart = "start"
+	ProcessEventExit  = "exit"
+)
+
+// ProcessEvent records a process instance of a watch starting or exiting.
+// Instances are identified by PID and start time, so a restarted process
+// reusing its PID is still told apart.
+type ProcessEvent struct {
+	ID         int64      `json:"id"`
+	Watch      string     `json:"watch"`                 // Process watch the instance matched
+	PID        int        `json:"pid"`                   // Process ID of the instance
+	EventType  string     `json:"event_type"`            // start or exit
+	Restart    bool       `json:"restart,omitempty"`     // Start event replacing an instance that exited
+	StartTime  *time.Time `json:"start_time,omitempty"`  // When the instance started
+	RuntimeSec int64      `json:"runtime_sec,omitempty"` // Exit events: how long the instance ran
+	Cmdline    string     `json:"cmdline,omitempty"`     // Command line of the instance
+	DetectedAt time.Time  `json:"detected_at"`           // When the collection that noticed it ran
+}
+
+// ProcessNode is a process in the process tree with the totals of its subtree
+type ProcessNode struct {
+	PID         int            `json:"pid"`
+	PPID        int            `json:"ppid"`
+	Name        string         `json:"name"`
+	User        string         `json:"user"`
+	Cmdline     string         `json:"cmdline,omitempty"`
+	CPUPercent  float64        `json:"cpu_percent"`
+	MemRSS      int64          `json:"mem_rss"`          // bytes
+	TreeCPU     float64        `json:"tree_cpu_percent"` // CPU% of the process and all its descendants
+	TreeMemRSS  int64          `json:"tree_mem_rss"`     // RSS of the process and all its descendants
+	Descendants int            `json:"descendants"`      // Number of processes below it
+	Children    []*ProcessNode `json:"children"`         // Child processes, busiest subtree first
+}
+
+// ErrProcessNotFound is returned when an operation targets a process that is not running
+var ErrProcessNotFound = errors.New("process not found")
+
+// ProcessDetails is an on-demand snapshot of a process's open files and sockets
+type ProcessDetails struct {
+	PID         int             `json:"pid"`
+	Name        string          `json:"name"`
+	Cmdline     string          `json:"cmdline,omitempty"`
+	OpenFDs     int             `json:"open_fds"`            // Number of open file descriptors
+	MaxFDs      int64           `json:"max_fds"`             // Soft open files limit, 0 = unlimited
+	MaxFDsHard  int64           `json:"max_fds_hard"`        // Hard open files limit, 0 = unlimited
+	Files       []ProcessFile   `json:"files"`               // Non-TCP descriptors by FD: files, pipes, other sockets
+	Sockets     []ProcessSocket `json:"sockets"`             // TCP sockets by FD
+	ListenPorts []int           `json:"listen_ports"`        // Ports of listening TCP sockets, ascending
+	Truncated   bool            `json:"truncated,omitempty"` // Files was cut at its limit
+	CollectedAt time.Time       `json:"collected_at"`
+}
+
+// ProcessFile is an open file descriptor and what it refers to
+type ProcessFile struct {
+	FD   int    `json:"fd"`
+	Path string `json:"path"` // File path, or e.g. "pipe:[1234]" for anonymous ones
+}
+
+// ProcessSocket is a TCP socket held open by a process
+type ProcessSocket struct {
+	FD         int    `json:"fd"`
+	Protocol   string `json:"protocol"` // tcp or tcp6
+	State      string `json:"state"`    // ESTABLISHED, LISTEN, CLOSE_WAIT, ...
+	LocalAddr  string `json:"local_addr"`
+	RemoteAddr string `json:"remote_addr,omitempty"` // Peer, empty for listening sockets
+}
+
+// Process group kinds
+const (
+	ProcessGroupUnit      = "unit"      // A systemd service or scope
+	ProcessGroupContainer = "container" // A Docker, containerd, CRI-O or Podman container
+)
+
+// ProcessGroup is the processes of one systemd unit or container
+type ProcessGroup struct {
+	Kind             string    `json:"kind"`
+	Name             string    `json:"name"`               // Unit name, or the short container ID
+	Cgroup           string    `json:"cgroup"`             // cgroup of the unit or container
+	Processes        int       `json:"processes"`          // Number of processes in the group
+	CPUPercent       float64   `json:"cpu_percent"`        // Sum over the processes
+	MemRSS           int64     `json:"mem_rss"`            // Sum over the processes, bytes
+	MemoryCurrent    int64     `json:"memory_current"`     // cgroup v2 memory.current, including page cache
+	CgroupCPUPercent float64   `json:"cgroup_cpu_percent"` // cgroup v2 cpu.stat usage since the previous collection
+	CollectedAt      time.Time `json:"collected_at"`
+}
@@ -131,7 +131,7 @@ func TestIntegration_TriggerScan(t *testing.T) {
 
 	// Trigger scan with nil paths (scan all configured paths)
 	// In integration tests, scanner is not configured, so we expect an error
-	err := env.client.TriggerScan(env.ctx, nil)
+	_, err := env.client.TriggerScan(env.ctx, nil)
 	if err != nil {
 		// Expected - scanner not configured in test environment
 		apiErr, ok := err.(*client.APIError)
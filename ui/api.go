@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"time"
 
 	"github.com/etlmon/etlmon/internal/config"
 	"github.com/etlmon/etlmon/pkg/models"
@@ -12,13 +13,30 @@ import (
 type APIClient interface {
 	// Filesystem operations
 	GetFilesystemUsage(ctx context.Context) ([]*models.FilesystemUsage, error)
+	GetDeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error)
 
 	// Path operations
 	GetPathStats(ctx context.Context) ([]*models.PathStats, error)
-	TriggerScan(ctx context.Context, paths []string) error
+	TriggerScan(ctx context.Context, paths []string) (*models.ScanJob, error)
+	GetScanJob(ctx context.Context, id string) (*models.ScanJob, error)
+	CancelScanJob(ctx context.Context, id string) (*models.ScanJob, error)
+	ListPathFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error)
+	GetPathBreakdown(ctx context.Context, path string) ([]models.PathDirUsage, error)
+
+	// Partition operations
+	GetPartitions(ctx context.Context, check string) ([]models.PartitionStatus, error)
+
+	// Pipeline operations
+	GetPipelines(ctx context.Context) ([]models.Pipeline, error)
+	GetPipelineHistory(ctx context.Context, name string, since time.Time) ([]models.PipelineStage, error)
 
 	// Process operations
 	GetProcessInfo(ctx context.Context) ([]*models.ProcessInfo, error)
+	GetProcessWatches(ctx context.Context) ([]*models.ProcessWatch, error)
+	GetProcessHistory(ctx context.Context, watch string, since time.Time) ([]models.ProcessWatchSample, error)
+	GetProcessGroups(ctx context.Context) ([]*models.ProcessGroup, error)
+	GetProcessTree(ctx context.Context, root int) ([]*models.ProcessNode, error)
+	GetProcessDetails(ctx context.Context, pid int) (*models.ProcessDetails, error)
 
 	// Log operations
 	GetLogFiles(ctx context.Context) ([]models.LogFileInfo, error)
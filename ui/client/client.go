@@ -45,76 +45,40 @@ func (c *Client) SetTimeout(d time.Duration) {
 
 // get performs a GET request and unmarshals the response into result
 func (c *Client) get(ctx context.Context, path string, result interface{}) error {
-	url := c.baseURL + path
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("do request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
-	}
-
-	// Check for error response
-	if resp.StatusCode >= 400 {
-		var errResp struct {
-			Error   string `json:"error"`
-			Code    string `json:"code"`
-			Details string `json:"details"`
-		}
-		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    errResp.Error,
-			}
-		}
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("HTTP %d", resp.StatusCode),
-		}
-	}
-
-	// Parse successful response
-	var wrapper struct {
-		Data json.RawMessage `json:"data"`
-	}
-	if err := json.Unmarshal(body, &wrapper); err != nil {
-		return fmt.Errorf("unmarshal response wrapper: %w", err)
-	}
-
-	// Unmarshal data into result
-	if err := json.Unmarshal(wrapper.Data, result); err != nil {
-		return fmt.Errorf("unmarshal response data: %w", err)
-	}
-
-	return nil
+	return c.do(req, result)
 }
 
 // post performs a POST request with a JSON body and unmarshals the response into result
 func (c *Client) post(ctx context.Context, path string, body, result interface{}) error {
-	url := c.baseURL + path
-
 	// Marshal body
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, result)
+}
+
+// delete performs a DELETE request and unmarshals the response into result
+func (c *Client) delete(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	return c.do(req, result)
+}
 
+// do sends the request and unmarshals the data field of the response into result
+func (c *Client) do(req *http.Request, result interface{}) error {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("do request: %w", err)
@@ -122,7 +86,7 @@ func (c *Client) post(ctx context.Context, path string, body, result interface{}
 	defer resp.Body.Close()
 
 	// Read body
-	respBody, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("read response body: %w", err)
 	}
@@ -134,7 +98,7 @@ func (c *Client) post(ctx context.Context, path string, body, result interface{}
 			Code    string `json:"code"`
 			Details string `json:"details"`
 		}
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
 			return &APIError{
 				StatusCode: resp.StatusCode,
 				Message:    errResp.Error,
@@ -150,7 +114,7 @@ func (c *Client) post(ctx context.Context, path string, body, result interface{}
 	var wrapper struct {
 		Data json.RawMessage `json:"data"`
 	}
-	if err := json.Unmarshal(respBody, &wrapper); err != nil {
+	if err := json.Unmarshal(body, &wrapper); err != nil {
 		return fmt.Errorf("unmarshal response wrapper: %w", err)
 	}
 
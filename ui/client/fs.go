@@ -14,3 +14,13 @@ func (c *Client) GetFilesystemUsage(ctx context.Context) ([]*models.FilesystemUs
 	}
 	return usage, nil
 }
+
+// GetDeletedFiles returns the node's last scan for deleted files that
+// processes still hold open
+func (c *Client) GetDeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	var report models.DeletedFilesReport
+	if err := c.get(ctx, "/api/v1/fs/deleted", &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
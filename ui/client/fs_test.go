@@ -79,3 +79,30 @@ func TestClient_GetFilesystemUsage_HandlesEmpty(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, usage)
 }
+
+func TestClient_GetDeletedFiles(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/fs/deleted", r.URL.Path)
+
+		report := models.DeletedFilesReport{
+			TotalBytes: 200 << 30,
+			Mounts:     []models.DeletedFilesMount{{MountPoint: "/data", Bytes: 200 << 30, Files: 1}},
+			Processes:  []models.DeletedFilesProcess{{PID: 4242, Name: "java", Bytes: 200 << 30, Files: 1}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": report})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	report, err := client.GetDeletedFiles(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, report.Mounts, 1)
+	assert.Equal(t, "/data", report.Mounts[0].MountPoint)
+	assert.Equal(t, int64(200<<30), report.Processes[0].Bytes)
+}
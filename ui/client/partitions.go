@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// GetPartitions retrieves partition statuses for a check (all checks if check is empty)
+func (c *Client) GetPartitions(ctx context.Context, check string) ([]models.PartitionStatus, error) {
+	var partitions []models.PartitionStatus
+	endpoint := "/api/v1/partitions"
+	if check != "" {
+		endpoint += "?check=" + url.QueryEscape(check)
+	}
+	if err := c.get(ctx, endpoint, &partitions); err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
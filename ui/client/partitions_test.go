@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPartitions_FiltersByCheck(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/partitions", r.URL.Path)
+		assert.Equal(t, "daily events", r.URL.Query().Get("check"))
+
+		partitions := []models.PartitionStatus{
+			{Check: "daily events", Partition: "/warehouse/events/dt=2026-10-19", Status: models.PartitionPending},
+			{Check: "daily events", Partition: "/warehouse/events/dt=2026-10-18", Status: models.PartitionMissing},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": partitions})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	partitions, err := client.GetPartitions(context.Background(), "daily events")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, models.PartitionMissing, partitions[1].Status)
+}
@@ -2,6 +2,9 @@ package client
 
 import (
 	"context"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 )
@@ -15,11 +18,98 @@ func (c *Client) GetPathStats(ctx context.Context) ([]*models.PathStats, error)
 	return stats, nil
 }
 
-// TriggerScan triggers a scan for the specified paths
-func (c *Client) TriggerScan(ctx context.Context, paths []string) error {
+// GetPathViolations retrieves stuck files for a path (all paths if path is empty)
+func (c *Client) GetPathViolations(ctx context.Context, path string) ([]models.PathViolation, error) {
+	var violations []models.PathViolation
+	endpoint := "/api/v1/paths/violations"
+	if path != "" {
+		endpoint += "?path=" + url.QueryEscape(path)
+	}
+	if err := c.get(ctx, endpoint, &violations); err != nil {
+		return nil, err
+	}
+	return violations, nil
+}
+
+// GetPathBreakdown retrieves the subdirectory sizes recorded for a path
+func (c *Client) GetPathBreakdown(ctx context.Context, path string) ([]models.PathDirUsage, error) {
+	var usage []models.PathDirUsage
+	if err := c.get(ctx, "/api/v1/paths/breakdown?path="+url.QueryEscape(path), &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetPathEvents retrieves file events detected after since (all tracked paths if path is empty)
+func (c *Client) GetPathEvents(ctx context.Context, path string, since time.Time) ([]models.PathEvent, error) {
+	var events []models.PathEvent
+	params := url.Values{}
+	if path != "" {
+		params.Set("path", path)
+	}
+	if !since.IsZero() {
+		params.Set("since", since.Format(time.RFC3339))
+	}
+	endpoint := "/api/v1/paths/events"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	if err := c.get(ctx, endpoint, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListPathFiles retrieves the top files under a monitored path
+func (c *Client) ListPathFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error) {
+	params := url.Values{}
+	params.Set("path", path)
+	if q.Sort != "" {
+		params.Set("sort", q.Sort)
+	}
+	if q.Order != "" {
+		params.Set("order", q.Order)
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Pattern != "" {
+		params.Set("pattern", q.Pattern)
+	}
+
+	var list models.PathFileList
+	if err := c.get(ctx, "/api/v1/paths/files?"+params.Encode(), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// TriggerScan queues a scan job for the specified paths
+func (c *Client) TriggerScan(ctx context.Context, paths []string) (*models.ScanJob, error) {
 	body := map[string]interface{}{
 		"paths": paths,
 	}
-	var result map[string]interface{}
-	return c.post(ctx, "/api/v1/paths/scan", body, &result)
+	var job models.ScanJob
+	if err := c.post(ctx, "/api/v1/paths/scan", body, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetScanJob retrieves the status and progress of a scan job
+func (c *Client) GetScanJob(ctx context.Context, id string) (*models.ScanJob, error) {
+	var job models.ScanJob
+	if err := c.get(ctx, "/api/v1/paths/scan/"+url.PathEscape(id), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelScanJob cancels a queued or running scan job
+func (c *Client) CancelScanJob(ctx context.Context, id string) (*models.ScanJob, error) {
+	var job models.ScanJob
+	if err := c.delete(ctx, "/api/v1/paths/scan/"+url.PathEscape(id), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
 }
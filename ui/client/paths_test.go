@@ -72,9 +72,7 @@ func TestClient_TriggerScan_Succeeds(t *testing.T) {
 		json.NewDecoder(r.Body).Decode(&receivedBody)
 
 		response := map[string]interface{}{
-			"data": map[string]string{
-				"status": "triggered",
-			},
+			"data": models.ScanJob{ID: "abc123", Status: models.ScanJobQueued},
 		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
@@ -84,13 +82,184 @@ func TestClient_TriggerScan_Succeeds(t *testing.T) {
 	// Test
 	client := NewClient(server.URL)
 	paths := []string{"/data/logs", "/data/archive"}
-	err := client.TriggerScan(context.Background(), paths)
+	job, err := client.TriggerScan(context.Background(), paths)
 
 	// Assert
 	require.NoError(t, err)
+	assert.Equal(t, "abc123", job.ID)
+	assert.Equal(t, models.ScanJobQueued, job.Status)
 	assert.NotNil(t, receivedBody["paths"])
 	pathsReceived := receivedBody["paths"].([]interface{})
 	assert.Len(t, pathsReceived, 2)
 	assert.Equal(t, "/data/logs", pathsReceived[0])
 	assert.Equal(t, "/data/archive", pathsReceived[1])
 }
+
+func TestClient_GetScanJob_ReturnsProgress(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/scan/abc123", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		job := models.ScanJob{ID: "abc123", Status: models.ScanJobRunning, FilesScanned: 42, DirsScanned: 3}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": job})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	job, err := client.GetScanJob(context.Background(), "abc123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.ScanJobRunning, job.Status)
+	assert.Equal(t, int64(42), job.FilesScanned)
+}
+
+func TestClient_CancelScanJob_SendsDelete(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/scan/abc123", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+
+		job := models.ScanJob{ID: "abc123", Status: models.ScanJobCancelled}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": job})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	job, err := client.CancelScanJob(context.Background(), "abc123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.ScanJobCancelled, job.Status)
+}
+
+func TestClient_GetPathViolations_SendsPathFilter(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/violations", r.URL.Path)
+		assert.Equal(t, "/data/in box", r.URL.Query().Get("path"))
+
+		violations := []models.PathViolation{
+			{
+				Path:      "/data/in box",
+				FilePath:  "/data/in box/partner.csv",
+				SizeBytes: 4096,
+				ModTime:   time.Now().Add(-3 * time.Hour),
+				Reason:    "older than 1h0m0s",
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": violations})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	violations, err := client.GetPathViolations(context.Background(), "/data/in box")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/data/in box/partner.csv", violations[0].FilePath)
+	assert.Equal(t, int64(4096), violations[0].SizeBytes)
+}
+
+func TestClient_GetPathBreakdown_ReturnsDirs(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/breakdown", r.URL.Path)
+		assert.Equal(t, "/data/in box", r.URL.Query().Get("path"))
+
+		usage := []models.PathDirUsage{
+			{Path: "/data/in box", Dir: ".", FileCount: 12, SizeBytes: 8192},
+			{Path: "/data/in box", Dir: "partner_a", Depth: 1, FileCount: 10, SizeBytes: 6144},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": usage})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	usage, err := client.GetPathBreakdown(context.Background(), "/data/in box")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+	assert.Equal(t, "partner_a", usage[1].Dir)
+	assert.Equal(t, int64(6144), usage[1].SizeBytes)
+}
+
+func TestClient_GetPathEvents_SendsSinceFilter(t *testing.T) {
+	since := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/events", r.URL.Path)
+		assert.Equal(t, "/data/input", r.URL.Query().Get("path"))
+		assert.Equal(t, "2026-02-03T12:00:00Z", r.URL.Query().Get("since"))
+
+		events := []models.PathEvent{
+			{ID: 7, Path: "/data/input", FileName: "partner.csv", EventType: models.PathEventCreated, SizeBytes: 512},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": events})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	events, err := client.GetPathEvents(context.Background(), "/data/input", since)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "partner.csv", events[0].FileName)
+	assert.Equal(t, models.PathEventCreated, events[0].EventType)
+}
+
+func TestClient_ListPathFiles_EncodesQuery(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/paths/files", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "/data/in box", q.Get("path"))
+		assert.Equal(t, "mtime", q.Get("sort"))
+		assert.Equal(t, "desc", q.Get("order"))
+		assert.Equal(t, "50", q.Get("limit"))
+		assert.Equal(t, "*.csv", q.Get("pattern"))
+
+		list := models.PathFileList{
+			Path:    "/data/in box",
+			Files:   []models.PathFile{{Name: "partner.csv", SizeBytes: 2048, Owner: "etl"}},
+			Matched: 1,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": list})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	list, err := client.ListPathFiles(context.Background(), "/data/in box", models.PathFilesQuery{
+		Sort:    models.PathFileSortMtime,
+		Order:   "desc",
+		Limit:   50,
+		Pattern: "*.csv",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, list.Files, 1)
+	assert.Equal(t, "partner.csv", list.Files[0].Name)
+	assert.Equal(t, "etl", list.Files[0].Owner)
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+)
+
+// GetPipelines retrieves the latest sample of every stage of each pipeline
+func (c *Client) GetPipelines(ctx context.Context) ([]models.Pipeline, error) {
+	var pipelines []models.Pipeline
+	if err := c.get(ctx, "/api/v1/pipelines", &pipelines); err != nil {
+		return nil, err
+	}
+	return pipelines, nil
+}
+
+// GetPipelineHistory retrieves pipeline stage samples taken after since
+// (all pipelines if name is empty)
+func (c *Client) GetPipelineHistory(ctx context.Context, name string, since time.Time) ([]models.PipelineStage, error) {
+	var samples []models.PipelineStage
+	params := url.Values{}
+	if name != "" {
+		params.Set("name", name)
+	}
+	if !since.IsZero() {
+		params.Set("since", since.Format(time.RFC3339))
+	}
+	endpoint := "/api/v1/pipelines/history"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	if err := c.get(ctx, endpoint, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
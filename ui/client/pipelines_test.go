@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPipelines(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/pipelines", r.URL.Path)
+
+		pipelines := []models.Pipeline{{
+			Name: "ingest",
+			Stages: []models.PipelineStage{
+				{Pipeline: "ingest", Stage: "inbox", Position: 0, FileCount: 40},
+				{Pipeline: "ingest", Stage: "done", Position: 1, FileCount: 900},
+			},
+		}}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": pipelines})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	pipelines, err := client.GetPipelines(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	require.Len(t, pipelines[0].Stages, 2)
+	assert.Equal(t, int64(900), pipelines[0].Stages[1].FileCount)
+}
+
+func TestClient_GetPipelineHistory_SendsFilters(t *testing.T) {
+	since := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/pipelines/history", r.URL.Path)
+		assert.Equal(t, "daily ingest", r.URL.Query().Get("name"))
+		assert.Equal(t, "2026-10-19T11:00:00Z", r.URL.Query().Get("since"))
+
+		samples := []models.PipelineStage{{Pipeline: "daily ingest", Stage: "inbox", Arrived: 12}}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": samples})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	samples, err := client.GetPipelineHistory(context.Background(), "daily ingest", since)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, int64(12), samples[0].Arrived)
+}
@@ -2,6 +2,10 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 )
@@ -14,3 +18,67 @@ func (c *Client) GetProcessInfo(ctx context.Context) ([]*models.ProcessInfo, err
 	}
 	return procs, nil
 }
+
+// GetProcessWatches retrieves the status of each configured process watch
+func (c *Client) GetProcessWatches(ctx context.Context) ([]*models.ProcessWatch, error) {
+	var watches []*models.ProcessWatch
+	if err := c.get(ctx, "/api/v1/processes/watches", &watches); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+// GetProcessHistory retrieves process watch samples taken after since
+// (all watches if watch is empty)
+func (c *Client) GetProcessHistory(ctx context.Context, watch string, since time.Time) ([]models.ProcessWatchSample, error) {
+	var samples []models.ProcessWatchSample
+	params := url.Values{}
+	if watch != "" {
+		params.Set("watch", watch)
+	}
+	if !since.IsZero() {
+		params.Set("since", since.Format(time.RFC3339))
+	}
+	endpoint := "/api/v1/processes/history"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	if err := c.get(ctx, endpoint, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// GetProcessGroups retrieves the processes of the node summed per systemd
+// unit and container
+func (c *Client) GetProcessGroups(ctx context.Context) ([]*models.ProcessGroup, error) {
+	var groups []*models.ProcessGroup
+	if err := c.get(ctx, "/api/v1/processes/groups", &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetProcessTree retrieves the process tree with subtree totals; a root
+// PID above 0 limits it to that process and its descendants
+func (c *Client) GetProcessTree(ctx context.Context, root int) ([]*models.ProcessNode, error) {
+	var nodes []*models.ProcessNode
+	endpoint := "/api/v1/processes/tree"
+	if root > 0 {
+		endpoint += "?root=" + strconv.Itoa(root)
+	}
+	if err := c.get(ctx, endpoint, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetProcessDetails retrieves the open files, sockets and FD limits of a
+// running process
+func (c *Client) GetProcessDetails(ctx context.Context, pid int) (*models.ProcessDetails, error) {
+	var details models.ProcessDetails
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/processes/%d/details", pid), &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetProcessWatches(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/processes/watches", r.URL.Path)
+
+		watches := []*models.ProcessWatch{
+			{Name: "etl_worker", Min: 1, Count: 2, PIDs: []int{20, 21}, Status: models.ProcessWatchUp},
+			{Name: "scheduler", Min: 1, PIDs: []int{}, Status: models.ProcessWatchDown},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": watches})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	watches, err := client.GetProcessWatches(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, watches, 2)
+	assert.Equal(t, []int{20, 21}, watches[0].PIDs)
+	assert.Equal(t, models.ProcessWatchDown, watches[1].Status)
+}
+
+func TestClient_GetProcessTree(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/processes/tree", r.URL.Path)
+		assert.Equal(t, "200", r.URL.Query().Get("root"))
+
+		nodes := []*models.ProcessNode{{
+			PID: 200, Name: "airflow", TreeCPU: 52, Descendants: 1,
+			Children: []*models.ProcessNode{{PID: 201, PPID: 200, Name: "python3", CPUPercent: 50, TreeCPU: 50}},
+		}}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": nodes})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	nodes, err := client.GetProcessTree(context.Background(), 200)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Len(t, nodes[0].Children, 1)
+	assert.Equal(t, 201, nodes[0].Children[0].PID)
+	assert.Equal(t, 52.0, nodes[0].TreeCPU)
+}
+
+func TestClient_GetProcessDetails(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/processes/4242/details", r.URL.Path)
+
+		details := models.ProcessDetails{
+			PID: 4242, Name: "java", OpenFDs: 12, MaxFDs: 1024,
+			Sockets:     []models.ProcessSocket{{FD: 5, Protocol: "tcp", State: "ESTABLISHED", LocalAddr: "10.0.0.5:51234", RemoteAddr: "10.0.0.9:5432"}},
+			ListenPorts: []int{8080},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": details})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	details, err := client.GetProcessDetails(context.Background(), 4242)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 12, details.OpenFDs)
+	assert.Equal(t, []int{8080}, details.ListenPorts)
+	require.Len(t, details.Sockets, 1)
+	assert.Equal(t, "10.0.0.9:5432", details.Sockets[0].RemoteAddr)
+}
+
+func TestClient_GetProcessGroups(t *testing.T) {
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/processes/groups", r.URL.Path)
+
+		groups := []*models.ProcessGroup{
+			{Kind: models.ProcessGroupContainer, Name: "4f1c2d3e4b5a", Processes: 1, CPUPercent: 40},
+			{Kind: models.ProcessGroupUnit, Name: "nifi.service", Processes: 2, CPUPercent: 35, MemoryCurrent: 3 << 30},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": groups})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	groups, err := client.GetProcessGroups(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, models.ProcessGroupContainer, groups[0].Kind)
+	assert.Equal(t, int64(3<<30), groups[1].MemoryCurrent)
+}
+
+func TestClient_GetProcessHistory_SendsFilters(t *testing.T) {
+	since := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/processes/history", r.URL.Path)
+		assert.Equal(t, "etl_worker", r.URL.Query().Get("watch"))
+		assert.Equal(t, "2026-10-19T11:00:00Z", r.URL.Query().Get("since"))
+
+		samples := []models.ProcessWatchSample{{Watch: "etl_worker", Count: 2, CPUPercent: 45, OpenFDs: 70}}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": samples})
+	}))
+	defer server.Close()
+
+	// Test
+	client := NewClient(server.URL)
+	samples, err := client.GetProcessHistory(context.Background(), "etl_worker", since)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 70, samples[0].OpenFDs)
+}
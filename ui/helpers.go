@@ -95,3 +95,47 @@ func FormatNumber(n int64) string {
 	}
 	return string(result)
 }
+
+// sparkBars are the levels of a sparkline, lowest first
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// FormatSparkline renders values as a sparkline of at most width bars,
+// averaging neighbouring values when there are more than width. Bars are
+// scaled between the lowest and highest value, so a flat series is drawn
+// at the lowest level.
+// Example output: ▁▂▂▃▅▇█▆
+func FormatSparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	// Average into buckets when there are more values than bars
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			start, end := i*len(values)/width, (i+1)*len(values)/width
+			sum := 0.0
+			for _, v := range values[start:end] {
+				sum += v
+			}
+			buckets[i] = sum / float64(end-start)
+		}
+		values = buckets
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkBars)-1))
+		}
+		bars[i] = sparkBars[level]
+	}
+	return string(bars)
+}
@@ -197,3 +197,44 @@ func TestFormatNumber_FormatsCorrectly(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSparkline_ScalesValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		expected string
+	}{
+		{
+			name:     "empty",
+			values:   nil,
+			width:    10,
+			expected: "",
+		},
+		{
+			name:     "rising",
+			values:   []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			width:    10,
+			expected: "▁▂▃▄▅▆▇█",
+		},
+		{
+			name:     "flat",
+			values:   []float64{5, 5, 5},
+			width:    10,
+			expected: "▁▁▁",
+		},
+		{
+			name:     "averaged into buckets",
+			values:   []float64{0, 0, 7, 7, 14, 14},
+			width:    3,
+			expected: "▁▄█",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatSparkline(tt.values, tt.width)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -63,14 +63,26 @@ func GaugeColor(percent float64) tcell.Color {
 	return GaugeFilled
 }
 
+// GaugeTag returns the dynamic color tag matching GaugeColor, for usage
+// percentages shown in text views.
+func GaugeTag(percent float64) string {
+	if percent > 90 {
+		return "[red]"
+	}
+	if percent > 75 {
+		return "[yellow]"
+	}
+	return "[green]"
+}
+
 // StatusColor returns the appropriate color for a status string.
 func StatusColor(status string) tcell.Color {
 	switch status {
-	case "OK", "ok", "connected":
+	case "OK", "ok", "connected", "PRESENT", "FRESH", "UP":
 		return StatusOK
-	case "SCANNING", "WARNING", "warning":
+	case "SCANNING", "WARNING", "warning", "TIMEOUT", "LATE", "DEGRADED":
 		return StatusWarning
-	case "ERROR", "error", "CRITICAL", "critical":
+	case "ERROR", "error", "CRITICAL", "critical", "STALE", "MISSING", "DOWN":
 		return StatusCritical
 	default:
 		return FgSecondary
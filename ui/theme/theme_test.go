@@ -30,6 +30,25 @@ func TestGaugeColor_ReturnsCorrectColor(t *testing.T) {
 	}
 }
 
+func TestGaugeTag_MatchesGaugeColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		percent  float64
+		expected string
+	}{
+		{"75% returns green", 75, "[green]"},
+		{"75.1% returns yellow", 75.1, "[yellow]"},
+		{"90% returns yellow", 90, "[yellow]"},
+		{"90.1% returns red", 90.1, "[red]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GaugeTag(tt.percent))
+		})
+	}
+}
+
 func TestStatusColor_ReturnsCorrectColor(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -43,6 +62,15 @@ func TestStatusColor_ReturnsCorrectColor(t *testing.T) {
 		{"WARNING returns yellow", "WARNING", StatusWarning},
 		{"ERROR returns red", "ERROR", StatusCritical},
 		{"CRITICAL returns red", "CRITICAL", StatusCritical},
+		{"TIMEOUT returns yellow", "TIMEOUT", StatusWarning},
+		{"STALE returns red", "STALE", StatusCritical},
+		{"PRESENT returns green", "PRESENT", StatusOK},
+		{"LATE returns yellow", "LATE", StatusWarning},
+		{"MISSING returns red", "MISSING", StatusCritical},
+		{"FRESH returns green", "FRESH", StatusOK},
+		{"UP returns green", "UP", StatusOK},
+		{"DEGRADED returns yellow", "DEGRADED", StatusWarning},
+		{"DOWN returns red", "DOWN", StatusCritical},
 		{"unknown returns secondary", "UNKNOWN", FgSecondary},
 		{"empty returns secondary", "", FgSecondary},
 	}
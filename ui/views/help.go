@@ -38,6 +38,11 @@ func NewHelpView() *HelpView {
 [teal::b]Detail Panel:[-::-]
   [aqua][[silver]/[aqua]][-]     Previous/Next tab
   [aqua]j/k[-]     Navigate within detail content
+  [aqua]Enter[-]   Paths: list files of selected path (Files tab)
+  [aqua]o[-]       Paths Files tab: cycle largest/oldest/newest/name
+  [aqua]Enter[-]   Paths Scan tab: start a scan job for all paths
+  [aqua]x[-]       Paths Scan tab: cancel the running scan job
+  [aqua]Enter[-]   Paths Tree tab: expand/collapse a path or directory
 
 [teal::b]Settings:[-::-]
   [aqua]a[-]       Add new entry
@@ -3,6 +3,7 @@ package views
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 	"github.com/etlmon/etlmon/ui"
@@ -13,8 +14,10 @@ import (
 // FSDetailProvider implements DetailProvider for filesystem monitoring
 type FSDetailProvider struct {
 	data       []*models.FilesystemUsage
-	summaryBox *tview.TextView // Summary tab content
-	usageTable *tview.Table    // Usage tab content
+	deleted    *models.DeletedFilesReport // nil when the node cannot scan for deleted files
+	deletedErr error                      // Why the deleted files are unavailable
+	summaryBox *tview.TextView            // Summary tab content
+	usageTable *tview.Table               // Usage tab content
 }
 
 // NewFSDetailProvider creates a new filesystem detail provider
@@ -32,14 +35,14 @@ func NewFSDetailProvider() *FSDetailProvider {
 		SetFixed(1, 0)
 
 	// Set headers
-	headers := []string{"Mount", "Total", "Used", "Avail", "Use%", "Usage"}
+	headers := []string{"Mount", "Total", "Used", "Avail", "Use%", "Inodes", "IUse%", "Usage"}
 	for i, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(theme.TableHeader).
 			SetAttributes(theme.TableHeaderAttr).
 			SetAlign(tview.AlignLeft).
 			SetSelectable(false)
-		if i == 5 {
+		if i == 7 {
 			cell.SetExpansion(1)
 		}
 		usageTable.SetCell(0, i, cell)
@@ -76,6 +79,14 @@ func (p *FSDetailProvider) Refresh(ctx context.Context, client ui.APIClient) err
 	}
 
 	p.data = usage
+
+	// An older node, or one without /proc, only loses the deleted files
+	// annotations; the summary says they are unavailable
+	p.deleted, p.deletedErr = client.GetDeletedFiles(ctx)
+	if p.deletedErr != nil {
+		p.deleted = nil
+	}
+
 	p.updateSummaryTab()
 	p.updateUsageTab()
 
@@ -109,11 +120,64 @@ func (p *FSDetailProvider) updateSummaryTab() {
 
 	// Build summary text
 	summary := fmt.Sprintf("%s%sFilesystem Summary%s\n\n", theme.TagBold, theme.TagAccent, theme.TagReset)
-	summary += fmt.Sprintf("%sTotal Mounts:%s %d\n\n", theme.TagLabel, theme.TagReset, len(p.data))
+	summary += fmt.Sprintf("%sTotal Mounts:%s %d\n", theme.TagLabel, theme.TagReset, len(p.data))
+	// Mounts whose last collection failed show their last known usage
+	for _, fs := range p.data {
+		if isFilesystemFailed(fs) {
+			summary += fmt.Sprintf("  [%s]%s%s %s\n", theme.StatusColor(fs.Status), fs.Status, theme.TagReset, fs.MountPoint)
+		}
+	}
+	summary += "\n"
 	summary += fmt.Sprintf("%sTotal Capacity:%s %s\n", theme.TagLabel, theme.TagReset, ui.FormatBytes(totalCapacity))
 	summary += fmt.Sprintf("%sTotal Used:%s     %s\n", theme.TagLabel, theme.TagReset, ui.FormatBytes(totalUsed))
 	summary += fmt.Sprintf("%sTotal Available:%s %s\n", theme.TagLabel, theme.TagReset, ui.FormatBytes(totalAvail))
-	summary += fmt.Sprintf("%sOverall Usage:%s   %.1f%%\n", theme.TagLabel, theme.TagReset, overallPercent)
+	summary += fmt.Sprintf("%sOverall Usage:%s   %s%.1f%%%s\n", theme.TagLabel, theme.TagReset,
+		theme.GaugeTag(overallPercent), overallPercent, theme.TagReset)
+
+	// Inodes, over the filesystems that have a fixed inode count
+	var totalInodes, usedInodes uint64
+	var fullest *models.FilesystemUsage
+	for _, fs := range p.data {
+		if fs.InodesTotal == 0 {
+			continue
+		}
+		totalInodes += fs.InodesTotal
+		usedInodes += fs.InodesUsed
+		if fullest == nil || fs.InodesUsedPercent > fullest.InodesUsedPercent {
+			fullest = fs
+		}
+	}
+	if fullest != nil {
+		inodePercent := float64(usedInodes) / float64(totalInodes) * 100.0
+		summary += fmt.Sprintf("\n%sTotal Inodes:%s   %s\n", theme.TagLabel, theme.TagReset, ui.FormatNumber(int64(totalInodes)))
+		summary += fmt.Sprintf("%sInodes Used:%s    %s\n", theme.TagLabel, theme.TagReset, ui.FormatNumber(int64(usedInodes)))
+		summary += fmt.Sprintf("%sInode Usage:%s    %s%.1f%%%s\n", theme.TagLabel, theme.TagReset,
+			theme.GaugeTag(inodePercent), inodePercent, theme.TagReset)
+		// A single full landing zone hides in the overall figure
+		summary += fmt.Sprintf("%sFullest (Inodes):%s %s %s%.1f%%%s\n", theme.TagLabel, theme.TagReset,
+			fullest.MountPoint, theme.GaugeTag(fullest.InodesUsedPercent), fullest.InodesUsedPercent, theme.TagReset)
+	}
+
+	// Space that df counts as used but du cannot find
+	if p.deletedErr != nil {
+		summary += fmt.Sprintf("\n%sHeld by Deleted Files:%s %sunavailable (%v)%s\n",
+			theme.TagLabel, theme.TagReset, theme.TagMuted, p.deletedErr, theme.TagReset)
+	}
+	if p.deleted != nil && p.deleted.TotalBytes > 0 {
+		summary += fmt.Sprintf("\n%sHeld by Deleted Files:%s %s (%d files)\n",
+			theme.TagLabel, theme.TagReset, ui.FormatBytes(uint64(p.deleted.TotalBytes)), p.deleted.TotalFiles)
+		for i, proc := range p.deleted.Processes {
+			if i == maxDeletedHolders {
+				summary += fmt.Sprintf("  %s... %d more processes%s\n", theme.TagMuted, len(p.deleted.Processes)-i, theme.TagReset)
+				break
+			}
+			summary += fmt.Sprintf("  %s (%d)  %s\n", proc.Name, proc.PID, ui.FormatBytes(uint64(proc.Bytes)))
+		}
+	}
+	if p.deleted != nil && p.deleted.Unresponsive > 0 {
+		summary += fmt.Sprintf("  %s%d deleted files on unresponsive mounts not counted%s\n",
+			theme.TagMuted, p.deleted.Unresponsive, theme.TagReset)
+	}
 
 	p.summaryBox.SetText(summary)
 }
@@ -130,8 +194,12 @@ func (p *FSDetailProvider) updateUsageTab() {
 		row := i + 1
 
 		// Mount point
+		mountColor := theme.FgPrimary
+		if isFilesystemFailed(fs) {
+			mountColor = theme.StatusColor(fs.Status)
+		}
 		p.usageTable.SetCell(row, 0, tview.NewTableCell(fs.MountPoint).
-			SetTextColor(theme.FgPrimary))
+			SetTextColor(mountColor))
 
 		// Total
 		p.usageTable.SetCell(row, 1, tview.NewTableCell(ui.FormatBytes(fs.TotalBytes)).
@@ -154,9 +222,75 @@ func (p *FSDetailProvider) updateUsageTab() {
 			SetTextColor(color).
 			SetAlign(tview.AlignRight))
 
-		// Usage gauge
-		p.usageTable.SetCell(row, 5, tview.NewTableCell(ui.FormatGauge(fs.UsedPercent, 25)).
+		// Inodes, which small-file landing zones can run out of first
+		if fs.InodesTotal > 0 {
+			p.usageTable.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%s/%s",
+				ui.FormatNumber(int64(fs.InodesUsed)), ui.FormatNumber(int64(fs.InodesTotal)))).
+				SetTextColor(theme.FgPrimary).
+				SetAlign(tview.AlignRight))
+			p.usageTable.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%.1f%%", fs.InodesUsedPercent)).
+				SetTextColor(theme.GaugeColor(fs.InodesUsedPercent)).
+				SetAlign(tview.AlignRight))
+		} else {
+			p.usageTable.SetCell(row, 5, tview.NewTableCell("-").
+				SetTextColor(theme.FgMuted).
+				SetAlign(tview.AlignRight))
+			p.usageTable.SetCell(row, 6, tview.NewTableCell("-").
+				SetTextColor(theme.FgMuted).
+				SetAlign(tview.AlignRight))
+		}
+
+		// A failed mount shows why instead of its last usage as current
+		if isFilesystemFailed(fs) {
+			p.usageTable.SetCell(row, 7, tview.NewTableCell(formatFilesystemFailure(fs)).
+				SetTextColor(theme.StatusColor(fs.Status)).
+				SetExpansion(1))
+			continue
+		}
+
+		// Usage gauge, noting space held by deleted files
+		gauge := ui.FormatGauge(fs.UsedPercent, 25)
+		if held := p.deletedBytes(fs.MountPoint); held > 0 {
+			gauge += fmt.Sprintf("  %s held by deleted files", ui.FormatBytes(uint64(held)))
+		}
+		p.usageTable.SetCell(row, 7, tview.NewTableCell(gauge).
 			SetTextColor(theme.GaugeColor(fs.UsedPercent)).
 			SetExpansion(1))
 	}
 }
+
+// isFilesystemFailed reports whether the last collection of a mount failed.
+// Older nodes send no status.
+func isFilesystemFailed(fs *models.FilesystemUsage) bool {
+	return fs.Status != "" && fs.Status != models.FilesystemOK
+}
+
+// formatFilesystemFailure renders a failed mount's status, reason and the
+// age of its last successful collection
+func formatFilesystemFailure(fs *models.FilesystemUsage) string {
+	text := fs.Status
+	if fs.StatusReason != "" {
+		text += ": " + fs.StatusReason
+	}
+	if fs.CollectedAt.IsZero() {
+		return text + ", never collected"
+	}
+	return fmt.Sprintf("%s, last ok %s ago", text, formatDuration(time.Since(fs.CollectedAt)))
+}
+
+// maxDeletedHolders caps the processes listed as holding deleted files in
+// the summary
+const maxDeletedHolders = 5
+
+// deletedBytes returns the space held by deleted files on a mount
+func (p *FSDetailProvider) deletedBytes(mountPoint string) int64 {
+	if p.deleted == nil {
+		return 0
+	}
+	for _, m := range p.deleted.Mounts {
+		if m.MountPoint == mountPoint {
+			return m.Bytes
+		}
+	}
+	return 0
+}
@@ -2,21 +2,46 @@ package views
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/etlmon/etlmon/internal/config"
 	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/etlmon/etlmon/ui/theme"
 )
 
 // mockAPIClient implements ui.APIClient for testing
 type mockAPIClient struct {
 	fsUsage       []*models.FilesystemUsage
+	deletedFiles  *models.DeletedFilesReport
+	deletedErr    error
 	pathStats     []*models.PathStats
 	procInfo      []*models.ProcessInfo
+	procWatches   []*models.ProcessWatch
+	watchesErr    error
+	procHistory   []models.ProcessWatchSample
+	historyErr    error
+	procGroups    []*models.ProcessGroup
+	groupsErr     error
+	procTree      []*models.ProcessNode
+	treeErr       error
+	procDetails   *models.ProcessDetails
+	detailsPID    int
+	detailsErr    error
 	logFiles      []models.LogFileInfo
 	logEntries    []*models.LogEntry
+	pathFiles     *models.PathFileList
+	scanJob       *models.ScanJob
+	scannedPaths  []string
+	cancelledJob  string
+	filesQuery    models.PathFilesQuery
+	breakdown     []models.PathDirUsage
+	breakdownPath string
+	partitions    []models.PartitionStatus
+	pipelines     []models.Pipeline
+	pipeHistory   []models.PipelineStage
 	cfg           *config.NodeConfig
 	fsErr         error
 	pathErr       error
@@ -24,6 +49,10 @@ type mockAPIClient struct {
 	logErr        error
 	logEntriesErr error
 	scanErr       error
+	filesErr      error
+	breakdownErr  error
+	partitionsErr error
+	pipelinesErr  error
 	cfgErr        error
 	saveErr       error
 }
@@ -32,18 +61,75 @@ func (m *mockAPIClient) GetFilesystemUsage(ctx context.Context) ([]*models.Files
 	return m.fsUsage, m.fsErr
 }
 
+func (m *mockAPIClient) GetDeletedFiles(ctx context.Context) (*models.DeletedFilesReport, error) {
+	return m.deletedFiles, m.deletedErr
+}
+
 func (m *mockAPIClient) GetPathStats(ctx context.Context) ([]*models.PathStats, error) {
 	return m.pathStats, m.pathErr
 }
 
-func (m *mockAPIClient) TriggerScan(ctx context.Context, paths []string) error {
-	return m.scanErr
+func (m *mockAPIClient) TriggerScan(ctx context.Context, paths []string) (*models.ScanJob, error) {
+	m.scannedPaths = paths
+	return m.scanJob, m.scanErr
+}
+
+func (m *mockAPIClient) GetScanJob(ctx context.Context, id string) (*models.ScanJob, error) {
+	return m.scanJob, m.scanErr
+}
+
+func (m *mockAPIClient) CancelScanJob(ctx context.Context, id string) (*models.ScanJob, error) {
+	m.cancelledJob = id
+	return m.scanJob, m.scanErr
+}
+
+func (m *mockAPIClient) ListPathFiles(ctx context.Context, path string, q models.PathFilesQuery) (*models.PathFileList, error) {
+	m.filesQuery = q
+	return m.pathFiles, m.filesErr
+}
+
+func (m *mockAPIClient) GetPathBreakdown(ctx context.Context, path string) ([]models.PathDirUsage, error) {
+	m.breakdownPath = path
+	return m.breakdown, m.breakdownErr
+}
+
+func (m *mockAPIClient) GetPartitions(ctx context.Context, check string) ([]models.PartitionStatus, error) {
+	return m.partitions, m.partitionsErr
+}
+
+func (m *mockAPIClient) GetPipelines(ctx context.Context) ([]models.Pipeline, error) {
+	return m.pipelines, m.pipelinesErr
+}
+
+func (m *mockAPIClient) GetPipelineHistory(ctx context.Context, name string, since time.Time) ([]models.PipelineStage, error) {
+	return m.pipeHistory, m.pipelinesErr
 }
 
 func (m *mockAPIClient) GetProcessInfo(ctx context.Context) ([]*models.ProcessInfo, error) {
 	return m.procInfo, m.procErr
 }
 
+func (m *mockAPIClient) GetProcessWatches(ctx context.Context) ([]*models.ProcessWatch, error) {
+	return m.procWatches, m.watchesErr
+}
+
+func (m *mockAPIClient) GetProcessHistory(ctx context.Context, watch string, since time.Time) ([]models.ProcessWatchSample, error) {
+	return m.procHistory, m.historyErr
+}
+
+func (m *mockAPIClient) GetProcessGroups(ctx context.Context) ([]*models.ProcessGroup, error) {
+	return m.procGroups, m.groupsErr
+}
+
+func (m *mockAPIClient) GetProcessTree(ctx context.Context, root int) ([]*models.ProcessNode, error) {
+	return m.procTree, m.treeErr
+}
+
+func (m *mockAPIClient) GetProcessDetails(ctx context.Context, pid int) (*models.ProcessDetails, error) {
+	m.detailsPID = pid
+	return m.procDetails, m.detailsErr
+}
+
 func (m *mockAPIClient) GetLogFiles(ctx context.Context) ([]models.LogFileInfo, error) {
 	return m.logFiles, m.logErr
 }
@@ -210,7 +296,7 @@ func TestFSProvider_UsageTab(t *testing.T) {
 	}
 
 	// Check header cells
-	headers := []string{"Mount", "Total", "Used", "Avail", "Use%", "Usage"}
+	headers := []string{"Mount", "Total", "Used", "Avail", "Use%", "Inodes", "IUse%", "Usage"}
 	for col, expectedHeader := range headers {
 		cell := provider.usageTable.GetCell(0, col)
 		if cell == nil {
@@ -232,7 +318,7 @@ func TestFSProvider_UsageTab(t *testing.T) {
 	}
 
 	// Check gauge column exists
-	gaugeCell := provider.usageTable.GetCell(1, 5)
+	gaugeCell := provider.usageTable.GetCell(1, 7)
 	if gaugeCell == nil {
 		t.Fatal("gauge cell is nil")
 	}
@@ -240,3 +326,149 @@ func TestFSProvider_UsageTab(t *testing.T) {
 		t.Errorf("gauge cell should contain gauge characters, got %q", gaugeCell.Text)
 	}
 }
+
+func TestFSProvider_DeletedFiles(t *testing.T) {
+	mock := &mockAPIClient{
+		fsUsage: []*models.FilesystemUsage{
+			{MountPoint: "/", TotalBytes: 100 << 30, UsedBytes: 40 << 30, AvailBytes: 60 << 30, UsedPercent: 40},
+			{MountPoint: "/data", TotalBytes: 1000 << 30, UsedBytes: 980 << 30, AvailBytes: 20 << 30, UsedPercent: 98},
+		},
+		deletedFiles: &models.DeletedFilesReport{
+			TotalBytes: 200 << 30,
+			TotalFiles: 2,
+			Mounts:     []models.DeletedFilesMount{{MountPoint: "/data", Bytes: 200 << 30, Files: 2}},
+			Processes:  []models.DeletedFilesProcess{{PID: 4242, Name: "java", Bytes: 200 << 30, Files: 2}},
+		},
+	}
+
+	provider := NewFSDetailProvider()
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if got := provider.usageTable.GetCell(2, 7).Text; !strings.HasSuffix(got, "200.00 GB held by deleted files") {
+		t.Errorf("expected /data to note the deleted files, got %q", got)
+	}
+	if got := provider.usageTable.GetCell(1, 7).Text; strings.Contains(got, "deleted") {
+		t.Errorf("expected no note on /, got %q", got)
+	}
+	text := provider.summaryBox.GetText(true)
+	if !strings.Contains(text, "Held by Deleted Files: 200.00 GB (2 files)") || !strings.Contains(text, "java (4242)") {
+		t.Errorf("expected the deleted files and their holder in the summary, got %q", text)
+	}
+}
+
+func TestFSProvider_DeletedFilesErrorKeepsUsage(t *testing.T) {
+	mock := &mockAPIClient{
+		fsUsage:    []*models.FilesystemUsage{{MountPoint: "/data", TotalBytes: 100, UsedBytes: 98, UsedPercent: 98}},
+		deletedErr: errors.New("501 not implemented"),
+	}
+
+	provider := NewFSDetailProvider()
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected deleted files errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.usageTable.GetRowCount() != 2 || strings.Contains(provider.usageTable.GetCell(1, 7).Text, "deleted") {
+		t.Errorf("expected the usage without a deleted files note")
+	}
+	if text := provider.summaryBox.GetText(true); !strings.Contains(text, "Held by Deleted Files: unavailable (501 not implemented)") {
+		t.Errorf("expected the summary to show the deleted files as unavailable, got %q", text)
+	}
+}
+
+func TestFSProvider_DeletedFilesUnresponsive(t *testing.T) {
+	mock := &mockAPIClient{
+		fsUsage:      []*models.FilesystemUsage{{MountPoint: "/data", TotalBytes: 100, UsedBytes: 50, UsedPercent: 50}},
+		deletedFiles: &models.DeletedFilesReport{Unresponsive: 3},
+	}
+
+	provider := NewFSDetailProvider()
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if text := provider.summaryBox.GetText(true); !strings.Contains(text, "3 deleted files on unresponsive mounts not counted") {
+		t.Errorf("expected the summary to note the unresponsive mounts, got %q", text)
+	}
+}
+
+func TestFSProvider_FailedMounts(t *testing.T) {
+	mock := &mockAPIClient{
+		fsUsage: []*models.FilesystemUsage{
+			{MountPoint: "/", TotalBytes: 100, UsedBytes: 40, UsedPercent: 40, Status: models.FilesystemOK},
+			{MountPoint: "/mnt/etl", TotalBytes: 100, UsedBytes: 50, UsedPercent: 50,
+				Status: models.FilesystemStale, StatusReason: "statfs timed out for /mnt/etl after 5s",
+				CollectedAt: time.Now().Add(-10 * time.Minute)},
+			{MountPoint: "/mnt/new", Status: models.FilesystemError, StatusReason: "statfs failed for /mnt/new: input/output error"},
+		},
+	}
+
+	provider := NewFSDetailProvider()
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if got := provider.usageTable.GetCell(1, 7).Text; strings.Contains(got, "STALE") {
+		t.Errorf("expected the gauge for an OK mount, got %q", got)
+	}
+	if got, want := provider.usageTable.GetCell(2, 7).Text, "STALE: statfs timed out for /mnt/etl after 5s, last ok 10m ago"; got != want {
+		t.Errorf("stale mount = %q, want %q", got, want)
+	}
+	if got, _, _ := provider.usageTable.GetCell(2, 0).Style.Decompose(); got != theme.StatusCritical {
+		t.Errorf("expected the stale mount highlighted, got color %v", got)
+	}
+	if got := provider.usageTable.GetCell(3, 7).Text; !strings.HasSuffix(got, "never collected") {
+		t.Errorf("expected a mount never collected to say so, got %q", got)
+	}
+
+	text := provider.summaryBox.GetText(true)
+	if !strings.Contains(text, "STALE /mnt/etl") || !strings.Contains(text, "ERROR /mnt/new") {
+		t.Errorf("expected the summary to list the failed mounts, got %q", text)
+	}
+}
+
+func TestFSProvider_Inodes(t *testing.T) {
+	mock := &mockAPIClient{
+		fsUsage: []*models.FilesystemUsage{
+			{MountPoint: "/", TotalBytes: 100 << 30, UsedBytes: 40 << 30, UsedPercent: 40,
+				InodesTotal: 1000000, InodesUsed: 200000, InodesFree: 800000, InodesUsedPercent: 20},
+			{MountPoint: "/landing", TotalBytes: 100 << 30, UsedBytes: 20 << 30, UsedPercent: 20,
+				InodesTotal: 65536, InodesUsed: 64880, InodesFree: 656, InodesUsedPercent: 99},
+			// btrfs reports no inode count
+			{MountPoint: "/srv", TotalBytes: 100 << 30, UsedBytes: 10 << 30, UsedPercent: 10},
+		},
+	}
+
+	provider := NewFSDetailProvider()
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	tests := []struct {
+		row, col int
+		want     string
+	}{
+		{1, 5, "200,000/1,000,000"},
+		{1, 6, "20.0%"},
+		{2, 6, "99.0%"},
+		{3, 5, "-"},
+		{3, 6, "-"},
+	}
+	for _, tt := range tests {
+		if got := provider.usageTable.GetCell(tt.row, tt.col).Text; got != tt.want {
+			t.Errorf("cell [%d,%d]: expected %q, got %q", tt.row, tt.col, tt.want, got)
+		}
+	}
+	// Inode usage is colored like byte usage
+	if got, _, _ := provider.usageTable.GetCell(2, 6).Style.Decompose(); got != theme.GaugeCrit {
+		t.Errorf("expected /landing inode usage in the critical color, got %v", got)
+	}
+
+	text := provider.summaryBox.GetText(true)
+	for _, want := range []string{"Total Inodes:   1,065,536", "Inodes Used:    264,880", "Fullest (Inodes): /landing 99.0%"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the summary, got %q", want, text)
+		}
+	}
+}
@@ -3,6 +3,12 @@ package views
 import (
 	"context"
 	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 	"github.com/etlmon/etlmon/ui"
@@ -18,10 +24,43 @@ type PathsDetailProvider struct {
 	scanFlex   *tview.Flex         // Scan tab content
 	scanTable  *tview.Table        // Scan tab path list
 	scanStatus *tview.TextView     // Scan status message
-	apiClient  ui.APIClient        // needed for TriggerScan
+	scanJob    *models.ScanJob     // Last manual scan job, polled until done
+	filesFlex  *tview.Flex         // Files tab content
+	filesInfo  *tview.TextView     // Files tab header (path, sort, match count)
+	filesTable *tview.Table        // Files tab file list
+	filesPath  string              // Path shown in the Files tab
+	filesSort  int                 // Index into pathFileSortModes
+	filesList  *models.PathFileList
+	treeView   *tview.TreeView     // Tree tab: paths expanded into their subdirectory breakdown
+	partitions []models.PartitionStatus
+	partTable  *tview.Table        // Partitions tab: partition completeness per check
+	pipelines  []models.Pipeline
+	throughput map[string]stageFlow // Files in and out of each pipeline stage over the last hour
+	pipeTable  *tview.Table        // Pipelines tab: each pipeline rendered as a stage funnel
+	apiClient  ui.APIClient        // needed for TriggerScan and ListPathFiles
 	tviewApp   *tview.Application  // for QueueUpdateDraw
 }
 
+// pathFileSortMode is a Files tab ordering, cycled with the 'o' key
+type pathFileSortMode struct {
+	label string
+	sort  string
+	order string
+}
+
+var pathFileSortModes = []pathFileSortMode{
+	{"largest", models.PathFileSortSize, "desc"},
+	{"oldest", models.PathFileSortMtime, "asc"},
+	{"newest", models.PathFileSortMtime, "desc"},
+	{"name", models.PathFileSortName, "asc"},
+}
+
+// pathFilesLimit is the number of files requested for the Files tab
+const pathFilesLimit = 200
+
+// scanJobPollInterval is how often a running manual scan job is polled for progress
+const scanJobPollInterval = time.Second
+
 // NewPathsDetailProvider creates a new paths detail provider
 func NewPathsDetailProvider(client ui.APIClient, app *tview.Application) *PathsDetailProvider {
 	// Create stats table (reusing paths.go table setup logic)
@@ -30,16 +69,8 @@ func NewPathsDetailProvider(client ui.APIClient, app *tview.Application) *PathsD
 		SetSelectable(true, false).
 		SetFixed(1, 0)
 
-	// Set headers
-	headers := []string{"Path", "Files", "Dirs", "Duration", "Status"}
-	for i, header := range headers {
-		cell := tview.NewTableCell(header).
-			SetTextColor(theme.TableHeader).
-			SetAttributes(theme.TableHeaderAttr).
-			SetAlign(tview.AlignLeft).
-			SetSelectable(false)
-		statsTable.SetCell(0, i, cell)
-	}
+	// Set headers; bucket columns are added once stats arrive
+	setStatsHeaders(statsTable, nil)
 
 	// Create scan tab components
 	scanTable := tview.NewTable().
@@ -48,10 +79,16 @@ func NewPathsDetailProvider(client ui.APIClient, app *tview.Application) *PathsD
 		SetFixed(1, 0)
 
 	// Scan table headers
-	scanTable.SetCell(0, 0, tview.NewTableCell("Path").
-		SetTextColor(theme.TableHeader).
-		SetAttributes(theme.TableHeaderAttr).
-		SetSelectable(false))
+	for i, header := range []string{"Path", "Status", "Reason"} {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetSelectable(false)
+		if i == 2 { // Reason column expands
+			cell.SetExpansion(1)
+		}
+		scanTable.SetCell(0, i, cell)
+	}
 
 	scanStatus := tview.NewTextView().
 		SetDynamicColors(true).
@@ -63,21 +100,124 @@ func NewPathsDetailProvider(client ui.APIClient, app *tview.Application) *PathsD
 		AddItem(scanTable, 0, 1, true).
 		AddItem(scanStatus, 3, 0, false)
 
+	// Create files tab components
+	filesTable := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	filesHeaders := []string{"Name", "Size", "Modified", "Owner"}
+	filesAligns := []int{tview.AlignLeft, tview.AlignRight, tview.AlignRight, tview.AlignLeft}
+	for i, header := range filesHeaders {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetAlign(filesAligns[i]).
+			SetSelectable(false)
+		if i == 0 { // Name column expands
+			cell.SetExpansion(1)
+		}
+		filesTable.SetCell(0, i, cell)
+	}
+
+	filesInfo := tview.NewTextView().
+		SetDynamicColors(true)
+	filesInfo.SetText(fmt.Sprintf("%sSelect a path in the Stats tab and press Enter to list its files%s", theme.TagLabel, theme.TagReset))
+
+	filesFlex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(filesInfo, 1, 0, false).
+		AddItem(filesTable, 0, 1, true)
+
+	// Create tree tab; path nodes are filled in by Refresh
+	treeRoot := tview.NewTreeNode("Paths").
+		SetColor(theme.TableHeader).
+		SetSelectable(false)
+	treeView := tview.NewTreeView().
+		SetRoot(treeRoot).
+		SetTopLevel(1)
+
+	// Create partitions tab
+	partTable := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Check", "Partition", "Status", "Due", "Arrived"} {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetSelectable(false)
+		if i == 1 { // Partition column expands
+			cell.SetExpansion(1)
+		}
+		partTable.SetCell(0, i, cell)
+	}
+
+	// Create pipelines tab
+	pipeTable := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range pipelineHeaders {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetSelectable(false)
+		if i == 2 { // Funnel column expands
+			cell.SetExpansion(1)
+		}
+		pipeTable.SetCell(0, i, cell)
+	}
+
 	p := &PathsDetailProvider{
 		statsTable: statsTable,
 		scanFlex:   scanFlex,
 		scanTable:  scanTable,
 		scanStatus: scanStatus,
+		filesFlex:  filesFlex,
+		filesInfo:  filesInfo,
+		filesTable: filesTable,
+		treeView:   treeView,
+		partTable:  partTable,
+		pipeTable:  pipeTable,
 		apiClient:  client,
 		tviewApp:   app,
 	}
 
-	// Set up Enter key handler for scan trigger
+	// Enter on a stats row drills down into that path's files
+	statsTable.SetSelectedFunc(func(row, column int) {
+		if row < 1 || row > len(p.data) {
+			return
+		}
+		p.filesPath = p.data[row-1].Path
+		p.loadFilesAsync()
+	})
+
+	// Enter on a tree node expands or collapses it
+	treeView.SetSelectedFunc(p.toggleTreeNode)
+
+	// 'o' cycles the Files tab ordering
+	filesTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == 'o' {
+			p.filesSort = (p.filesSort + 1) % len(pathFileSortModes)
+			p.loadFilesAsync()
+			return nil
+		}
+		return event
+	})
+
+	// Enter starts a scan job for all paths, 'x' cancels it
 	scanTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEnter {
 			p.triggerScan()
 			return nil
 		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'x' {
+			p.cancelScan()
+			return nil
+		}
 		return event
 	})
 
@@ -86,7 +226,7 @@ func NewPathsDetailProvider(client ui.APIClient, app *tview.Application) *PathsD
 
 // Tabs returns the list of tab names
 func (p *PathsDetailProvider) Tabs() []string {
-	return []string{"Stats", "Scan"}
+	return []string{"Stats", "Scan", "Files", "Tree", "Partitions", "Pipelines"}
 }
 
 // TabContent returns the tview Primitive for the given tab index
@@ -96,6 +236,14 @@ func (p *PathsDetailProvider) TabContent(tabIndex int) tview.Primitive {
 		return p.statsTable
 	case 1:
 		return p.scanFlex
+	case 2:
+		return p.filesFlex
+	case 3:
+		return p.treeView
+	case 4:
+		return p.partTable
+	case 5:
+		return p.pipeTable
 	default:
 		return nil
 	}
@@ -108,9 +256,26 @@ func (p *PathsDetailProvider) Refresh(ctx context.Context, client ui.APIClient)
 		return err
 	}
 
+	sortByStaleness(stats)
 	p.data = stats
 	p.updateStatsTab()
 	p.updateScanTab()
+	p.updateTreeTab()
+
+	// A node without partition checks, or an older one, only affects the Partitions tab
+	partitions, err := client.GetPartitions(ctx, "")
+	p.partitions = partitions
+	p.updatePartitionsTab(err)
+
+	// Likewise for pipelines; throughput is summed over the last hour of samples
+	pipelines, err := client.GetPipelines(ctx)
+	var history []models.PipelineStage
+	if err == nil && len(pipelines) > 0 {
+		history, err = client.GetPipelineHistory(ctx, "", time.Now().Add(-time.Hour))
+	}
+	p.pipelines = pipelines
+	p.throughput = sumStageFlow(history)
+	p.updatePipelinesTab(err)
 
 	return nil
 }
@@ -120,12 +285,97 @@ func (p *PathsDetailProvider) OnSelect(activeTabIndex int) {
 	// No special action needed
 }
 
+// pathStatsHeaders are the fixed Stats tab columns, followed by one column per bucket
+var pathStatsHeaders = []string{"Path", "Files", "Dirs", "Duration", "Status", "Freshness"}
+
+// setStatsHeaders writes the Stats tab header row with a column per bucket
+// name, dropping bucket columns that no longer exist
+func setStatsHeaders(table *tview.Table, buckets []string) {
+	headers := append(append([]string{}, pathStatsHeaders...), buckets...)
+	for i := table.GetColumnCount() - 1; i >= len(headers); i-- {
+		table.RemoveColumn(i)
+	}
+	for i, header := range headers {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false)
+		if i >= len(pathStatsHeaders) {
+			cell.SetAlign(tview.AlignRight)
+		}
+		table.SetCell(0, i, cell)
+	}
+}
+
+// freshnessRank orders freshness states from dead feeds down to paths without a cadence
+var freshnessRank = map[string]int{
+	models.FreshnessStale: 3,
+	models.FreshnessLate:  2,
+	models.FreshnessFresh: 1,
+}
+
+// sortByStaleness moves stale and late paths to the top, oldest data first
+// and paths without any files before those. Paths without a cadence keep
+// their original order at the bottom.
+func sortByStaleness(data []*models.PathStats) {
+	age := func(ps *models.PathStats) int64 {
+		if ps.NewestFileAt == nil {
+			return math.MaxInt64
+		}
+		return ps.DataAgeSec
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		ri, rj := freshnessRank[data[i].Freshness], freshnessRank[data[j].Freshness]
+		if ri != rj {
+			return ri > rj
+		}
+		return ri > 0 && age(data[i]) > age(data[j])
+	})
+}
+
+// formatFreshness renders a path's freshness with the age of its newest file
+func formatFreshness(ps *models.PathStats) string {
+	switch {
+	case ps.Freshness == "":
+		return "-"
+	case ps.NewestFileAt == nil:
+		return ps.Freshness + " (no files)"
+	default:
+		return fmt.Sprintf("%s (%s)", ps.Freshness, formatDuration(time.Duration(ps.DataAgeSec)*time.Second))
+	}
+}
+
+// bucketNames returns the bucket names reported by any path, sorted
+func bucketNames(data []*models.PathStats) []string {
+	var names []string
+	for _, ps := range data {
+		for _, b := range ps.Buckets {
+			if !slices.Contains(names, b.Name) {
+				names = append(names, b.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatBucket renders a bucket cell as "files (size)", or "0" when empty
+func formatBucket(b models.PathBucket) string {
+	if b.FileCount == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%s (%s)", ui.FormatNumber(b.FileCount), formatFileSize(b.SizeBytes))
+}
+
 // updateStatsTab populates the stats table (reusing paths.go rendering logic)
 func (p *PathsDetailProvider) updateStatsTab() {
 	// Clear existing rows (keep header)
 	for i := p.statsTable.GetRowCount() - 1; i > 0; i-- {
 		p.statsTable.RemoveRow(i)
 	}
+	buckets := bucketNames(p.data)
+	setStatsHeaders(p.statsTable, buckets)
 
 	// Populate rows
 	for i, ps := range p.data {
@@ -154,6 +404,24 @@ func (p *PathsDetailProvider) updateStatsTab() {
 		color := theme.StatusColor(ps.Status)
 		p.statsTable.SetCell(row, 4, tview.NewTableCell(ps.Status).
 			SetTextColor(color))
+
+		// Freshness of the newest file against the expected cadence
+		p.statsTable.SetCell(row, 5, tview.NewTableCell(formatFreshness(ps)).
+			SetTextColor(theme.StatusColor(ps.Freshness)))
+
+		// Buckets, "-" where the path does not configure one
+		for j, name := range buckets {
+			text := "-"
+			for _, b := range ps.Buckets {
+				if b.Name == name {
+					text = formatBucket(b)
+					break
+				}
+			}
+			p.statsTable.SetCell(row, len(pathStatsHeaders)+j, tview.NewTableCell(text).
+				SetTextColor(theme.FgPrimary).
+				SetAlign(tview.AlignRight))
+		}
 	}
 }
 
@@ -164,23 +432,328 @@ func (p *PathsDetailProvider) updateScanTab() {
 		p.scanTable.RemoveRow(i)
 	}
 
-	// Populate path list
+	// Populate path list, highlighting paths that violate their SLA or sit on a stale mount
 	for i, ps := range p.data {
 		row := i + 1
+
+		pathColor := theme.FgPrimary
+		var attr tcell.AttrMask
+		if isSLAViolation(ps.Status) || ps.Status == "STALE" {
+			pathColor = theme.StatusColor(ps.Status)
+			attr = tcell.AttrBold
+		}
 		p.scanTable.SetCell(row, 0, tview.NewTableCell(ps.Path).
-			SetTextColor(theme.FgPrimary))
+			SetTextColor(pathColor).
+			SetAttributes(attr))
+
+		p.scanTable.SetCell(row, 1, tview.NewTableCell(ps.Status).
+			SetTextColor(theme.StatusColor(ps.Status)))
+
+		reason := ps.StatusReason
+		if reason == "" {
+			reason = ps.ErrorMessage
+		}
+		p.scanTable.SetCell(row, 2, tview.NewTableCell(reason).
+			SetTextColor(theme.FgSecondary).
+			SetExpansion(1))
 	}
 
-	// Reset status message
+	// Keep showing the last scan job, otherwise reset status message
+	if p.scanJob != nil {
+		p.showScanJob()
+		return
+	}
 	p.scanStatus.SetText(fmt.Sprintf("%sPress Enter to trigger scan for all paths%s", theme.TagLabel, theme.TagReset))
 }
 
-// triggerScan triggers a manual scan for all paths
+// loadFilesAsync fetches the Files tab listing in the background so that
+// slow walks on large paths do not block the UI
+func (p *PathsDetailProvider) loadFilesAsync() {
+	if p.filesPath == "" {
+		return
+	}
+	p.filesInfo.SetText(fmt.Sprintf("%sListing %s...%s", theme.TagLabel, p.filesPath, theme.TagReset))
+
+	if p.tviewApp == nil {
+		_ = p.loadFiles(context.Background())
+		return
+	}
+	path, sortIdx := p.filesPath, p.filesSort
+	go func() {
+		list, err := p.fetchFiles(context.Background(), path, sortIdx)
+		p.tviewApp.QueueUpdateDraw(func() {
+			// Drop results for a selection the user has already moved away from
+			if path == p.filesPath && sortIdx == p.filesSort {
+				p.applyFiles(list, err)
+			}
+		})
+	}()
+}
+
+// loadFiles fetches the file listing for the selected path.
+// This is a synchronous method for testability; callers should wrap in goroutine if needed
+func (p *PathsDetailProvider) loadFiles(ctx context.Context) error {
+	list, err := p.fetchFiles(ctx, p.filesPath, p.filesSort)
+	p.applyFiles(list, err)
+	return err
+}
+
+// fetchFiles requests a listing for path using the given sort mode
+func (p *PathsDetailProvider) fetchFiles(ctx context.Context, path string, sortIdx int) (*models.PathFileList, error) {
+	if p.apiClient == nil {
+		return nil, fmt.Errorf("apiClient is nil")
+	}
+
+	mode := pathFileSortModes[sortIdx]
+	return p.apiClient.ListPathFiles(ctx, path, models.PathFilesQuery{
+		Sort:  mode.sort,
+		Order: mode.order,
+		Limit: pathFilesLimit,
+	})
+}
+
+// applyFiles stores a listing result and redraws the Files tab
+func (p *PathsDetailProvider) applyFiles(list *models.PathFileList, err error) {
+	p.filesList = list
+	p.updateFilesTab()
+	if err != nil {
+		p.filesInfo.SetText(fmt.Sprintf("%s[red]Failed to list %s: %v%s", theme.TagBold, p.filesPath, err, theme.TagReset))
+	}
+}
+
+// updateFilesTab populates the files table and header from the last listing
+func (p *PathsDetailProvider) updateFilesTab() {
+	// Clear existing rows (keep header)
+	for i := p.filesTable.GetRowCount() - 1; i > 0; i-- {
+		p.filesTable.RemoveRow(i)
+	}
+
+	if p.filesList == nil {
+		return
+	}
+
+	mode := pathFileSortModes[p.filesSort]
+	info := fmt.Sprintf("%s%s%s  %s%d of %s files, %s first (o: change order)%s",
+		theme.TagBold, p.filesList.Path, theme.TagReset,
+		theme.TagLabel, len(p.filesList.Files), ui.FormatNumber(p.filesList.Matched), mode.label, theme.TagReset)
+	if p.filesList.Truncated {
+		info += " [yellow]partial: scan timeout reached[-]"
+	}
+	p.filesInfo.SetText(info)
+
+	for i, f := range p.filesList.Files {
+		row := i + 1
+
+		p.filesTable.SetCell(row, 0, tview.NewTableCell(f.Name).
+			SetTextColor(theme.FgPrimary).
+			SetExpansion(1))
+
+		p.filesTable.SetCell(row, 1, tview.NewTableCell(formatFileSize(f.SizeBytes)).
+			SetTextColor(theme.FgSecondary).
+			SetAlign(tview.AlignRight))
+
+		p.filesTable.SetCell(row, 2, tview.NewTableCell(f.ModTime.Format("2006-01-02 15:04:05")).
+			SetTextColor(theme.FgSecondary).
+			SetAlign(tview.AlignRight))
+
+		p.filesTable.SetCell(row, 3, tview.NewTableCell(f.Owner).
+			SetTextColor(theme.FgSecondary))
+	}
+}
+
+// updatePartitionsTab populates the partitions table, newest partition of each check first
+func (p *PathsDetailProvider) updatePartitionsTab(err error) {
+	// Clear existing rows (keep header)
+	for i := p.partTable.GetRowCount() - 1; i > 0; i-- {
+		p.partTable.RemoveRow(i)
+	}
+
+	if err != nil {
+		p.partTable.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Failed to load partitions: %v", err)).
+			SetTextColor(theme.StatusCritical).
+			SetExpansion(1))
+		return
+	}
+	if len(p.partitions) == 0 {
+		p.partTable.SetCell(1, 0, tview.NewTableCell("(no partition checks configured)").
+			SetTextColor(theme.FgMuted).
+			SetExpansion(1))
+		return
+	}
+
+	for i, part := range p.partitions {
+		row := i + 1
+
+		p.partTable.SetCell(row, 0, tview.NewTableCell(part.Check).
+			SetTextColor(theme.FgPrimary))
+
+		p.partTable.SetCell(row, 1, tview.NewTableCell(part.Partition).
+			SetTextColor(theme.FgSecondary).
+			SetExpansion(1))
+
+		p.partTable.SetCell(row, 2, tview.NewTableCell(part.Status).
+			SetTextColor(theme.StatusColor(part.Status)))
+
+		p.partTable.SetCell(row, 3, tview.NewTableCell(part.Due.Local().Format("2006-01-02 15:04")).
+			SetTextColor(theme.FgSecondary))
+
+		p.partTable.SetCell(row, 4, tview.NewTableCell(formatArrival(part)).
+			SetTextColor(theme.FgSecondary))
+	}
+}
+
+// formatArrival renders when a partition's marker appeared, noting a late arrival
+func formatArrival(part models.PartitionStatus) string {
+	if part.ArrivedAt == nil {
+		return "-"
+	}
+	text := part.ArrivedAt.Local().Format("2006-01-02 15:04")
+	if part.ArrivedLate() {
+		text += " (late)"
+	}
+	return text
+}
+
+// pipelineHeaders are the Pipelines tab columns
+var pipelineHeaders = []string{"Pipeline", "Stage", "Funnel", "Files", "Size", "Oldest", "In/h", "Out/h", "Status"}
+
+// funnelWidth is the width of the widest funnel bar
+const funnelWidth = 30
+
+// stageFlow counts the files that entered and left a pipeline stage
+type stageFlow struct {
+	arrived  int64
+	departed int64
+}
+
+// stageKey identifies a stage across pipelines
+func stageKey(pipeline, stage string) string {
+	return pipeline + "\x00" + stage
+}
+
+// sumStageFlow totals the arrivals and departures of each stage in samples
+func sumStageFlow(samples []models.PipelineStage) map[string]stageFlow {
+	flows := make(map[string]stageFlow)
+	for _, s := range samples {
+		key := stageKey(s.Pipeline, s.Stage)
+		f := flows[key]
+		f.arrived += s.Arrived
+		f.departed += s.Departed
+		flows[key] = f
+	}
+	return flows
+}
+
+// updatePipelinesTab renders each pipeline as a funnel, one row per stage
+// with a bar sized against the pipeline's fullest stage
+func (p *PathsDetailProvider) updatePipelinesTab(err error) {
+	// Clear existing rows (keep header)
+	for i := p.pipeTable.GetRowCount() - 1; i > 0; i-- {
+		p.pipeTable.RemoveRow(i)
+	}
+
+	if err != nil {
+		p.pipeTable.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Failed to load pipelines: %v", err)).
+			SetTextColor(theme.StatusCritical).
+			SetExpansion(1))
+		return
+	}
+	if len(p.pipelines) == 0 {
+		p.pipeTable.SetCell(1, 0, tview.NewTableCell("(no pipelines configured)").
+			SetTextColor(theme.FgMuted).
+			SetExpansion(1))
+		return
+	}
+
+	row := 1
+	for _, pl := range p.pipelines {
+		var widest int64
+		for _, stage := range pl.Stages {
+			widest = max(widest, stage.FileCount)
+		}
+
+		for i, stage := range pl.Stages {
+			name := ""
+			if i == 0 {
+				name = pl.Name
+			}
+			p.pipeTable.SetCell(row, 0, tview.NewTableCell(name).
+				SetTextColor(theme.FgPrimary))
+
+			p.pipeTable.SetCell(row, 1, tview.NewTableCell(stage.Stage).
+				SetTextColor(theme.FgPrimary))
+
+			p.pipeTable.SetCell(row, 2, tview.NewTableCell(formatFunnelBar(stage.FileCount, widest, funnelWidth)).
+				SetTextColor(theme.StatusColor(stage.Status)).
+				SetExpansion(1))
+
+			p.pipeTable.SetCell(row, 3, tview.NewTableCell(ui.FormatNumber(stage.FileCount)).
+				SetTextColor(theme.FgSecondary).
+				SetAlign(tview.AlignRight))
+
+			p.pipeTable.SetCell(row, 4, tview.NewTableCell(formatFileSize(stage.SizeBytes)).
+				SetTextColor(theme.FgSecondary).
+				SetAlign(tview.AlignRight))
+
+			oldest := "-"
+			if stage.OldestFileAt != nil {
+				oldest = formatDuration(time.Duration(stage.OldestAgeSec) * time.Second)
+			}
+			p.pipeTable.SetCell(row, 5, tview.NewTableCell(oldest).
+				SetTextColor(theme.FgSecondary).
+				SetAlign(tview.AlignRight))
+
+			flow := p.throughput[stageKey(pl.Name, stage.Stage)]
+			p.pipeTable.SetCell(row, 6, tview.NewTableCell(ui.FormatNumber(flow.arrived)).
+				SetTextColor(theme.FgSecondary).
+				SetAlign(tview.AlignRight))
+
+			p.pipeTable.SetCell(row, 7, tview.NewTableCell(ui.FormatNumber(flow.departed)).
+				SetTextColor(theme.FgSecondary).
+				SetAlign(tview.AlignRight))
+
+			status := stage.Status
+			if status == "" {
+				status = "-"
+			}
+			p.pipeTable.SetCell(row, 8, tview.NewTableCell(status).
+				SetTextColor(theme.StatusColor(stage.Status)))
+
+			row++
+		}
+	}
+}
+
+// formatFunnelBar renders a bar for count centred in width, scaled against
+// widest so that the stages of a pipeline narrow like a funnel; a stage
+// holding any files gets at least one block
+func formatFunnelBar(count, widest int64, width int) string {
+	filled := 0
+	if widest > 0 {
+		filled = int(count * int64(width) / widest)
+		if filled == 0 && count > 0 {
+			filled = 1
+		}
+	}
+	pad := (width - filled) / 2
+	return strings.Repeat(" ", pad) + strings.Repeat("█", filled) + strings.Repeat(" ", width-filled-pad)
+}
+
+// isSLAViolation reports whether a path status indicates a stuck-file or count violation
+func isSLAViolation(status string) bool {
+	return status == "WARNING" || status == "CRITICAL"
+}
+
+// triggerScan submits a scan job for all paths and polls it for progress
 func (p *PathsDetailProvider) triggerScan() {
 	if p.apiClient == nil {
 		p.scanStatus.SetText(fmt.Sprintf("%s[red]Error: API client not available%s", theme.TagBold, theme.TagReset))
 		return
 	}
+	if p.scanJob != nil && !p.scanJob.Done() {
+		p.showScanJob()
+		return
+	}
 
 	// Collect all paths
 	var paths []string
@@ -193,24 +766,195 @@ func (p *PathsDetailProvider) triggerScan() {
 		return
 	}
 
-	// Show scanning status
-	p.scanStatus.SetText(fmt.Sprintf("%s[yellow]Scanning %d paths...%s", theme.TagBold, len(paths), theme.TagReset))
-	if p.tviewApp != nil {
-		p.tviewApp.Draw()
+	job, err := p.apiClient.TriggerScan(context.Background(), paths)
+	if err != nil {
+		p.scanStatus.SetText(fmt.Sprintf("%s[red]Scan failed: %v%s", theme.TagBold, err, theme.TagReset))
+		return
+	}
+
+	p.scanJob = job
+	p.showScanJob()
+
+	if p.tviewApp != nil && !job.Done() {
+		go p.pollScanJob(job.ID)
+	}
+}
+
+// pollScanJob refreshes the scan status with the job's progress until it finishes
+func (p *PathsDetailProvider) pollScanJob(id string) {
+	for {
+		time.Sleep(scanJobPollInterval)
+
+		job, err := p.apiClient.GetScanJob(context.Background(), id)
+		p.tviewApp.QueueUpdateDraw(func() {
+			if p.scanJob == nil || p.scanJob.ID != id {
+				return
+			}
+			if err != nil {
+				p.scanStatus.SetText(fmt.Sprintf("%s[red]Scan job %s: %v%s", theme.TagBold, id, err, theme.TagReset))
+				return
+			}
+			p.scanJob = job
+			p.showScanJob()
+		})
+		if err != nil || job.Done() {
+			return
+		}
 	}
+}
 
-	// Trigger scan
-	ctx := context.Background()
-	err := p.apiClient.TriggerScan(ctx, paths)
+// cancelScan cancels the current scan job, if one is still queued or running
+func (p *PathsDetailProvider) cancelScan() {
+	if p.apiClient == nil || p.scanJob == nil || p.scanJob.Done() {
+		return
+	}
 
-	// Update status
+	job, err := p.apiClient.CancelScanJob(context.Background(), p.scanJob.ID)
 	if err != nil {
-		p.scanStatus.SetText(fmt.Sprintf("%s[red]Scan failed: %v%s", theme.TagBold, err, theme.TagReset))
-	} else {
-		p.scanStatus.SetText(fmt.Sprintf("%s[green]Scan triggered successfully for %d paths%s", theme.TagBold, len(paths), theme.TagReset))
+		p.scanStatus.SetText(fmt.Sprintf("%s[red]Cancel failed: %v%s", theme.TagBold, err, theme.TagReset))
+		return
+	}
+	p.scanJob = job
+	p.showScanJob()
+}
+
+// showScanJob renders the current scan job's status and live counts
+func (p *PathsDetailProvider) showScanJob() {
+	job := p.scanJob
+	var text string
+	switch job.Status {
+	case models.ScanJobQueued:
+		text = fmt.Sprintf("[yellow]Scan job %s queued for %d paths (x to cancel)", job.ID, len(job.Paths))
+	case models.ScanJobRunning:
+		text = fmt.Sprintf("[yellow]Scan job %s: %d files, %d dirs scanned in %s (x to cancel)",
+			job.ID, job.FilesScanned, job.DirsScanned, job.CurrentPath)
+	case models.ScanJobCompleted:
+		text = fmt.Sprintf("[green]Scan job %s completed: %d files, %d dirs in %d paths",
+			job.ID, job.FilesScanned, job.DirsScanned, len(job.Paths))
+	case models.ScanJobCancelled:
+		text = fmt.Sprintf("[yellow]Scan job %s cancelled after %d files", job.ID, job.FilesScanned)
+	default:
+		text = fmt.Sprintf("[red]Scan job %s failed: %s", job.ID, job.Error)
+	}
+	p.scanStatus.SetText(theme.TagBold + text + theme.TagReset)
+}
+
+// pathTreeRef is the reference of a path node in the Tree tab
+type pathTreeRef struct {
+	path string
+}
+
+// updateTreeTab syncs the Tree tab's path nodes with the latest stats,
+// keeping the expanded breakdown of paths that are still monitored
+func (p *PathsDetailProvider) updateTreeTab() {
+	root := p.treeView.GetRoot()
+	existing := make(map[string]*tview.TreeNode)
+	for _, node := range root.GetChildren() {
+		if ref, ok := node.GetReference().(pathTreeRef); ok {
+			existing[ref.path] = node
+		}
+	}
+
+	root.ClearChildren()
+	for _, ps := range p.data {
+		node, ok := existing[ps.Path]
+		if !ok {
+			node = tview.NewTreeNode("").
+				SetReference(pathTreeRef{path: ps.Path}).
+				SetExpanded(false)
+		}
+		node.SetText(fmt.Sprintf("%s  %s files", ps.Path, ui.FormatNumber(ps.FileCount))).
+			SetColor(theme.StatusColor(ps.Status))
+		root.AddChild(node)
+	}
+
+	// The tree view moves a selection that was removed to the first node
+	if p.treeView.GetCurrentNode() == nil && len(root.GetChildren()) > 0 {
+		p.treeView.SetCurrentNode(root.GetChildren()[0])
+	}
+}
+
+// toggleTreeNode collapses an expanded node. Expanding a path node fetches
+// its latest breakdown; a directory node just shows its children.
+func (p *PathsDetailProvider) toggleTreeNode(node *tview.TreeNode) {
+	if node.IsExpanded() && len(node.GetChildren()) > 0 {
+		node.SetExpanded(false)
+		return
+	}
+	if ref, ok := node.GetReference().(pathTreeRef); ok {
+		p.loadBreakdownAsync(node, ref.path)
+		return
+	}
+	node.SetExpanded(true)
+}
+
+// loadBreakdownAsync fetches a path's breakdown in the background and
+// attaches it below the path's node
+func (p *PathsDetailProvider) loadBreakdownAsync(node *tview.TreeNode, path string) {
+	if p.apiClient == nil {
+		return
 	}
+	if p.tviewApp == nil {
+		usage, err := p.apiClient.GetPathBreakdown(context.Background(), path)
+		applyBreakdown(node, usage, err)
+		return
+	}
+	go func() {
+		usage, err := p.apiClient.GetPathBreakdown(context.Background(), path)
+		p.tviewApp.QueueUpdateDraw(func() {
+			applyBreakdown(node, usage, err)
+		})
+	}()
+}
+
+// applyBreakdown replaces a path node's children with its directory tree,
+// largest directories first
+func applyBreakdown(node *tview.TreeNode, usage []models.PathDirUsage, err error) {
+	node.ClearChildren()
+	node.SetExpanded(true)
+	if err != nil {
+		node.AddChild(treeMessage(fmt.Sprintf("[red]Failed to load breakdown: %v[-]", err)))
+		return
+	}
+
+	children := make(map[string][]models.PathDirUsage)
+	for _, u := range usage {
+		if u.Depth == 0 {
+			continue
+		}
+		parent := filepath.Dir(u.Dir)
+		children[parent] = append(children[parent], u)
+	}
+	if len(children) == 0 {
+		node.AddChild(treeMessage("No subdirectories recorded (set breakdown_depth)"))
+		return
+	}
+	for _, dirs := range children {
+		sort.Slice(dirs, func(i, j int) bool {
+			if dirs[i].SizeBytes != dirs[j].SizeBytes {
+				return dirs[i].SizeBytes > dirs[j].SizeBytes
+			}
+			return dirs[i].Dir < dirs[j].Dir
+		})
+	}
+	addDirNodes(node, ".", children)
+}
 
-	if p.tviewApp != nil {
-		p.tviewApp.Draw()
+// addDirNodes adds the subdirectories of dir below node, collapsed
+func addDirNodes(node *tview.TreeNode, dir string, children map[string][]models.PathDirUsage) {
+	for _, u := range children[dir] {
+		child := tview.NewTreeNode(fmt.Sprintf("%s/  %s  %s files",
+			filepath.Base(u.Dir), formatFileSize(u.SizeBytes), ui.FormatNumber(u.FileCount))).
+			SetColor(theme.FgPrimary).
+			SetExpanded(false)
+		addDirNodes(child, u.Dir, children)
+		node.AddChild(child)
 	}
 }
+
+// treeMessage returns a non-selectable informational tree node
+func treeMessage(text string) *tview.TreeNode {
+	return tview.NewTreeNode(text).
+		SetColor(theme.FgSecondary).
+		SetSelectable(false)
+}
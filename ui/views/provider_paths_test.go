@@ -2,18 +2,20 @@ package views
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/etlmon/etlmon/ui/theme"
 )
 
 func TestPathsProvider_Tabs(t *testing.T) {
 	provider := NewPathsDetailProvider(nil, nil)
 	tabs := provider.Tabs()
 
-	expected := []string{"Stats", "Scan"}
+	expected := []string{"Stats", "Scan", "Files", "Tree", "Partitions", "Pipelines"}
 	if len(tabs) != len(expected) {
 		t.Fatalf("expected %d tabs, got %d", len(expected), len(tabs))
 	}
@@ -139,6 +141,87 @@ func TestPathsProvider_StatsTab(t *testing.T) {
 	}
 }
 
+func TestPathsProvider_StatsTab_BucketColumns(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{
+				Path:   "/data/input",
+				Status: "OK",
+				Buckets: []models.PathBucket{
+					{Name: "errors", Pattern: "*.err", FileCount: 3, SizeBytes: 2048},
+					{Name: "data", Pattern: "*.csv", FileCount: 0},
+				},
+			},
+			{Path: "/data/logs", Status: "OK"},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	want := []string{"Path", "Files", "Dirs", "Duration", "Status", "Freshness", "data", "errors"}
+	if got := provider.statsTable.GetColumnCount(); got != len(want) {
+		t.Fatalf("expected %d columns, got %d", len(want), got)
+	}
+	for col, header := range want {
+		if cell := provider.statsTable.GetCell(0, col); cell.Text != header {
+			t.Errorf("header[%d]: expected %q, got %q", col, header, cell.Text)
+		}
+	}
+	if got := provider.statsTable.GetCell(1, 6).Text; got != "0" {
+		t.Errorf("empty data bucket: got %q, want %q", got, "0")
+	}
+	if got := provider.statsTable.GetCell(1, 7).Text; got != "3 (2.0 KB)" {
+		t.Errorf("errors bucket: got %q, want %q", got, "3 (2.0 KB)")
+	}
+	if got := provider.statsTable.GetCell(2, 7).Text; got != "-" {
+		t.Errorf("path without buckets: got %q, want %q", got, "-")
+	}
+
+	// Bucket columns disappear once no path reports them
+	mock.pathStats = []*models.PathStats{{Path: "/data/logs", Status: "OK"}}
+	_ = provider.Refresh(context.Background(), mock)
+	if got := provider.statsTable.GetColumnCount(); got != 6 {
+		t.Errorf("expected 6 columns after buckets are gone, got %d", got)
+	}
+}
+
+func TestPathsProvider_StatsTab_SortsByStaleness(t *testing.T) {
+	newest := time.Now().Add(-time.Hour)
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{Path: "/data/a_untracked", Status: "OK"},
+			{Path: "/data/b_fresh", Status: "OK", Freshness: models.FreshnessFresh, NewestFileAt: &newest, DataAgeSec: 60},
+			{Path: "/data/c_late", Status: "WARNING", Freshness: models.FreshnessLate, NewestFileAt: &newest, DataAgeSec: 1200},
+			{Path: "/data/d_stale", Status: "CRITICAL", Freshness: models.FreshnessStale, NewestFileAt: &newest, DataAgeSec: 3600},
+			{Path: "/data/e_stale", Status: "CRITICAL", Freshness: models.FreshnessStale, NewestFileAt: &newest, DataAgeSec: 7200},
+			{Path: "/data/f_empty", Status: "CRITICAL", Freshness: models.FreshnessStale},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	want := []struct{ path, freshness string }{
+		{"/data/f_empty", "STALE (no files)"},
+		{"/data/e_stale", "STALE (2h)"},
+		{"/data/d_stale", "STALE (1h)"},
+		{"/data/c_late", "LATE (20m)"},
+		{"/data/b_fresh", "FRESH (1m)"},
+		{"/data/a_untracked", "-"},
+	}
+	for i, w := range want {
+		if got := provider.statsTable.GetCell(i+1, 0).Text; got != w.path {
+			t.Errorf("row %d: expected %s, got %s", i+1, w.path, got)
+		}
+		if got := provider.statsTable.GetCell(i+1, 5).Text; got != w.freshness {
+			t.Errorf("row %d: expected freshness %q, got %q", i+1, w.freshness, got)
+		}
+	}
+}
+
 func TestPathsProvider_ScanTab(t *testing.T) {
 	mock := &mockAPIClient{
 		pathStats: []*models.PathStats{
@@ -166,3 +249,358 @@ func TestPathsProvider_ScanTab(t *testing.T) {
 		t.Fatal("scanFlex is nil")
 	}
 }
+
+func TestPathsProvider_ScanTab_HighlightsSLAViolations(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{Path: "/data/logs", Status: "OK"},
+			{Path: "/data/input", Status: "CRITICAL", StatusReason: "12 files older than 1h0m0s (oldest 3h0m0s)"},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	// Header + 2 rows
+	if rowCount := provider.scanTable.GetRowCount(); rowCount != 3 {
+		t.Fatalf("expected 3 rows, got %d", rowCount)
+	}
+
+	okCell := provider.scanTable.GetCell(1, 0)
+	if fg, _, _ := okCell.Style.Decompose(); fg != theme.FgPrimary {
+		t.Errorf("healthy path should use primary color, got %v", fg)
+	}
+
+	stuckCell := provider.scanTable.GetCell(2, 0)
+	if fg, _, _ := stuckCell.Style.Decompose(); fg != theme.StatusCritical {
+		t.Errorf("critical path should be highlighted red, got %v", fg)
+	}
+
+	reasonCell := provider.scanTable.GetCell(2, 2)
+	if !strings.Contains(reasonCell.Text, "older than") {
+		t.Errorf("expected reason to be shown, got %q", reasonCell.Text)
+	}
+}
+
+func TestPathsProvider_ScanTab_TriggerAndCancelJob(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{Path: "/data/logs", Status: "OK"},
+			{Path: "/data/input", Status: "OK"},
+		},
+		scanJob: &models.ScanJob{ID: "a1b2", Paths: []string{"/data/logs", "/data/input"}, Status: models.ScanJobQueued},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	provider.triggerScan()
+
+	if len(mock.scannedPaths) != 2 {
+		t.Errorf("expected scan job for 2 paths, got %v", mock.scannedPaths)
+	}
+	if text := provider.scanStatus.GetText(true); !strings.Contains(text, "a1b2") || !strings.Contains(text, "queued") {
+		t.Errorf("expected queued job to be shown, got %q", text)
+	}
+
+	// A refresh keeps showing the job instead of the idle prompt
+	_ = provider.Refresh(context.Background(), mock)
+	if text := provider.scanStatus.GetText(true); !strings.Contains(text, "a1b2") {
+		t.Errorf("refresh should keep job status, got %q", text)
+	}
+
+	mock.scanJob = &models.ScanJob{ID: "a1b2", Status: models.ScanJobCancelled, FilesScanned: 10}
+	provider.cancelScan()
+
+	if mock.cancelledJob != "a1b2" {
+		t.Errorf("expected job a1b2 to be cancelled, got %q", mock.cancelledJob)
+	}
+	if text := provider.scanStatus.GetText(true); !strings.Contains(text, "cancelled") {
+		t.Errorf("expected cancelled status, got %q", text)
+	}
+}
+
+func TestPathsProvider_FilesTab_DrillDown(t *testing.T) {
+	modTime := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{Path: "/data/input", FileCount: 40000, Status: "OK"},
+		},
+		pathFiles: &models.PathFileList{
+			Path: "/data/input",
+			Files: []models.PathFile{
+				{Name: "partner_a/big.csv", SizeBytes: 2 * 1024 * 1024, ModTime: modTime, Owner: "etl"},
+				{Name: "small.csv", SizeBytes: 512, ModTime: modTime, Owner: "root"},
+			},
+			Matched:   40000,
+			Truncated: true,
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	if provider.TabContent(2) == nil {
+		t.Fatal("Files tab returned nil primitive")
+	}
+
+	// Select the path (simulates Enter on the Stats tab)
+	provider.filesPath = "/data/input"
+	if err := provider.loadFiles(context.Background()); err != nil {
+		t.Fatalf("loadFiles failed: %v", err)
+	}
+
+	if mock.filesQuery.Sort != models.PathFileSortSize || mock.filesQuery.Order != "desc" {
+		t.Errorf("expected largest-first query, got %+v", mock.filesQuery)
+	}
+
+	// Header + 2 rows
+	if rowCount := provider.filesTable.GetRowCount(); rowCount != 3 {
+		t.Fatalf("expected 3 rows, got %d", rowCount)
+	}
+	if name := provider.filesTable.GetCell(1, 0).Text; name != "partner_a/big.csv" {
+		t.Errorf("expected first file partner_a/big.csv, got %q", name)
+	}
+	if size := provider.filesTable.GetCell(1, 1).Text; size != "2.0 MB" {
+		t.Errorf("expected size 2.0 MB, got %q", size)
+	}
+	if owner := provider.filesTable.GetCell(2, 3).Text; owner != "root" {
+		t.Errorf("expected owner root, got %q", owner)
+	}
+
+	info := provider.filesInfo.GetText(true)
+	if !strings.Contains(info, "40,000") || !strings.Contains(info, "partial") {
+		t.Errorf("expected match count and partial marker in header, got %q", info)
+	}
+}
+
+func TestPathsProvider_FilesTab_Error(t *testing.T) {
+	mock := &mockAPIClient{filesErr: context.DeadlineExceeded}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	provider.filesPath = "/data/input"
+
+	if err := provider.loadFiles(context.Background()); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if !strings.Contains(provider.filesInfo.GetText(true), "Failed to list") {
+		t.Errorf("expected error message, got %q", provider.filesInfo.GetText(true))
+	}
+}
+
+func TestPathsProvider_TreeTab_ExpandsBreakdown(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{
+			{Path: "/data/input", FileCount: 30, Status: "OK"},
+			{Path: "/data/logs", FileCount: 5, Status: "OK"},
+		},
+		breakdown: []models.PathDirUsage{
+			{Dir: ".", FileCount: 30, SizeBytes: 3072},
+			{Dir: "partner_a", Depth: 1, FileCount: 10, SizeBytes: 1024},
+			{Dir: "partner_b", Depth: 1, FileCount: 20, SizeBytes: 2048},
+			{Dir: "partner_b/2026", Depth: 2, FileCount: 20, SizeBytes: 2048},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	if provider.TabContent(3) != provider.treeView {
+		t.Fatal("Tree tab should be the tree view")
+	}
+	paths := provider.treeView.GetRoot().GetChildren()
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 path nodes, got %d", len(paths))
+	}
+	if !strings.HasPrefix(paths[0].GetText(), "/data/input") {
+		t.Errorf("first node: got %q", paths[0].GetText())
+	}
+
+	// Enter on a path loads its breakdown, largest directory first
+	provider.toggleTreeNode(paths[0])
+	if mock.breakdownPath != "/data/input" {
+		t.Errorf("expected breakdown request for /data/input, got %q", mock.breakdownPath)
+	}
+	dirs := paths[0].GetChildren()
+	if len(dirs) != 2 || !paths[0].IsExpanded() {
+		t.Fatalf("expected 2 expanded child dirs, got %d", len(dirs))
+	}
+	if !strings.HasPrefix(dirs[0].GetText(), "partner_b/") || !strings.Contains(dirs[0].GetText(), "2.0 KB") {
+		t.Errorf("expected partner_b first with its size, got %q", dirs[0].GetText())
+	}
+	if len(dirs[0].GetChildren()) != 1 || dirs[0].IsExpanded() {
+		t.Error("expected partner_b to hold one collapsed subdirectory")
+	}
+
+	// Directory nodes expand and collapse without reloading
+	provider.toggleTreeNode(dirs[0])
+	if !dirs[0].IsExpanded() {
+		t.Error("expected partner_b to expand")
+	}
+	provider.toggleTreeNode(paths[0])
+	if paths[0].IsExpanded() {
+		t.Error("expected path node to collapse")
+	}
+
+	// A refresh keeps the loaded breakdown
+	_ = provider.Refresh(context.Background(), mock)
+	if got := provider.treeView.GetRoot().GetChildren()[0]; got != paths[0] || len(got.GetChildren()) != 2 {
+		t.Error("expected refresh to keep the path node and its breakdown")
+	}
+}
+
+func TestPathsProvider_TreeTab_NoBreakdown(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{{Path: "/data/logs", Status: "OK"}},
+		breakdown: []models.PathDirUsage{{Dir: "."}},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	_ = provider.Refresh(context.Background(), mock)
+
+	node := provider.treeView.GetRoot().GetChildren()[0]
+	provider.toggleTreeNode(node)
+	children := node.GetChildren()
+	if len(children) != 1 || !strings.Contains(children[0].GetText(), "breakdown_depth") {
+		t.Errorf("expected a breakdown_depth hint, got %v", children)
+	}
+
+	mock.breakdownErr = errors.New("connection refused")
+	provider.toggleTreeNode(node) // collapse
+	provider.toggleTreeNode(node) // reload
+	if children := node.GetChildren(); len(children) != 1 || !strings.Contains(children[0].GetText(), "connection refused") {
+		t.Errorf("expected the load error, got %v", children)
+	}
+}
+
+func TestPathsProvider_PartitionsTab(t *testing.T) {
+	due := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	onTime := due.Add(-time.Hour)
+	late := due.Add(2 * time.Hour)
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{{Path: "/data/input", Status: "OK"}},
+		partitions: []models.PartitionStatus{
+			{Check: "events", Partition: "/warehouse/events/dt=2026-10-19", Status: models.PartitionPending, Due: due.AddDate(0, 0, 1)},
+			{Check: "events", Partition: "/warehouse/events/dt=2026-10-18", Status: models.PartitionPresent, Due: due, ArrivedAt: &onTime},
+			{Check: "events", Partition: "/warehouse/events/dt=2026-10-17", Status: models.PartitionPresent, Due: due.AddDate(0, 0, -1), ArrivedAt: &late},
+			{Check: "events", Partition: "/warehouse/events/dt=2026-10-16", Status: models.PartitionMissing, Due: due.AddDate(0, 0, -2)},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	table := provider.partTable
+	if table.GetRowCount() != 5 {
+		t.Fatalf("expected 5 rows (header + 4 partitions), got %d", table.GetRowCount())
+	}
+	if got := table.GetCell(2, 4).Text; strings.Contains(got, "late") || got == "-" {
+		t.Errorf("expected on-time arrival without late note, got %q", got)
+	}
+	if got := table.GetCell(3, 4).Text; !strings.HasSuffix(got, "(late)") {
+		t.Errorf("expected late arrival to be noted, got %q", got)
+	}
+	if got := table.GetCell(4, 4).Text; got != "-" {
+		t.Errorf("expected no arrival for a missing partition, got %q", got)
+	}
+	if got := table.GetCell(4, 2).Text; got != models.PartitionMissing {
+		t.Errorf("expected MISSING status, got %q", got)
+	}
+}
+
+func TestPathsProvider_PartitionsTab_ErrorKeepsStats(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats:     []*models.PathStats{{Path: "/data/input", Status: "OK"}},
+		partitionsErr: errors.New("404 not found"),
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected partition errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.statsTable.GetRowCount() != 2 {
+		t.Errorf("expected the stats tab to be populated, got %d rows", provider.statsTable.GetRowCount())
+	}
+	if got := provider.partTable.GetCell(1, 0).Text; !strings.Contains(got, "404 not found") {
+		t.Errorf("expected the error in the partitions tab, got %q", got)
+	}
+}
+
+func TestPathsProvider_PipelinesTab_RendersFunnel(t *testing.T) {
+	oldest := time.Now().Add(-90 * time.Minute)
+	mock := &mockAPIClient{
+		pathStats: []*models.PathStats{{Path: "/data/inbox", Status: "OK"}},
+		pipelines: []models.Pipeline{{
+			Name: "ingest",
+			Stages: []models.PipelineStage{
+				{Pipeline: "ingest", Stage: "inbox", FileCount: 40, OldestFileAt: &oldest, OldestAgeSec: 5400, Status: "OK"},
+				{Pipeline: "ingest", Stage: "processing", FileCount: 10, Status: "WARNING"},
+				{Pipeline: "ingest", Stage: "error", FileCount: 0},
+			},
+		}},
+		pipeHistory: []models.PipelineStage{
+			{Pipeline: "ingest", Stage: "inbox", Arrived: 12, Departed: 10},
+			{Pipeline: "ingest", Stage: "inbox", Arrived: 8, Departed: 9},
+		},
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	table := provider.pipeTable
+	if table.GetRowCount() != 4 {
+		t.Fatalf("expected 4 rows (header + 3 stages), got %d", table.GetRowCount())
+	}
+	if got := table.GetCell(1, 0).Text; got != "ingest" {
+		t.Errorf("expected the pipeline name on its first stage, got %q", got)
+	}
+	if got := table.GetCell(2, 0).Text; got != "" {
+		t.Errorf("expected the pipeline name only once, got %q", got)
+	}
+
+	inbox := strings.Count(table.GetCell(1, 2).Text, "█")
+	processing := strings.Count(table.GetCell(2, 2).Text, "█")
+	if inbox != funnelWidth || processing >= inbox || processing == 0 {
+		t.Errorf("expected the funnel to narrow from %d blocks, got %d then %d", funnelWidth, inbox, processing)
+	}
+	if got := strings.Count(table.GetCell(3, 2).Text, "█"); got != 0 {
+		t.Errorf("expected an empty stage to have no bar, got %d blocks", got)
+	}
+
+	if got := table.GetCell(1, 5).Text; got == "-" {
+		t.Errorf("expected the oldest file age, got %q", got)
+	}
+	if got := table.GetCell(1, 6).Text; got != "20" {
+		t.Errorf("expected 20 arrivals in the last hour, got %q", got)
+	}
+	if got := table.GetCell(1, 7).Text; got != "19" {
+		t.Errorf("expected 19 departures in the last hour, got %q", got)
+	}
+	if got := table.GetCell(3, 5).Text; got != "-" {
+		t.Errorf("expected no age for an empty stage, got %q", got)
+	}
+}
+
+func TestPathsProvider_PipelinesTab_ErrorKeepsStats(t *testing.T) {
+	mock := &mockAPIClient{
+		pathStats:    []*models.PathStats{{Path: "/data/inbox", Status: "OK"}},
+		pipelinesErr: errors.New("404 not found"),
+	}
+
+	provider := NewPathsDetailProvider(mock, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected pipeline errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.statsTable.GetRowCount() != 2 {
+		t.Errorf("expected the stats tab to be populated, got %d rows", provider.statsTable.GetRowCount())
+	}
+	if got := provider.pipeTable.GetCell(1, 0).Text; !strings.Contains(got, "404 not found") {
+		t.Errorf("expected the error in the pipelines tab, got %q", got)
+	}
+}
@@ -4,31 +4,144 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
 	"github.com/etlmon/etlmon/ui"
 	"github.com/etlmon/etlmon/ui/theme"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 // ProcessDetailProvider implements DetailProvider for process monitoring
 type ProcessDetailProvider struct {
 	data        []*models.ProcessInfo
-	listTable   *tview.Table // List tab: all processes
-	topCPUTable *tview.Table // Top CPU tab: sorted by CPU%
-	topMemTable *tview.Table // Top Memory tab: sorted by Memory
+	listFlex    *tview.Flex     // List tab: process table with the details pane below
+	listTable   *tview.Table    // List tab: all processes
+	detailsView *tview.TextView // List tab: open files and sockets of the selected process
+	detailsPID  int             // process shown in the details pane, 0 when closed
+	topCPUTable *tview.Table    // Top CPU tab: sorted by CPU%
+	topMemTable *tview.Table    // Top Memory tab: sorted by Memory
+	watches     []*models.ProcessWatch
+	history     map[string][]models.ProcessWatchSample // per watch, oldest first
+	watchTable  *tview.Table                           // Watches tab: configured process watches
+	treeView    *tview.TreeView                        // Tree tab: processes below their parents
+	groups      []*models.ProcessGroup
+	groupTable  *tview.Table       // Groups tab: totals per systemd unit and container
+	collapsed   map[int]bool       // PIDs collapsed in the Tree tab, kept across refreshes
+	apiClient   ui.APIClient       // needed for GetProcessDetails
+	tviewApp    *tview.Application // for QueueUpdateDraw
 }
 
+// detailsHeight is the height of the process details pane when open
+const detailsHeight = 14
+
+// Watch trends cover the last day, drawn in at most trendWidth bars
+const (
+	trendPeriod = 24 * time.Hour
+	trendWidth  = 12
+)
+
 // NewProcessDetailProvider creates a new process detail provider
-func NewProcessDetailProvider() *ProcessDetailProvider {
+func NewProcessDetailProvider(client ui.APIClient, app *tview.Application) *ProcessDetailProvider {
 	p := &ProcessDetailProvider{
 		listTable:   createProcessTable(),
 		topCPUTable: createProcessTable(),
 		topMemTable: createProcessTable(),
+		watchTable:  createWatchTable(),
+		groupTable:  createGroupTable(),
+		collapsed:   make(map[int]bool),
+		apiClient:   client,
+		tviewApp:    app,
 	}
+
+	// List tab; the details pane stays collapsed until a process is opened
+	p.detailsView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	p.detailsView.SetBorder(true).
+		SetTitle(" Details ")
+	p.listFlex = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(p.listTable, 0, 1, true).
+		AddItem(p.detailsView, 0, 0, false)
+
+	// Enter on a process opens its open files and sockets, Esc closes them
+	p.listTable.SetSelectedFunc(func(row, column int) {
+		if row < 1 || row > len(p.data) {
+			return
+		}
+		p.loadDetailsAsync(p.data[row-1].PID)
+	})
+	p.listTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape && p.detailsPID != 0 {
+			p.closeDetails()
+			return nil
+		}
+		return event
+	})
+
+	// Tree tab; process nodes are filled in by Refresh
+	treeRoot := tview.NewTreeNode("Processes").
+		SetColor(theme.TableHeader).
+		SetSelectable(false)
+	p.treeView = tview.NewTreeView().
+		SetRoot(treeRoot).
+		SetTopLevel(1)
+
+	// Enter on a process expands or collapses its children
+	p.treeView.SetSelectedFunc(p.toggleTreeNode)
 	return p
 }
 
+// createWatchTable creates the process watch table with headers
+func createWatchTable() *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Watch", "Status", "Running", "Expected", "Restarts", "CPU (24h)", "RSS (24h)", "PIDs", "Reason"} {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetSelectable(false)
+		if i == 8 { // Reason column expands
+			cell.SetExpansion(1)
+		}
+		table.SetCell(0, i, cell)
+	}
+
+	return table
+}
+
+// createGroupTable creates the unit and container table with headers
+func createGroupTable() *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"Group", "Kind", "Procs", "CPU%", "RSS", "cg Memory", "cg CPU%", "Cgroup"}
+	for i, header := range headers {
+		cell := tview.NewTableCell(header).
+			SetTextColor(theme.TableHeader).
+			SetAttributes(theme.TableHeaderAttr).
+			SetSelectable(false)
+		if i >= 2 && i <= 6 {
+			cell.SetAlign(tview.AlignRight)
+		}
+		if i == 7 { // Cgroup column expands
+			cell.SetExpansion(1)
+		}
+		table.SetCell(0, i, cell)
+	}
+
+	return table
+}
+
 // createProcessTable creates a process table with headers
 func createProcessTable() *tview.Table {
 	table := tview.NewTable().
@@ -37,7 +150,7 @@ func createProcessTable() *tview.Table {
 		SetFixed(1, 0)
 
 	// Set headers
-	headers := []string{"PID", "User", "CPU%", "Memory", "Status", "Elapsed", "Name"}
+	headers := []string{"PID", "User", "CPU%", "Memory", "Status", "Elapsed", "Name", "Command"}
 	aligns := []int{
 		tview.AlignRight, // PID
 		tview.AlignLeft,  // User
@@ -46,6 +159,7 @@ func createProcessTable() *tview.Table {
 		tview.AlignLeft,  // Status
 		tview.AlignRight, // Elapsed
 		tview.AlignLeft,  // Name
+		tview.AlignLeft,  // Command
 	}
 
 	for i, header := range headers {
@@ -54,7 +168,7 @@ func createProcessTable() *tview.Table {
 			SetAttributes(theme.TableHeaderAttr).
 			SetAlign(aligns[i]).
 			SetSelectable(false)
-		if i == 6 { // Name column expands
+		if i == 7 { // Command column expands
 			cell.SetExpansion(1)
 		}
 		table.SetCell(0, i, cell)
@@ -65,18 +179,24 @@ func createProcessTable() *tview.Table {
 
 // Tabs returns the list of tab names
 func (p *ProcessDetailProvider) Tabs() []string {
-	return []string{"List", "Top CPU", "Top Memory"}
+	return []string{"List", "Top CPU", "Top Memory", "Watches", "Tree", "Groups"}
 }
 
 // TabContent returns the tview Primitive for the given tab index
 func (p *ProcessDetailProvider) TabContent(tabIndex int) tview.Primitive {
 	switch tabIndex {
 	case 0:
-		return p.listTable
+		return p.listFlex
 	case 1:
 		return p.topCPUTable
 	case 2:
 		return p.topMemTable
+	case 3:
+		return p.watchTable
+	case 4:
+		return p.treeView
+	case 5:
+		return p.groupTable
 	default:
 		return nil
 	}
@@ -94,6 +214,23 @@ func (p *ProcessDetailProvider) Refresh(ctx context.Context, client ui.APIClient
 	p.populateTopCPUTable()
 	p.populateTopMemTable()
 
+	// An older node without watches only affects the Watches tab
+	watches, err := client.GetProcessWatches(ctx)
+	p.watches = watches
+	if err == nil {
+		// Without history the trend columns stay empty
+		samples, histErr := client.GetProcessHistory(ctx, "", time.Now().Add(-trendPeriod))
+		p.history = groupWatchSamples(samples, histErr)
+	}
+	p.populateWatchTable(err)
+
+	tree, err := client.GetProcessTree(ctx, 0)
+	p.populateTree(tree, err)
+
+	groups, err := client.GetProcessGroups(ctx)
+	p.groups = groups
+	p.populateGroupTable(err)
+
 	return nil
 }
 
@@ -167,6 +304,393 @@ func (p *ProcessDetailProvider) populateTopMemTable() {
 	}
 }
 
+// populateWatchTable fills the Watches tab with each watch's status
+func (p *ProcessDetailProvider) populateWatchTable(err error) {
+	// Clear existing rows (keep header)
+	for i := p.watchTable.GetRowCount() - 1; i > 0; i-- {
+		p.watchTable.RemoveRow(i)
+	}
+
+	if err != nil {
+		p.watchTable.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Failed to load process watches: %v", err)).
+			SetTextColor(theme.StatusCritical).
+			SetExpansion(1))
+		return
+	}
+	if len(p.watches) == 0 {
+		p.watchTable.SetCell(1, 0, tview.NewTableCell("(no process watches configured)").
+			SetTextColor(theme.FgMuted).
+			SetExpansion(1))
+		return
+	}
+
+	for i, w := range p.watches {
+		row := i + 1
+
+		p.watchTable.SetCell(row, 0, tview.NewTableCell(w.Name).
+			SetTextColor(theme.FgPrimary))
+
+		p.watchTable.SetCell(row, 1, tview.NewTableCell(w.Status).
+			SetTextColor(theme.StatusColor(w.Status)))
+
+		p.watchTable.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", w.Count)).
+			SetTextColor(theme.FgPrimary).
+			SetAlign(tview.AlignRight))
+
+		p.watchTable.SetCell(row, 3, tview.NewTableCell(formatExpectedCount(w)).
+			SetTextColor(theme.FgSecondary).
+			SetAlign(tview.AlignRight))
+
+		// Restarts in the last 24h, highlighted so that flapping stands out
+		restartColor := theme.FgSecondary
+		if w.Restarts > 0 {
+			restartColor = theme.StatusWarning
+		}
+		p.watchTable.SetCell(row, 4, tview.NewTableCell(formatRestarts(w)).
+			SetTextColor(restartColor).
+			SetAlign(tview.AlignRight))
+
+		cpuTrend, rssTrend := formatWatchTrends(p.history[w.Name])
+		p.watchTable.SetCell(row, 5, tview.NewTableCell(cpuTrend).
+			SetTextColor(theme.FgPrimary))
+
+		p.watchTable.SetCell(row, 6, tview.NewTableCell(rssTrend).
+			SetTextColor(theme.FgPrimary))
+
+		p.watchTable.SetCell(row, 7, tview.NewTableCell(formatPIDs(w.PIDs)).
+			SetTextColor(theme.FgSecondary))
+
+		p.watchTable.SetCell(row, 8, tview.NewTableCell(w.Reason).
+			SetTextColor(theme.FgSecondary).
+			SetExpansion(1))
+	}
+}
+
+// closeDetails collapses the details pane
+func (p *ProcessDetailProvider) closeDetails() {
+	p.detailsPID = 0
+	p.detailsView.Clear()
+	p.listFlex.ResizeItem(p.detailsView, 0, 0)
+}
+
+// loadDetailsAsync fetches a process's details in the background and shows
+// them in the details pane
+func (p *ProcessDetailProvider) loadDetailsAsync(pid int) {
+	if p.apiClient == nil {
+		return
+	}
+	p.detailsPID = pid
+	p.listFlex.ResizeItem(p.detailsView, detailsHeight, 0)
+	p.detailsView.SetTitle(fmt.Sprintf(" Details: PID %d ", pid))
+	if p.tviewApp == nil {
+		details, err := p.apiClient.GetProcessDetails(context.Background(), pid)
+		p.showDetails(pid, details, err)
+		return
+	}
+	p.detailsView.SetText(fmt.Sprintf("%sLoading open files of PID %d...%s", theme.TagMuted, pid, theme.TagReset))
+	go func() {
+		details, err := p.apiClient.GetProcessDetails(context.Background(), pid)
+		p.tviewApp.QueueUpdateDraw(func() {
+			p.showDetails(pid, details, err)
+		})
+	}()
+}
+
+// showDetails renders a process's FD usage, listening ports, sockets and
+// open files in the details pane, unless another process was opened since
+func (p *ProcessDetailProvider) showDetails(pid int, d *models.ProcessDetails, err error) {
+	if pid != p.detailsPID {
+		return
+	}
+	p.detailsView.ScrollToBeginning()
+	if err != nil {
+		p.detailsView.SetText(fmt.Sprintf("%s[red]Failed to load details of PID %d: %s%s",
+			theme.TagBold, pid, tview.Escape(err.Error()), theme.TagReset))
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s %s%s%s\n", theme.TagBold, tview.Escape(d.Name), theme.TagReset,
+		theme.TagSecondary, tview.Escape(d.Cmdline), theme.TagReset)
+	fmt.Fprintf(&b, "%sFDs:%s %s\n", theme.TagLabel, theme.TagReset, formatFDUsage(d))
+
+	ports := "-"
+	if len(d.ListenPorts) > 0 {
+		parts := make([]string, len(d.ListenPorts))
+		for i, port := range d.ListenPorts {
+			parts[i] = strconv.Itoa(port)
+		}
+		ports = strings.Join(parts, ", ")
+	}
+	fmt.Fprintf(&b, "%sListening:%s %s\n", theme.TagLabel, theme.TagReset, ports)
+
+	fmt.Fprintf(&b, "\n%sSockets (%d)%s\n", theme.TagLabel, len(d.Sockets), theme.TagReset)
+	for _, sock := range d.Sockets {
+		peer := sock.RemoteAddr
+		if peer == "" {
+			peer = "*"
+		}
+		fmt.Fprintf(&b, "%5d  %-4s %-11s %s -> %s\n", sock.FD, sock.Protocol, sock.State, sock.LocalAddr, peer)
+	}
+
+	fmt.Fprintf(&b, "\n%sFiles (%d)%s\n", theme.TagLabel, len(d.Files), theme.TagReset)
+	for _, f := range d.Files {
+		fmt.Fprintf(&b, "%5d  %s\n", f.FD, tview.Escape(f.Path))
+	}
+	if d.Truncated {
+		fmt.Fprintf(&b, "%s  ... %d more descriptors not listed%s\n", theme.TagMuted,
+			d.OpenFDs-len(d.Files)-len(d.Sockets), theme.TagReset)
+	}
+
+	p.detailsView.SetText(b.String())
+}
+
+// formatFDUsage renders open descriptors against the soft limit, colored
+// as the limit gets close
+func formatFDUsage(d *models.ProcessDetails) string {
+	if d.MaxFDs == 0 {
+		return fmt.Sprintf("%d open (unlimited)", d.OpenFDs)
+	}
+	pct := float64(d.OpenFDs) / float64(d.MaxFDs) * 100
+	color := ""
+	switch {
+	case pct >= 95:
+		color = "[red]"
+	case pct >= 80:
+		color = "[yellow]"
+	}
+	text := fmt.Sprintf("%d / %d (%.0f%%)", d.OpenFDs, d.MaxFDs, pct)
+	if color != "" {
+		text = color + text + "[-]"
+	}
+	return text
+}
+
+// processTreeRef is the reference of a process node in the Tree tab
+type processTreeRef struct {
+	pid int
+}
+
+// populateTree rebuilds the Tree tab from the latest process tree, keeping
+// the collapsed processes and the selection of the previous refresh
+func (p *ProcessDetailProvider) populateTree(nodes []*models.ProcessNode, err error) {
+	selected := -1
+	if node := p.treeView.GetCurrentNode(); node != nil {
+		if ref, ok := node.GetReference().(processTreeRef); ok {
+			selected = ref.pid
+		}
+	}
+
+	root := p.treeView.GetRoot()
+	root.ClearChildren()
+	if err != nil {
+		root.AddChild(treeMessage(fmt.Sprintf("Failed to load process tree: %v", err)).
+			SetColor(theme.StatusCritical))
+		return
+	}
+	if len(nodes) == 0 {
+		root.AddChild(treeMessage("(no processes)").
+			SetColor(theme.FgMuted))
+		return
+	}
+
+	var current *tview.TreeNode
+	var add func(parent *tview.TreeNode, n *models.ProcessNode)
+	add = func(parent *tview.TreeNode, n *models.ProcessNode) {
+		node := tview.NewTreeNode(formatProcessNode(n)).
+			SetReference(processTreeRef{pid: n.PID}).
+			SetColor(processNodeColor(n)).
+			SetExpanded(!p.collapsed[n.PID])
+		if n.PID == selected {
+			current = node
+		}
+		for _, child := range n.Children {
+			add(node, child)
+		}
+		parent.AddChild(node)
+	}
+	for _, n := range nodes {
+		add(root, n)
+	}
+
+	if current == nil {
+		current = root.GetChildren()[0]
+	}
+	p.treeView.SetCurrentNode(current)
+}
+
+// toggleTreeNode expands or collapses a process node and remembers it
+func (p *ProcessDetailProvider) toggleTreeNode(node *tview.TreeNode) {
+	ref, ok := node.GetReference().(processTreeRef)
+	if !ok || len(node.GetChildren()) == 0 {
+		return
+	}
+	node.SetExpanded(!node.IsExpanded())
+	if node.IsExpanded() {
+		delete(p.collapsed, ref.pid)
+	} else {
+		p.collapsed[ref.pid] = true
+	}
+}
+
+// formatProcessNode renders a process with its own usage and, when it has
+// children, the totals of its subtree
+func formatProcessNode(n *models.ProcessNode) string {
+	text := fmt.Sprintf("%s (%d)  %.1f%%  %s", n.Name, n.PID, n.CPUPercent, ui.FormatBytes(uint64(n.MemRSS)))
+	if n.Descendants > 0 {
+		text += fmt.Sprintf("  [tree: %d procs  %.1f%%  %s]",
+			n.Descendants+1, n.TreeCPU, ui.FormatBytes(uint64(n.TreeMemRSS)))
+	}
+	return tview.Escape(text)
+}
+
+// processNodeColor colors a process by the CPU use of its subtree, with the
+// same thresholds as the process tables
+func processNodeColor(n *models.ProcessNode) tcell.Color {
+	switch {
+	case n.TreeCPU > 80:
+		return theme.StatusCritical
+	case n.TreeCPU > 50:
+		return theme.StatusWarning
+	default:
+		return theme.FgPrimary
+	}
+}
+
+// populateGroupTable fills the Groups tab with the totals of each systemd
+// unit and container
+func (p *ProcessDetailProvider) populateGroupTable(err error) {
+	// Clear existing rows (keep header)
+	for i := p.groupTable.GetRowCount() - 1; i > 0; i-- {
+		p.groupTable.RemoveRow(i)
+	}
+
+	if err != nil {
+		p.groupTable.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Failed to load process groups: %v", err)).
+			SetTextColor(theme.StatusCritical).
+			SetExpansion(1))
+		return
+	}
+	if len(p.groups) == 0 {
+		p.groupTable.SetCell(1, 0, tview.NewTableCell("(no systemd units or containers found)").
+			SetTextColor(theme.FgMuted).
+			SetExpansion(1))
+		return
+	}
+
+	for i, g := range p.groups {
+		row := i + 1
+
+		p.groupTable.SetCell(row, 0, tview.NewTableCell(g.Name).
+			SetTextColor(theme.FgPrimary))
+
+		p.groupTable.SetCell(row, 1, tview.NewTableCell(g.Kind).
+			SetTextColor(theme.FgSecondary))
+
+		p.groupTable.SetCell(row, 2, tview.NewTableCell(strconv.Itoa(g.Processes)).
+			SetTextColor(theme.FgPrimary).
+			SetAlign(tview.AlignRight))
+
+		p.groupTable.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f", g.CPUPercent)).
+			SetTextColor(theme.FgPrimary).
+			SetAlign(tview.AlignRight))
+
+		p.groupTable.SetCell(row, 4, tview.NewTableCell(ui.FormatBytes(uint64(g.MemRSS))).
+			SetTextColor(theme.FgPrimary).
+			SetAlign(tview.AlignRight))
+
+		// cgroup v2 accounting; absent on v1 hosts
+		cgMem, cgCPU := "-", "-"
+		if g.MemoryCurrent > 0 {
+			cgMem = ui.FormatBytes(uint64(g.MemoryCurrent))
+			cgCPU = fmt.Sprintf("%.1f", g.CgroupCPUPercent)
+		}
+		p.groupTable.SetCell(row, 5, tview.NewTableCell(cgMem).
+			SetTextColor(theme.FgSecondary).
+			SetAlign(tview.AlignRight))
+
+		p.groupTable.SetCell(row, 6, tview.NewTableCell(cgCPU).
+			SetTextColor(theme.FgSecondary).
+			SetAlign(tview.AlignRight))
+
+		p.groupTable.SetCell(row, 7, tview.NewTableCell(g.Cgroup).
+			SetTextColor(theme.FgMuted).
+			SetExpansion(1))
+	}
+}
+
+// formatExpectedCount renders a watch's expected instance range
+func formatExpectedCount(w *models.ProcessWatch) string {
+	switch {
+	case w.Max == 0:
+		return fmt.Sprintf("≥%d", w.Min)
+	case w.Max == w.Min:
+		return fmt.Sprintf("%d", w.Min)
+	default:
+		return fmt.Sprintf("%d-%d", w.Min, w.Max)
+	}
+}
+
+// formatRestarts renders a watch's restart count with how long ago the
+// latest restart was
+func formatRestarts(w *models.ProcessWatch) string {
+	if w.Restarts == 0 || w.LastRestartAt == nil {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%s ago)", w.Restarts, formatDuration(time.Since(*w.LastRestartAt)))
+}
+
+// groupWatchSamples splits process watch history by watch; a failed
+// fetch yields no history
+func groupWatchSamples(samples []models.ProcessWatchSample, err error) map[string][]models.ProcessWatchSample {
+	history := make(map[string][]models.ProcessWatchSample)
+	if err != nil {
+		return history
+	}
+	for _, s := range samples {
+		history[s.Watch] = append(history[s.Watch], s)
+	}
+	return history
+}
+
+// formatWatchTrends renders a watch's CPU and RSS history as sparklines
+// followed by the latest value
+func formatWatchTrends(samples []models.ProcessWatchSample) (cpu, rss string) {
+	if len(samples) == 0 {
+		return "-", "-"
+	}
+	cpuValues := make([]float64, len(samples))
+	rssValues := make([]float64, len(samples))
+	for i, s := range samples {
+		cpuValues[i] = s.CPUPercent
+		rssValues[i] = float64(s.MemRSS)
+	}
+	last := samples[len(samples)-1]
+	cpu = fmt.Sprintf("%s %.1f%%", ui.FormatSparkline(cpuValues, trendWidth), last.CPUPercent)
+	rss = fmt.Sprintf("%s %s", ui.FormatSparkline(rssValues, trendWidth), ui.FormatBytes(uint64(last.MemRSS)))
+	return cpu, rss
+}
+
+// formatPIDs lists up to five PIDs, summarizing the rest
+func formatPIDs(pids []int) string {
+	const shown = 5
+	if len(pids) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, shown)
+	for i, pid := range pids {
+		if i == shown {
+			break
+		}
+		parts = append(parts, strconv.Itoa(pid))
+	}
+	text := strings.Join(parts, ",")
+	if len(pids) > shown {
+		text += fmt.Sprintf(" +%d", len(pids)-shown)
+	}
+	return text
+}
+
 // addProcessRow adds a process row to the table with color coding
 func (p *ProcessDetailProvider) addProcessRow(table *tview.Table, row int, proc *models.ProcessInfo) {
 	// PID
@@ -214,6 +738,10 @@ func (p *ProcessDetailProvider) addProcessRow(table *tview.Table, row int, proc
 
 	// Name
 	table.SetCell(row, 6, tview.NewTableCell(proc.Name).
-		SetTextColor(theme.FgPrimary).
+		SetTextColor(theme.FgPrimary))
+
+	// Command line, empty for kernel threads and where ps is used
+	table.SetCell(row, 7, tview.NewTableCell(proc.Cmdline).
+		SetTextColor(theme.FgSecondary).
 		SetExpansion(1))
 }
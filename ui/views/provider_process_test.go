@@ -2,18 +2,20 @@ package views
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/etlmon/etlmon/pkg/models"
+	"github.com/gdamore/tcell/v2"
 )
 
 func TestProcessProvider_Tabs(t *testing.T) {
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	tabs := provider.Tabs()
 
-	expected := []string{"List", "Top CPU", "Top Memory"}
+	expected := []string{"List", "Top CPU", "Top Memory", "Watches", "Tree", "Groups"}
 	if len(tabs) != len(expected) {
 		t.Fatalf("expected %d tabs, got %d", len(expected), len(tabs))
 	}
@@ -51,7 +53,7 @@ func TestProcessProvider_Refresh_Success(t *testing.T) {
 		},
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	err := provider.Refresh(context.Background(), mock)
 	if err != nil {
 		t.Fatalf("Refresh failed: %v", err)
@@ -68,7 +70,7 @@ func TestProcessProvider_Refresh_Error(t *testing.T) {
 		procErr: context.DeadlineExceeded,
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	err := provider.Refresh(context.Background(), mock)
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -84,6 +86,7 @@ func TestProcessProvider_ListTab(t *testing.T) {
 			{
 				PID:        1234,
 				Name:       "test-process",
+				Cmdline:    "/usr/bin/test-process --once",
 				User:       "root",
 				CPUPercent: 45.5,
 				MemRSS:     1024 * 1024 * 100,
@@ -102,7 +105,7 @@ func TestProcessProvider_ListTab(t *testing.T) {
 		},
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	_ = provider.Refresh(context.Background(), mock)
 
 	// Get List tab content
@@ -123,7 +126,7 @@ func TestProcessProvider_ListTab(t *testing.T) {
 	}
 
 	// Check header cells
-	headers := []string{"PID", "User", "CPU%", "Memory", "Status", "Elapsed", "Name"}
+	headers := []string{"PID", "User", "CPU%", "Memory", "Status", "Elapsed", "Name", "Command"}
 	for col, expectedHeader := range headers {
 		cell := provider.listTable.GetCell(0, col)
 		if cell == nil {
@@ -151,6 +154,10 @@ func TestProcessProvider_ListTab(t *testing.T) {
 	if nameCell.Text != "test-process" {
 		t.Errorf("expected Name 'test-process', got %q", nameCell.Text)
 	}
+
+	if cmdCell := provider.listTable.GetCell(1, 7); cmdCell.Text != "/usr/bin/test-process --once" {
+		t.Errorf("expected the command line, got %q", cmdCell.Text)
+	}
 }
 
 func TestProcessProvider_ListTab_ColorCoding(t *testing.T) {
@@ -186,7 +193,7 @@ func TestProcessProvider_ListTab_ColorCoding(t *testing.T) {
 		},
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	_ = provider.Refresh(context.Background(), mock)
 
 	// Check CPU color coding
@@ -250,7 +257,7 @@ func TestProcessProvider_TopCPUTab(t *testing.T) {
 		},
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	_ = provider.Refresh(context.Background(), mock)
 
 	// Get Top CPU tab content
@@ -308,7 +315,7 @@ func TestProcessProvider_TopMemTab(t *testing.T) {
 		},
 	}
 
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	_ = provider.Refresh(context.Background(), mock)
 
 	// Get Top Memory tab content
@@ -372,7 +379,7 @@ func TestProcessProvider_TopTabs_Limit10(t *testing.T) {
 	}
 
 	mock := &mockAPIClient{procInfo: procs}
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 	_ = provider.Refresh(context.Background(), mock)
 
 	// Top CPU tab should have max 10 + header = 11 rows
@@ -389,10 +396,309 @@ func TestProcessProvider_TopTabs_Limit10(t *testing.T) {
 }
 
 func TestProcessProvider_OnSelect(t *testing.T) {
-	provider := NewProcessDetailProvider()
+	provider := NewProcessDetailProvider(nil, nil)
 
 	// OnSelect should not panic
 	provider.OnSelect(0)
 	provider.OnSelect(1)
 	provider.OnSelect(2)
 }
+
+func TestProcessProvider_WatchesTab(t *testing.T) {
+	restarted := time.Now().Add(-5 * time.Minute)
+	mock := &mockAPIClient{
+		procWatches: []*models.ProcessWatch{
+			{Name: "etl_worker", Min: 1, Count: 7, PIDs: []int{20, 21, 22, 23, 24, 25, 26}, Status: models.ProcessWatchUp},
+			{Name: "loaders", Min: 2, Max: 4, Count: 1, PIDs: []int{4242}, Status: models.ProcessWatchDegraded, Reason: "1 running, expected at least 2", Restarts: 3, LastRestartAt: &restarted},
+			{Name: "scheduler", Min: 1, Max: 1, PIDs: []int{}, Status: models.ProcessWatchDown, Reason: "no matching process"},
+		},
+		procHistory: []models.ProcessWatchSample{
+			{Watch: "etl_worker", CPUPercent: 10, MemRSS: 1 << 30},
+			{Watch: "etl_worker", CPUPercent: 10, MemRSS: 2 << 30},
+			{Watch: "etl_worker", CPUPercent: 30, MemRSS: 3 << 30},
+		},
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	table := provider.watchTable
+	if table.GetRowCount() != 4 {
+		t.Fatalf("expected 4 rows (header + 3 watches), got %d", table.GetRowCount())
+	}
+	tests := []struct {
+		row, col int
+		want     string
+	}{
+		{1, 3, "≥1"},
+		{1, 4, "0"},
+		{1, 5, "▁▁█ 30.0%"},
+		{1, 6, "▁▄█ 3.00 GB"},
+		{1, 7, "20,21,22,23,24 +2"},
+		{2, 5, "-"},
+		{2, 1, "DEGRADED"},
+		{2, 3, "2-4"},
+		{3, 2, "0"},
+		{3, 3, "1"},
+		{3, 7, "-"},
+		{3, 8, "no matching process"},
+	}
+	for _, tt := range tests {
+		if got := table.GetCell(tt.row, tt.col).Text; got != tt.want {
+			t.Errorf("cell [%d,%d]: expected %q, got %q", tt.row, tt.col, tt.want, got)
+		}
+	}
+	if got := table.GetCell(2, 4).Text; !strings.HasPrefix(got, "3 (") || !strings.HasSuffix(got, " ago)") {
+		t.Errorf("expected 3 restarts with the last one's age, got %q", got)
+	}
+}
+
+func TestProcessProvider_WatchesTab_ErrorKeepsList(t *testing.T) {
+	mock := &mockAPIClient{
+		procInfo:   []*models.ProcessInfo{{PID: 1, Name: "init", Status: "sleeping"}},
+		watchesErr: errors.New("404 not found"),
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected watch errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.listTable.GetRowCount() != 2 {
+		t.Errorf("expected the list tab to be populated, got %d rows", provider.listTable.GetRowCount())
+	}
+	if got := provider.watchTable.GetCell(1, 0).Text; !strings.Contains(got, "404 not found") {
+		t.Errorf("expected the error in the watches tab, got %q", got)
+	}
+}
+
+func TestProcessProvider_WatchesTab_HistoryErrorKeepsWatches(t *testing.T) {
+	mock := &mockAPIClient{
+		procWatches: []*models.ProcessWatch{{Name: "etl_worker", Min: 1, Count: 1, PIDs: []int{20}, Status: models.ProcessWatchUp}},
+		historyErr:  errors.New("404 not found"),
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected history errors not to fail the refresh, got %v", err)
+	}
+
+	if got := provider.watchTable.GetCell(1, 1).Text; got != models.ProcessWatchUp {
+		t.Errorf("expected the watch status, got %q", got)
+	}
+	if got := provider.watchTable.GetCell(1, 5).Text; got != "-" {
+		t.Errorf("expected no CPU trend, got %q", got)
+	}
+}
+
+func TestProcessProvider_TreeTab_KeepsCollapsedNodes(t *testing.T) {
+	tree := []*models.ProcessNode{{
+		PID: 1, Name: "systemd", CPUPercent: 0.5, MemRSS: 1 << 20, TreeCPU: 90.5, TreeMemRSS: 3 << 20, Descendants: 2,
+		Children: []*models.ProcessNode{{
+			PID: 200, PPID: 1, Name: "airflow", CPUPercent: 10, MemRSS: 1 << 20, TreeCPU: 90, TreeMemRSS: 2 << 20, Descendants: 1,
+			Children: []*models.ProcessNode{{PID: 201, PPID: 200, Name: "python3", CPUPercent: 80, MemRSS: 1 << 20, TreeCPU: 80, TreeMemRSS: 1 << 20}},
+		}},
+	}}
+	mock := &mockAPIClient{
+		procInfo: []*models.ProcessInfo{{PID: 1, Name: "systemd"}},
+		procTree: tree,
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if provider.TabContent(4) != provider.treeView {
+		t.Fatal("Tree tab should be the tree view")
+	}
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	roots := provider.treeView.GetRoot().GetChildren()
+	if len(roots) != 1 {
+		t.Fatalf("expected 1 root process, got %d", len(roots))
+	}
+	if got := roots[0].GetText(); !strings.HasPrefix(got, "systemd (1)  0.5%") || !strings.Contains(got, "tree: 3 procs  90.5%") {
+		t.Errorf("unexpected root text %q", got)
+	}
+	airflow := roots[0].GetChildren()[0]
+	if len(airflow.GetChildren()) != 1 || !airflow.IsExpanded() {
+		t.Fatalf("expected airflow expanded with its worker")
+	}
+	if got := airflow.GetChildren()[0].GetText(); strings.Contains(got, "tree:") {
+		t.Errorf("expected no subtree totals for a leaf, got %q", got)
+	}
+
+	// Collapsing survives a refresh
+	provider.treeView.SetCurrentNode(airflow)
+	provider.toggleTreeNode(airflow)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	airflow = provider.treeView.GetRoot().GetChildren()[0].GetChildren()[0]
+	if airflow.IsExpanded() {
+		t.Error("expected airflow to stay collapsed after a refresh")
+	}
+	if provider.treeView.GetCurrentNode() != airflow {
+		t.Error("expected the selection to stay on airflow")
+	}
+
+	provider.toggleTreeNode(airflow)
+	if !airflow.IsExpanded() || provider.collapsed[200] {
+		t.Error("expected airflow to expand again")
+	}
+}
+
+func TestProcessProvider_TreeTab_ErrorKeepsList(t *testing.T) {
+	mock := &mockAPIClient{
+		procInfo: []*models.ProcessInfo{{PID: 1, Name: "init", Status: "sleeping"}},
+		treeErr:  errors.New("404 not found"),
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected tree errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.listTable.GetRowCount() != 2 {
+		t.Errorf("expected the list tab to be populated, got %d rows", provider.listTable.GetRowCount())
+	}
+	nodes := provider.treeView.GetRoot().GetChildren()
+	if len(nodes) != 1 || !strings.Contains(nodes[0].GetText(), "404 not found") {
+		t.Errorf("expected the error in the tree tab, got %d nodes", len(nodes))
+	}
+}
+
+func TestProcessProvider_DetailsPane(t *testing.T) {
+	mock := &mockAPIClient{
+		procInfo: []*models.ProcessInfo{{PID: 4242, Name: "java", Status: "sleeping"}},
+		procDetails: &models.ProcessDetails{
+			PID: 4242, Name: "java", Cmdline: "java -jar /opt/etl/loader.jar", OpenFDs: 900, MaxFDs: 1024,
+			Files: []models.ProcessFile{{FD: 3, Path: "/data/in/orders.csv"}},
+			Sockets: []models.ProcessSocket{
+				{FD: 4, Protocol: "tcp", State: "LISTEN", LocalAddr: "0.0.0.0:8080"},
+				{FD: 5, Protocol: "tcp", State: "ESTABLISHED", LocalAddr: "10.0.0.5:51234", RemoteAddr: "10.0.0.9:5432"},
+			},
+			ListenPorts: []int{8080},
+		},
+	}
+
+	provider := NewProcessDetailProvider(mock, nil)
+	if provider.TabContent(0) != provider.listFlex {
+		t.Fatal("List tab should hold the table and the details pane")
+	}
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	provider.loadDetailsAsync(4242)
+
+	if mock.detailsPID != 4242 {
+		t.Errorf("expected details of PID 4242 to be requested, got %d", mock.detailsPID)
+	}
+	text := provider.detailsView.GetText(true)
+	for _, want := range []string{
+		"900 / 1024 (88%)",
+		"Listening: 8080",
+		"Sockets (2)",
+		"ESTABLISHED 10.0.0.5:51234 -> 10.0.0.9:5432",
+		"LISTEN      0.0.0.0:8080 -> *",
+		"3  /data/in/orders.csv",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the details pane, got:\n%s", want, text)
+		}
+	}
+
+	// Esc closes the pane
+	provider.listTable.GetInputCapture()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if provider.detailsPID != 0 || provider.detailsView.GetText(true) != "" {
+		t.Error("expected Esc to close the details pane")
+	}
+}
+
+func TestProcessProvider_DetailsPane_Error(t *testing.T) {
+	mock := &mockAPIClient{detailsErr: errors.New("process not found")}
+
+	provider := NewProcessDetailProvider(mock, nil)
+	provider.loadDetailsAsync(4242)
+
+	if got := provider.detailsView.GetText(true); !strings.Contains(got, "Failed to load details of PID 4242: process not found") {
+		t.Errorf("expected the error in the details pane, got %q", got)
+	}
+}
+
+func TestFormatFDUsage(t *testing.T) {
+	tests := []struct {
+		details models.ProcessDetails
+		want    string
+	}{
+		{models.ProcessDetails{OpenFDs: 12, MaxFDs: 1024}, "12 / 1024 (1%)"},
+		{models.ProcessDetails{OpenFDs: 1000, MaxFDs: 1024}, "[red]1000 / 1024 (98%)[-]"},
+		{models.ProcessDetails{OpenFDs: 12}, "12 open (unlimited)"},
+	}
+	for _, tt := range tests {
+		if got := formatFDUsage(&tt.details); got != tt.want {
+			t.Errorf("formatFDUsage(%d/%d) = %q, want %q", tt.details.OpenFDs, tt.details.MaxFDs, got, tt.want)
+		}
+	}
+}
+
+func TestProcessProvider_GroupsTab(t *testing.T) {
+	mock := &mockAPIClient{
+		procInfo: []*models.ProcessInfo{{PID: 10, Name: "java", Unit: "nifi.service"}},
+		procGroups: []*models.ProcessGroup{
+			{Kind: models.ProcessGroupContainer, Name: "4f1c2d3e4b5a", Cgroup: "/docker/4f1c2d3e4b5a", Processes: 1, CPUPercent: 40, MemRSS: 200 << 20},
+			{Kind: models.ProcessGroupUnit, Name: "nifi.service", Cgroup: "/system.slice/nifi.service", Processes: 2, CPUPercent: 35, MemRSS: 1 << 30, MemoryCurrent: 3 << 30, CgroupCPUPercent: 36.5},
+		},
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if provider.TabContent(5) != provider.groupTable {
+		t.Fatal("Groups tab should be the group table")
+	}
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	table := provider.groupTable
+	tests := []struct {
+		row, col int
+		want     string
+	}{
+		{1, 0, "4f1c2d3e4b5a"},
+		{1, 1, "container"},
+		{1, 5, "-"},
+		{1, 6, "-"},
+		{2, 0, "nifi.service"},
+		{2, 2, "2"},
+		{2, 3, "35.0"},
+		{2, 5, "3.00 GB"},
+		{2, 6, "36.5"},
+		{2, 7, "/system.slice/nifi.service"},
+	}
+	for _, tt := range tests {
+		if got := table.GetCell(tt.row, tt.col).Text; got != tt.want {
+			t.Errorf("cell [%d,%d]: expected %q, got %q", tt.row, tt.col, tt.want, got)
+		}
+	}
+}
+
+func TestProcessProvider_GroupsTab_ErrorKeepsList(t *testing.T) {
+	mock := &mockAPIClient{
+		procInfo:  []*models.ProcessInfo{{PID: 1, Name: "init", Status: "sleeping"}},
+		groupsErr: errors.New("404 not found"),
+	}
+
+	provider := NewProcessDetailProvider(nil, nil)
+	if err := provider.Refresh(context.Background(), mock); err != nil {
+		t.Fatalf("expected group errors not to fail the refresh, got %v", err)
+	}
+
+	if provider.listTable.GetRowCount() != 2 {
+		t.Errorf("expected the list tab to be populated, got %d rows", provider.listTable.GetRowCount())
+	}
+	if got := provider.groupTable.GetCell(1, 0).Text; !strings.Contains(got, "404 not found") {
+		t.Errorf("expected the error in the groups tab, got %q", got)
+	}
+}
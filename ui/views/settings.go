@@ -704,12 +704,14 @@ func (v *SettingsView) editPathEntry() {
 			depth = 10
 		}
 		if pathStr != "" {
-			v.cfg.Paths[idx] = config.PathConfig{
-				Path:         pathStr,
-				ScanInterval: time.Duration(interval) * time.Second,
-				MaxDepth:     depth,
-				Timeout:      30 * time.Second,
+			// Keep settings not editable here (excludes, SLA expectations, ...)
+			entry.Path = pathStr
+			entry.ScanInterval = time.Duration(interval) * time.Second
+			entry.MaxDepth = depth
+			if entry.Timeout == 0 {
+				entry.Timeout = 30 * time.Second
 			}
+			v.cfg.Paths[idx] = entry
 			v.dirty = true
 			v.refreshPathTable()
 			v.setStatus("Modified (press 's' to save)", false)
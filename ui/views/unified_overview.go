@@ -37,7 +37,7 @@ func NewUnifiedOverview(app *tview.Application, client ui.APIClient) *UnifiedOve
 	// Initialize providers for each category
 	uo.providers[0] = NewFSDetailProvider()                    // FS
 	uo.providers[1] = NewPathsDetailProvider(client, app)      // Paths
-	uo.providers[2] = NewProcessDetailProvider()               // Process
+	uo.providers[2] = NewProcessDetailProvider(client, app)    // Process
 	uo.providers[3] = NewLogsDetailProvider(client, app)       // Logs
 
 	// Set up layout: CategoryList (20 fixed) + DetailPanel (flex)